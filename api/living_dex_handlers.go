@@ -0,0 +1,107 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/services"
+)
+
+func (s *Server) handleGetLivingDex(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	dex, err := s.livingDex.GetLivingDex(currentUser(r).ID, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, dex)
+}
+
+func (s *Server) handleSetLivingDexCaught(w http.ResponseWriter, r *http.Request) {
+	s.setLivingDexCaught(w, r, true)
+}
+
+func (s *Server) handleDeleteLivingDexCaught(w http.ResponseWriter, r *http.Request) {
+	s.setLivingDexCaught(w, r, false)
+}
+
+func (s *Server) setLivingDexCaught(w http.ResponseWriter, r *http.Request, caught bool) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	speciesID, err := pathID(r, "speciesId")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	progress, err := s.livingDex.SetCaught(currentUser(r).ID, id, speciesID, caught)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, progress)
+}
+
+func (s *Server) handleGetLivingDexMissing(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	versionID, err := strconv.Atoi(r.URL.Query().Get("versionId"))
+	if err != nil || versionID < 1 {
+		writeError(w, fmt.Errorf("%w: versionId query parameter is required", services.ErrInvalidInput))
+		return
+	}
+
+	missing, err := s.livingDex.GetMissing(currentUser(r).ID, id, versionID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, missing)
+}
+
+func (s *Server) handleGetLivingDexChecklist(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// Buffered so a failure can still be reported as a JSON error
+	var buf bytes.Buffer
+	if err := s.livingDex.WriteChecklist(&buf, currentUser(r).ID, id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"pokedex-%d.csv\"", id))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+func (s *Server) handleGetGameProgress(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	progress, err := s.livingDex.GetGameProgress(currentUser(r).ID, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, progress)
+}
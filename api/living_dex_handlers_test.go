@@ -0,0 +1,87 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLivingDexEndpoints(t *testing.T) {
+	s := setupServer(t)
+	ash := register(t, s, "ash")
+	gary := register(t, s, "gary")
+
+	var progress dto.DexProgress
+	status := do(t, s, "PUT", "/api/living-dex/pokedexes/2/species/25", ash, nil, &progress)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, dto.DexProgress{Pokedex: dto.Pokedex{ID: 2, Name: "kanto", RegionName: "kanto"}, Caught: 1, Total: 4, Percent: 25}, progress)
+
+	t.Run("Living dex", func(t *testing.T) {
+		var dex dto.LivingDex
+		require.Equal(t, http.StatusOK, do(t, s, "GET", "/api/living-dex/pokedexes/2", ash, nil, &dex))
+		require.Len(t, dex.Entries, 4)
+		assert.Equal(t, dto.DexEntry{EntryNumber: 25, SpeciesID: 25, Name: "pikachu", Caught: true}, dex.Entries[3])
+		assert.False(t, dex.Entries[0].Caught)
+
+		var other dto.LivingDex
+		require.Equal(t, http.StatusOK, do(t, s, "GET", "/api/living-dex/pokedexes/2", gary, nil, &other))
+		assert.Zero(t, other.Caught)
+
+		assert.Equal(t, http.StatusNotFound, do(t, s, "GET", "/api/living-dex/pokedexes/99", ash, nil, nil))
+	})
+
+	t.Run("Species not in pokedex", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, do(t, s, "PUT", "/api/living-dex/pokedexes/2/species/150", ash, nil, nil))
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		var missing []dto.MissingEntry
+		require.Equal(t, http.StatusOK, do(t, s, "GET", "/api/living-dex/pokedexes/2/missing?versionId=1", ash, nil, &missing))
+		require.Len(t, missing, 3)
+
+		assert.Equal(t, "bulbasaur", missing[0].Name)
+		assert.Empty(t, missing[0].Availability)
+		assert.Equal(t, []string{"ivysaur"}, missing[0].EvolutionFamily)
+
+		assert.Equal(t, []dto.VersionAvailability{
+			{VersionID: 1, Version: "red", Locations: []string{"Route 4"}},
+			{VersionID: 2, Version: "blue", Locations: []string{"Route 4"}},
+		}, missing[1].Availability)
+		assert.Empty(t, missing[1].ExclusiveTo)
+
+		assert.Equal(t, "ekans", missing[2].Name)
+		assert.Equal(t, []string{"red"}, missing[2].ExclusiveTo)
+
+		assert.Equal(t, http.StatusBadRequest, do(t, s, "GET", "/api/living-dex/pokedexes/2/missing", ash, nil, nil))
+	})
+
+	t.Run("Game progress", func(t *testing.T) {
+		var game dto.GameProgress
+		require.Equal(t, http.StatusOK, do(t, s, "GET", "/api/living-dex/versions/2", ash, nil, &game))
+		assert.Equal(t, "blue", game.Version.Name)
+		require.Len(t, game.Pokedexes, 1)
+		assert.Equal(t, 1, game.Caught)
+		assert.Equal(t, 4, game.Total)
+	})
+
+	t.Run("Checklist", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/living-dex/pokedexes/2/checklist", nil)
+		req.Header.Set("Authorization", "Bearer "+ash)
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "number,species,caught\n1,bulbasaur,false\n2,ivysaur,false\n23,ekans,false\n25,pikachu,true\n", rec.Body.String())
+	})
+
+	t.Run("Uncatch", func(t *testing.T) {
+		var progress dto.DexProgress
+		require.Equal(t, http.StatusOK, do(t, s, "DELETE", "/api/living-dex/pokedexes/2/species/25", ash, nil, &progress))
+		assert.Zero(t, progress.Caught)
+	})
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/services"
+)
+
+func (s *Server) handleListNuzlockes(w http.ResponseWriter, r *http.Request) {
+	runs, err := s.nuzlockes.List(currentUser(r).ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, runs)
+}
+
+func (s *Server) handleCreateNuzlocke(w http.ResponseWriter, r *http.Request) {
+	var in services.NuzlockeRunInput
+	if err := decodeJSON(w, r, &in); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	run, err := s.nuzlockes.Create(currentUser(r).ID, &in)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, run)
+}
+
+func (s *Server) handleGetNuzlocke(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	run, err := s.nuzlockes.Get(currentUser(r).ID, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, run)
+}
+
+func (s *Server) handleDeleteNuzlocke(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := s.nuzlockes.Delete(currentUser(r).ID, id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleGetNuzlockeStats(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	stats, err := s.nuzlockes.Stats(currentUser(r).ID, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
+
+func (s *Server) handleRecordNuzlockeEncounter(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var in services.NuzlockeEncounterInput
+	if err := decodeJSON(w, r, &in); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	run, err := s.nuzlockes.RecordEncounter(currentUser(r).ID, id, &in)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, run)
+}
+
+func (s *Server) handleUpdateNuzlockeEncounter(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	encounterID, err := pathID(r, "encounterId")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var in services.NuzlockeEncounterUpdate
+	if err := decodeJSON(w, r, &in); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	run, err := s.nuzlockes.UpdateEncounter(currentUser(r).ID, id, encounterID, &in)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, run)
+}
+
+func (s *Server) handleDeleteNuzlockeEncounter(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	encounterID, err := pathID(r, "encounterId")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	run, err := s.nuzlockes.DeleteEncounter(currentUser(r).ID, id, encounterID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, run)
+}
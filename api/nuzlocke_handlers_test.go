@@ -0,0 +1,73 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNuzlockeEndpoints(t *testing.T) {
+	s := setupServer(t)
+	ash := register(t, s, "ash")
+	gary := register(t, s, "gary")
+
+	var run dto.NuzlockeRun
+	in := services.NuzlockeRunInput{VersionID: 1, Name: "Red hardcore", Rules: dto.NuzlockeRules{DupesClause: true, ShinyClause: true}}
+	require.Equal(t, http.StatusCreated, do(t, s, "POST", "/api/nuzlockes", ash, in, &run))
+	assert.True(t, run.Rules.DupesClause)
+
+	var updated dto.NuzlockeRun
+	encounter := services.NuzlockeEncounterInput{LocationID: 2, SpeciesID: 2, Nickname: "Vine", Level: 20}
+	require.Equal(t, http.StatusCreated, do(t, s, "POST", "/api/nuzlockes/1/encounters", ash, encounter, &updated))
+	require.Len(t, updated.Encounters, 1)
+	assert.Equal(t, "Route 4", updated.Encounters[0].LocationName)
+	assert.Equal(t, dto.NuzlockeAlive, updated.Encounters[0].Status)
+
+	t.Run("Rules", func(t *testing.T) {
+		// Second encounter on the same route
+		status := do(t, s, "POST", "/api/nuzlockes/1/encounters", ash, services.NuzlockeEncounterInput{LocationID: 2, SpeciesID: 23, Level: 8}, nil)
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		// Bulbasaur is a dupe of Ivysaur
+		status = do(t, s, "POST", "/api/nuzlockes/1/encounters", ash, services.NuzlockeEncounterInput{LocationID: 1, SpeciesID: 1, Level: 5}, nil)
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		// Unknown location
+		status = do(t, s, "POST", "/api/nuzlockes/1/encounters", ash, services.NuzlockeEncounterInput{LocationID: 99, SpeciesID: 25, Level: 5}, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+		// Route 29 is in johto, red takes place in kanto
+		status = do(t, s, "POST", "/api/nuzlockes/1/encounters", ash, services.NuzlockeEncounterInput{LocationID: 3, SpeciesID: 25, Level: 5}, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Death and stats", func(t *testing.T) {
+		var p dto.NuzlockeRun
+		status := do(t, s, "POST", "/api/nuzlockes/1/encounters", ash, services.NuzlockeEncounterInput{LocationID: 1, SpeciesID: 25, Level: 4}, &p)
+		require.Equal(t, http.StatusCreated, status)
+		pikachuID := p.Encounters[1].ID
+
+		update := services.NuzlockeEncounterUpdate{Nickname: "Sparky", Level: 9, Status: dto.NuzlockeDead, CauseOfDeath: "Weedle poison"}
+		require.Equal(t, http.StatusOK, do(t, s, "PUT", fmt.Sprintf("/api/nuzlockes/1/encounters/%d", pikachuID), ash, update, nil))
+
+		var stats dto.NuzlockeStats
+		require.Equal(t, http.StatusOK, do(t, s, "GET", "/api/nuzlockes/1/stats", ash, nil, &stats))
+		assert.Equal(t, 1, stats.Alive)
+		assert.Equal(t, 1, stats.Dead)
+		require.Len(t, stats.Graveyard, 1)
+		assert.Equal(t, "Sparky", stats.Graveyard[0].Nickname)
+		assert.Equal(t, map[string]int{"Weedle poison": 1}, stats.DeathsByCause)
+	})
+
+	t.Run("Other users get 404", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, do(t, s, "GET", "/api/nuzlockes/1", gary, nil, nil))
+		assert.Equal(t, http.StatusNotFound, do(t, s, "GET", "/api/nuzlockes/1/stats", gary, nil, nil))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, do(t, s, "DELETE", "/api/nuzlockes/1", ash, nil, nil))
+		assert.Equal(t, http.StatusNotFound, do(t, s, "GET", "/api/nuzlockes/1", ash, nil, nil))
+	})
+}
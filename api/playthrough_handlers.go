@@ -0,0 +1,143 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/services"
+)
+
+type speciesStatusRequest struct {
+	Status string `json:"status"` // "seen" or "caught"
+}
+
+func (s *Server) handleListPlaythroughs(w http.ResponseWriter, r *http.Request) {
+	playthroughs, err := s.playthroughs.List(currentUser(r).ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, playthroughs)
+}
+
+func (s *Server) handleCreatePlaythrough(w http.ResponseWriter, r *http.Request) {
+	var in services.PlaythroughInput
+	if err := decodeJSON(w, r, &in); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	p, err := s.playthroughs.Create(currentUser(r).ID, &in)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, p)
+}
+
+func (s *Server) handleGetPlaythrough(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	p, err := s.playthroughs.Get(currentUser(r).ID, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) handleUpdatePlaythrough(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var in services.PlaythroughInput
+	if err := decodeJSON(w, r, &in); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	p, err := s.playthroughs.Update(currentUser(r).ID, id, &in)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) handleDeletePlaythrough(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := s.playthroughs.Delete(currentUser(r).ID, id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleSetSpeciesStatus(w http.ResponseWriter, r *http.Request) {
+	var in speciesStatusRequest
+	if err := decodeJSON(w, r, &in); err != nil {
+		writeError(w, err)
+		return
+	}
+	if in.Status == "" {
+		writeError(w, fmt.Errorf("%w: status is required, use DELETE to mark a species as unseen", services.ErrInvalidInput))
+		return
+	}
+	s.setSpeciesStatus(w, r, in.Status)
+}
+
+func (s *Server) handleDeleteSpeciesStatus(w http.ResponseWriter, r *http.Request) {
+	s.setSpeciesStatus(w, r, "")
+}
+
+func (s *Server) setSpeciesStatus(w http.ResponseWriter, r *http.Request, status string) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	speciesID, err := pathID(r, "speciesId")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	p, err := s.playthroughs.SetSpeciesStatus(currentUser(r).ID, id, speciesID, status)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) handleSetParty(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var party []dto.PartyMember
+	if err := decodeJSON(w, r, &party); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	p, err := s.playthroughs.SetParty(currentUser(r).ID, id, party)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
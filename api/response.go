@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/db"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/services"
+)
+
+// maxBodyBytes limits the size of JSON request bodies
+const maxBodyBytes = 1 << 20
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger().Error("failed to encode response", "err", err)
+	}
+}
+
+// writeError maps service and repository errors to status codes, unknown
+// errors are logged and hidden behind a 500
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrUnauthorized):
+		status = http.StatusUnauthorized
+	case errors.Is(err, services.ErrRuleViolation):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, db.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, db.ErrConflict):
+		status = http.StatusConflict
+	}
+
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		logger().Error("internal error", "err", err)
+		message = "internal server error"
+	}
+	writeJSON(w, status, errorResponse{Error: message})
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("%w: malformed JSON body: %v", services.ErrInvalidInput, err)
+	}
+	return nil
+}
+
+// pathID parses a numeric path value like {id}
+func pathID(r *http.Request, name string) (int, error) {
+	id, err := strconv.Atoi(r.PathValue(name))
+	if err != nil || id < 1 {
+		return 0, fmt.Errorf("%w: invalid %s %q", services.ErrInvalidInput, name, r.PathValue(name))
+	}
+	return id, nil
+}
+
+// queryID parses a required numeric query parameter like ?versionId=
+func queryID(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	id, err := strconv.Atoi(value)
+	if err != nil || id < 1 {
+		return 0, fmt.Errorf("%w: %s query parameter is required, got %q", services.ErrInvalidInput, name, value)
+	}
+	return id, nil
+}
+
+// assetURL turns the path of a stored image into an absolute URL, clients add
+// ?w= for a thumbnail. Paths outside the asset store give "".
+func (s *Server) assetURL(path string) string {
+	name, ok := s.assets.Name(path)
+	if path == "" || !ok {
+		return ""
+	}
+	return s.publicURL + "/assets/" + name
+}
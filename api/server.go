@@ -0,0 +1,116 @@
+// Package api exposes the companion over HTTP as a JSON API.
+package api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/assets"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/metrics"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/services"
+)
+
+type Server struct {
+	auth         *services.AuthService
+	playthroughs *services.PlaythroughService
+	livingDex    *services.LivingDexService
+	versions     *services.VersionService
+	nuzlockes    *services.NuzlockeService
+	breeding     *services.BreedingPlanner
+	battles      *services.BattlePlanner
+	assets       *assets.Store
+	publicURL    string // Base of absolute asset URLs, e.g. "https://pokemon.example.com"
+	mux          *http.ServeMux
+}
+
+func NewServer(auth *services.AuthService, playthroughs *services.PlaythroughService, livingDex *services.LivingDexService, versions *services.VersionService, nuzlockes *services.NuzlockeService, breeding *services.BreedingPlanner, battles *services.BattlePlanner, assetStore *assets.Store, publicURL string) *Server {
+	s := &Server{
+		auth:         auth,
+		playthroughs: playthroughs,
+		livingDex:    livingDex,
+		versions:     versions,
+		nuzlockes:    nuzlockes,
+		breeding:     breeding,
+		battles:      battles,
+		assets:       assetStore,
+		publicURL:    strings.TrimSuffix(publicURL, "/"),
+		mux:          http.NewServeMux(),
+	}
+	s.routes()
+	return s
+}
+
+func (s *Server) routes() {
+	s.mux.HandleFunc("POST /api/auth/register", s.handleRegister)
+	s.mux.HandleFunc("POST /api/auth/login", s.handleLogin)
+	s.mux.HandleFunc("POST /api/auth/logout", s.requireAuth(s.handleLogout))
+	s.mux.HandleFunc("GET /api/me", s.requireAuth(s.handleMe))
+
+	s.mux.HandleFunc("GET /api/playthroughs", s.requireAuth(s.handleListPlaythroughs))
+	s.mux.HandleFunc("POST /api/playthroughs", s.requireAuth(s.handleCreatePlaythrough))
+	s.mux.HandleFunc("GET /api/playthroughs/{id}", s.requireAuth(s.handleGetPlaythrough))
+	s.mux.HandleFunc("PUT /api/playthroughs/{id}", s.requireAuth(s.handleUpdatePlaythrough))
+	s.mux.HandleFunc("DELETE /api/playthroughs/{id}", s.requireAuth(s.handleDeletePlaythrough))
+	s.mux.HandleFunc("PUT /api/playthroughs/{id}/species/{speciesId}", s.requireAuth(s.handleSetSpeciesStatus))
+	s.mux.HandleFunc("DELETE /api/playthroughs/{id}/species/{speciesId}", s.requireAuth(s.handleDeleteSpeciesStatus))
+	s.mux.HandleFunc("PUT /api/playthroughs/{id}/party", s.requireAuth(s.handleSetParty))
+	s.mux.HandleFunc("GET /api/playthroughs/{id}/plan", s.requireAuth(s.handleGetPlaythroughPlan))
+
+	s.mux.HandleFunc("GET /api/living-dex/pokedexes/{id}", s.requireAuth(s.handleGetLivingDex))
+	s.mux.HandleFunc("PUT /api/living-dex/pokedexes/{id}/species/{speciesId}", s.requireAuth(s.handleSetLivingDexCaught))
+	s.mux.HandleFunc("DELETE /api/living-dex/pokedexes/{id}/species/{speciesId}", s.requireAuth(s.handleDeleteLivingDexCaught))
+	s.mux.HandleFunc("GET /api/living-dex/pokedexes/{id}/missing", s.requireAuth(s.handleGetLivingDexMissing))
+	s.mux.HandleFunc("GET /api/living-dex/pokedexes/{id}/checklist", s.requireAuth(s.handleGetLivingDexChecklist))
+	s.mux.HandleFunc("GET /api/living-dex/versions/{id}", s.requireAuth(s.handleGetGameProgress))
+
+	s.mux.HandleFunc("GET /api/nuzlockes", s.requireAuth(s.handleListNuzlockes))
+	s.mux.HandleFunc("POST /api/nuzlockes", s.requireAuth(s.handleCreateNuzlocke))
+	s.mux.HandleFunc("GET /api/nuzlockes/{id}", s.requireAuth(s.handleGetNuzlocke))
+	s.mux.HandleFunc("DELETE /api/nuzlockes/{id}", s.requireAuth(s.handleDeleteNuzlocke))
+	s.mux.HandleFunc("GET /api/nuzlockes/{id}/stats", s.requireAuth(s.handleGetNuzlockeStats))
+	s.mux.HandleFunc("POST /api/nuzlockes/{id}/encounters", s.requireAuth(s.handleRecordNuzlockeEncounter))
+	s.mux.HandleFunc("PUT /api/nuzlockes/{id}/encounters/{encounterId}", s.requireAuth(s.handleUpdateNuzlockeEncounter))
+	s.mux.HandleFunc("DELETE /api/nuzlockes/{id}/encounters/{encounterId}", s.requireAuth(s.handleDeleteNuzlockeEncounter))
+
+	s.mux.HandleFunc("GET /api/version-groups/{id}/exclusives", s.handleGetVersionExclusives)
+	s.mux.HandleFunc("GET /api/versions/{id}/metadata", s.handleGetVersionMetadata)
+	s.mux.HandleFunc("GET /api/pokemon/{id}/sprites", s.handleGetPokemonSprites)
+	s.mux.HandleFunc("GET /api/moves/{id}", s.handleGetMove)
+	s.mux.HandleFunc("GET /api/breeding/chains", s.handleGetBreedingChains)
+	s.mux.HandleFunc("GET /api/versions/{id}/trainers", s.handleGetVersionTrainers)
+	s.mux.HandleFunc("GET /api/trainers/{id}/plan", s.handleGetTrainerPlan)
+
+	s.mux.Handle("GET /assets/", http.StripPrefix("/assets/", s.assets.Handler()))
+	s.mux.Handle("GET /metrics", metrics.Handler())
+}
+
+// ServeHTTP routes a request and records its status and latency under the
+// route pattern it matched
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	s.mux.ServeHTTP(rec, r)
+
+	route := r.Pattern
+	if route == "" {
+		route = "unmatched"
+	}
+	metrics.ObserveRequest(route, rec.status, time.Since(start))
+	logger().Debug("request", "method", r.Method, "path", r.URL.Path, "status", rec.status, "duration", time.Since(start))
+}
+
+// statusRecorder remembers the status code written to a response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/assets"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/db"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupServer(t *testing.T) *Server {
+	database, err := db.New(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+
+	_, err = database.Exec(`INSERT INTO version_groups (id, name, generation_name, sort_order) VALUES (1, 'red-blue', 'generation-i', 1)`)
+	require.NoError(t, err)
+	_, err = database.Exec(`INSERT INTO versions (id, name, display_name, version_group_id) VALUES (1, 'red', 'Red', 1), (2, 'blue', 'Blue', 1)`)
+	require.NoError(t, err)
+	seedKantoDex(t, database)
+	seedBrock(t, database)
+	seedMoveEffects(t, database)
+
+	userDatabase, err := db.NewUserDatabase(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { userDatabase.Close() })
+
+	auth := services.NewAuthService(db.NewUserRepository(userDatabase), services.DefaultSessionTTL)
+	playthroughs := services.NewPlaythroughService(db.NewPlaythroughRepository(userDatabase), db.NewVersionRepository(database))
+	livingDex := services.NewLivingDexService(
+		db.NewLivingDexRepository(userDatabase),
+		db.NewPokedexRepository(database),
+		db.NewVersionRepository(database),
+		db.NewEncounterRepository(database),
+	)
+
+	versions := services.NewVersionService(db.NewVersionRepository(database), db.NewEncounterRepository(database), db.NewPokemonRepository(database), db.NewMoveRepository(database))
+
+	nuzlockes := services.NewNuzlockeService(
+		db.NewNuzlockeRepository(userDatabase),
+		db.NewVersionRepository(database),
+		db.NewEncounterRepository(database),
+		db.NewPokemonRepository(database),
+	)
+
+	breeding := services.NewBreedingPlanner(db.NewPokemonRepository(database), db.NewVersionRepository(database))
+
+	battles := services.NewBattlePlanner(
+		db.NewTrainerRepository(database),
+		db.NewPlaythroughRepository(userDatabase),
+		db.NewPokemonRepository(database),
+		db.NewMoveRepository(database),
+		db.NewVersionRepository(database),
+		db.NewTypeRepository(database),
+		db.NewEncounterRepository(database),
+		services.NewStatCalculator(db.NewPokemonRepository(database), db.NewVersionRepository(database), db.NewNatureRepository(database)),
+	)
+
+	assetStore := assets.NewStore(t.TempDir(), 16)
+	seedMedia(t, database, assetStore)
+
+	return NewServer(auth, playthroughs, livingDex, versions, nuzlockes, breeding, battles, assetStore, "https://pokemon.example/")
+}
+
+// seedKantoDex adds a small kanto pokedex to red-blue. Bulbasaur has no wild
+// encounters, Ekans is only found in red.
+func seedKantoDex(t *testing.T, database *db.Database) {
+	statements := []string{
+		`INSERT INTO pokedexes (id, name, region_name) VALUES (2, 'kanto', 'kanto')`,
+		`INSERT INTO version_group_pokedexes (version_group_id, pokedex_id) VALUES (1, 2)`,
+		`INSERT INTO species (id, name, evolution_chain_id) VALUES (1, 'bulbasaur', 1), (2, 'ivysaur', 1), (23, 'ekans', 10), (25, 'pikachu', 11)`,
+		`INSERT INTO pokedex_entries (pokedex_id, species_id, entry_number) VALUES (2, 1, 1), (2, 2, 2), (2, 23, 23), (2, 25, 25)`,
+		`INSERT INTO pokemon (id, species_id, name, is_default) VALUES (1, 1, 'bulbasaur', 1), (2, 2, 'ivysaur', 1), (23, 23, 'ekans', 1), (25, 25, 'pikachu', 1)`,
+		`INSERT INTO locations (id, name, region_name, display_name) VALUES
+			(1, 'viridian-forest', 'kanto', 'Viridian Forest'), (2, 'kanto-route-4', 'kanto', 'Route 4'), (3, 'johto-route-29', 'johto', 'Route 29')`,
+		`INSERT INTO location_areas (id, name, location_id) VALUES (1, 'viridian-forest-area', 1), (2, 'kanto-route-4-area', 2)`,
+		`INSERT INTO encounters (pokemon_id, location_area_id, version_id, method, min_level, max_level, chance) VALUES
+			(25, 1, 1, 'walk', 3, 5, 5), (25, 1, 2, 'walk', 3, 5, 5),
+			(2, 2, 1, 'walk', 20, 20, 1), (2, 2, 2, 'walk', 20, 20, 1),
+			(23, 2, 1, 'walk', 6, 12, 25)`,
+	}
+	for _, stmt := range statements {
+		_, err := database.Exec(stmt)
+		require.NoError(t, err)
+	}
+}
+
+// do sends a JSON request and decodes the JSON response into out when set
+func do(t *testing.T, s *Server, method, path, token string, body any, out any) int {
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if out != nil {
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(out), rec.Body.String())
+	}
+	return rec.Code
+}
+
+func register(t *testing.T, s *Server, username string) string {
+	var session sessionResponse
+	status := do(t, s, "POST", "/api/auth/register", "", credentials{Username: username, Password: "pikachu123"}, &session)
+	require.Equal(t, http.StatusCreated, status)
+	return session.Token
+}
+
+func TestAuth(t *testing.T) {
+	s := setupServer(t)
+	token := register(t, s, "ash")
+
+	var me dto.User
+	assert.Equal(t, http.StatusOK, do(t, s, "GET", "/api/me", token, nil, &me))
+	assert.Equal(t, "ash", me.Username)
+
+	t.Run("Duplicate username", func(t *testing.T) {
+		status := do(t, s, "POST", "/api/auth/register", "", credentials{Username: "ash", Password: "pikachu123"}, nil)
+		assert.Equal(t, http.StatusConflict, status)
+	})
+
+	t.Run("Wrong password", func(t *testing.T) {
+		status := do(t, s, "POST", "/api/auth/login", "", credentials{Username: "ash", Password: "charmander"}, nil)
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("Missing token", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, do(t, s, "GET", "/api/me", "", nil, nil))
+	})
+
+	t.Run("Login and logout", func(t *testing.T) {
+		var session sessionResponse
+		status := do(t, s, "POST", "/api/auth/login", "", credentials{Username: "ash", Password: "pikachu123"}, &session)
+		require.Equal(t, http.StatusOK, status)
+
+		assert.Equal(t, http.StatusNoContent, do(t, s, "POST", "/api/auth/logout", session.Token, nil, nil))
+		assert.Equal(t, http.StatusUnauthorized, do(t, s, "GET", "/api/me", session.Token, nil, nil))
+		// Other sessions stay valid
+		assert.Equal(t, http.StatusOK, do(t, s, "GET", "/api/me", token, nil, nil))
+	})
+}
+
+func TestPlaythroughEndpoints(t *testing.T) {
+	s := setupServer(t)
+	ash := register(t, s, "ash")
+	gary := register(t, s, "gary")
+
+	var created dto.Playthrough
+	status := do(t, s, "POST", "/api/playthroughs", ash, services.PlaythroughInput{VersionID: 1, Name: "First run"}, &created)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, 1, created.VersionID)
+
+	t.Run("Unknown version", func(t *testing.T) {
+		status := do(t, s, "POST", "/api/playthroughs", ash, services.PlaythroughInput{VersionID: 99, Name: "Nope"}, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Species and party", func(t *testing.T) {
+		var p dto.Playthrough
+		status := do(t, s, "PUT", "/api/playthroughs/1/species/25", ash, speciesStatusRequest{Status: "caught"}, &p)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []int{25}, p.Caught)
+
+		party := []dto.PartyMember{{Slot: 1, PokemonID: 25, Nickname: "Sparky", Level: 5}}
+		status = do(t, s, "PUT", "/api/playthroughs/1/party", ash, party, &p)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, party, p.Party)
+
+		status = do(t, s, "PUT", "/api/playthroughs/1/party", ash, []dto.PartyMember{{Slot: 7, PokemonID: 25, Level: 5}}, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+
+		var unseen dto.Playthrough
+		status = do(t, s, "DELETE", "/api/playthroughs/1/species/25", ash, nil, &unseen)
+		require.Equal(t, http.StatusOK, status)
+		assert.Empty(t, unseen.Caught)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		var p dto.Playthrough
+		status := do(t, s, "PUT", "/api/playthroughs/1", ash, services.PlaythroughInput{Name: "Renamed", Notes: "Beat Brock"}, &p)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "Beat Brock", p.Notes)
+
+		var list []dto.Playthrough
+		require.Equal(t, http.StatusOK, do(t, s, "GET", "/api/playthroughs", ash, nil, &list))
+		require.Len(t, list, 1)
+		assert.Equal(t, "Renamed", list[0].Name)
+	})
+
+	t.Run("Other users get 404", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, do(t, s, "GET", "/api/playthroughs/1", gary, nil, nil))
+		assert.Equal(t, http.StatusNotFound, do(t, s, "DELETE", "/api/playthroughs/1", gary, nil, nil))
+		assert.Equal(t, http.StatusNotFound, do(t, s, "PUT", "/api/playthroughs/1/species/1", gary, speciesStatusRequest{Status: "seen"}, nil))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, do(t, s, "DELETE", "/api/playthroughs/1", ash, nil, nil))
+		assert.Equal(t, http.StatusNotFound, do(t, s, "GET", "/api/playthroughs/1", ash, nil, nil))
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	s := setupServer(t)
+	require.Equal(t, http.StatusUnauthorized, do(t, s, "GET", "/api/me", "", nil, nil))
+	require.Equal(t, http.StatusNotFound, do(t, s, "GET", "/nothing-here", "", nil, nil))
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `pokemon_http_requests_total{route="GET /api/me",status="401"}`)
+	assert.Contains(t, body, `pokemon_http_requests_total{route="unmatched",status="404"}`)
+	// Seeding the database goes through the instrumented Exec
+	assert.Contains(t, body, `pokemon_db_rows_written_total{table="versions"}`)
+}
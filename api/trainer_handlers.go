@@ -0,0 +1,53 @@
+package api
+
+import "net/http"
+
+func (s *Server) handleGetVersionTrainers(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	trainers, err := s.battles.GetTrainers(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, trainers)
+}
+
+func (s *Server) handleGetTrainerPlan(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	plan, err := s.battles.PlanForPool(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, plan)
+}
+
+func (s *Server) handleGetPlaythroughPlan(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	trainerID, err := queryID(r, "trainerId")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	plan, err := s.battles.PlanForParty(currentUser(r).ID, id, trainerID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, plan)
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/db"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedBrock adds Brock with a single Geodude to red, and the stats, types and
+// level-up moves the battle planner needs for the Kanto dex species
+func seedBrock(t *testing.T, database *db.Database) {
+	statements := []string{
+		`INSERT INTO species (id, name) VALUES (74, 'geodude')`,
+		`INSERT INTO pokemon (id, species_id, name, is_default, hp, attack, defense, special_attack, special_defense, speed)
+			VALUES (74, 74, 'geodude', 1, 40, 80, 100, 30, 30, 20)`,
+		`UPDATE pokemon SET hp = 60, attack = 62, defense = 63, special_attack = 80, special_defense = 80, speed = 60 WHERE id = 2`,
+		`UPDATE pokemon SET hp = 35, attack = 60, defense = 44, special_attack = 40, special_defense = 54, speed = 55 WHERE id = 23`,
+		`UPDATE pokemon SET hp = 35, attack = 55, defense = 30, special_attack = 50, special_defense = 40, speed = 90 WHERE id = 25`,
+		`UPDATE pokemon SET sprite_front_default = '', sprite_front_shiny = '', sprite_artwork = '', sprite_artwork_shiny = ''`,
+		`INSERT INTO pokemon_types (pokemon_id, type_name, slot) VALUES
+			(74, 'rock', 1), (74, 'ground', 2), (2, 'grass', 1), (2, 'poison', 2), (23, 'poison', 1), (25, 'electric', 1)`,
+		`INSERT INTO types (name, damage_class) VALUES
+			('rock', 'physical'), ('ground', 'physical'), ('grass', 'special'), ('poison', 'physical'), ('electric', 'special')`,
+		`INSERT INTO type_effectiveness (attacking_type, defending_type, multiplier) VALUES
+			('grass', 'rock', 2), ('grass', 'ground', 2), ('electric', 'ground', 0), ('poison', 'rock', 0.5), ('poison', 'ground', 0.5),
+			('ground', 'electric', 2), ('ground', 'poison', 2), ('rock', 'grass', 1)`,
+		`INSERT INTO moves (id, name, type_name, power, pp, damage_class) VALUES
+			(22, 'vine-whip', 'grass', 35, 10, 'physical'), (40, 'poison-sting', 'poison', 15, 35, 'physical'), (84, 'thunder-shock', 'electric', 40, 30, 'special')`,
+		`INSERT INTO pokemon_moves (pokemon_id, move_id, version_group_id, learn_method, level_learned_at) VALUES
+			(2, 22, 1, 'level-up', 1), (23, 40, 1, 'level-up', 1), (25, 84, 1, 'level-up', 1)`,
+		`INSERT INTO trainers (id, name, role, version_id, battle, battle_order, location, badge, type_specialty, source_url)
+			VALUES (1, 'Brock', 'gym-leader', 1, 1, 1, 'Pewter Gym', 'Boulder Badge', 'rock', 'https://bulbapedia.bulbagarden.net/wiki/Brock')`,
+		`INSERT INTO trainer_pokemon (trainer_id, slot, species_id, level, held_item, moves) VALUES (1, 1, 74, 12, '', 'tackle,defense-curl')`,
+	}
+	for _, stmt := range statements {
+		_, err := database.Exec(stmt)
+		require.NoError(t, err)
+	}
+}
+
+func TestTrainerEndpoints(t *testing.T) {
+	s := setupServer(t)
+
+	var trainers []dto.Trainer
+	require.Equal(t, http.StatusOK, do(t, s, "GET", "/api/versions/1/trainers", "", nil, &trainers))
+	require.Len(t, trainers, 1)
+	assert.Equal(t, "Brock", trainers[0].Name)
+	assert.Equal(t, []string{"tackle", "defense-curl"}, trainers[0].Team[0].Moves)
+
+	assert.Equal(t, http.StatusNotFound, do(t, s, "GET", "/api/versions/99/trainers", "", nil, nil))
+
+	t.Run("Obtainable pool", func(t *testing.T) {
+		var plan dto.BattlePlan
+		require.Equal(t, http.StatusOK, do(t, s, "GET", "/api/trainers/1/plan", "", nil, &plan))
+		assert.Equal(t, dto.PlanSourcePool, plan.Source)
+		assert.Equal(t, 12, plan.LevelCap)
+
+		// Ivysaur, Ekans and Pikachu can be caught in red
+		require.Len(t, plan.Counters, 3)
+		assert.Equal(t, "ivysaur", plan.Counters[0].Name)
+		assert.Equal(t, "vine-whip", plan.Counters[0].Matchups[0].Move)
+		assert.Equal(t, 4.0, plan.Counters[0].Matchups[0].Effectiveness)
+		assert.Equal(t, "pikachu", plan.Counters[2].Name)
+
+		assert.Equal(t, http.StatusNotFound, do(t, s, "GET", "/api/trainers/99/plan", "", nil, nil))
+	})
+
+	t.Run("Party", func(t *testing.T) {
+		ash := register(t, s, "ash")
+		require.Equal(t, http.StatusCreated, do(t, s, "POST", "/api/playthroughs", ash, services.PlaythroughInput{VersionID: 1, Name: "Red"}, nil))
+		require.Equal(t, http.StatusCreated, do(t, s, "POST", "/api/playthroughs", ash, services.PlaythroughInput{VersionID: 2, Name: "Blue"}, nil))
+
+		// No party yet
+		assert.Equal(t, http.StatusBadRequest, do(t, s, "GET", "/api/playthroughs/1/plan?trainerId=1", ash, nil, nil))
+
+		party := []dto.PartyMember{{Slot: 1, PokemonID: 25, Level: 15}, {Slot: 2, PokemonID: 23, Level: 11}}
+		require.Equal(t, http.StatusOK, do(t, s, "PUT", "/api/playthroughs/1/party", ash, party, nil))
+
+		var plan dto.BattlePlan
+		require.Equal(t, http.StatusOK, do(t, s, "GET", "/api/playthroughs/1/plan?trainerId=1", ash, nil, &plan))
+		assert.Equal(t, dto.PlanSourceParty, plan.Source)
+		require.Len(t, plan.Counters, 2)
+		assert.Equal(t, "ekans", plan.Counters[0].Name)
+		assert.Equal(t, 11, plan.Counters[0].Level)
+		assert.Empty(t, plan.Counters[1].Matchups[0].Move)
+
+		// Brock of red can't be planned for a blue playthrough
+		assert.Equal(t, http.StatusBadRequest, do(t, s, "GET", "/api/playthroughs/2/plan?trainerId=1", ash, nil, nil))
+		assert.Equal(t, http.StatusBadRequest, do(t, s, "GET", "/api/playthroughs/1/plan", ash, nil, nil))
+		assert.Equal(t, http.StatusUnauthorized, do(t, s, "GET", "/api/playthroughs/1/plan?trainerId=1", "", nil, nil))
+	})
+}
@@ -0,0 +1,104 @@
+package api
+
+import "net/http"
+
+func (s *Server) handleGetVersionExclusives(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	exclusives, err := s.versions.GetVersionExclusives(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, exclusives)
+}
+
+func (s *Server) handleGetVersionMetadata(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	metadata, err := s.versions.GetVersionMetadata(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	for i := range metadata.Media {
+		metadata.Media[i].URL = s.assetURL(metadata.Media[i].Path)
+	}
+	writeJSON(w, http.StatusOK, metadata)
+}
+
+func (s *Server) handleGetPokemonSprites(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	versionGroupID, err := queryID(r, "versionGroupId")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	sprites, err := s.versions.GetPokemonSprites(id, versionGroupID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	for _, sp := range sprites {
+		sp.URL = s.assetURL(sp.Path)
+	}
+	writeJSON(w, http.StatusOK, sprites)
+}
+
+func (s *Server) handleGetMove(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	versionGroupID, err := queryID(r, "versionGroupId")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	move, err := s.versions.GetMove(id, versionGroupID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, move)
+}
+
+func (s *Server) handleGetBreedingChains(w http.ResponseWriter, r *http.Request) {
+	speciesID, err := queryID(r, "speciesId")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	moveID, err := queryID(r, "moveId")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	versionGroupID, err := queryID(r, "versionGroupId")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	chains, err := s.breeding.FindChains(speciesID, moveID, versionGroupID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, chains)
+}
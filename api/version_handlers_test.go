@@ -0,0 +1,137 @@
+package api
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/assets"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/db"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+)
+
+// seedMedia stores a 32x32 image as red's cover and pikachu's red-blue sprite
+func seedMedia(t *testing.T, database *db.Database, store *assets.Store) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 32, 32))))
+	asset, err := store.Save(buf.Bytes(), "https://example.com/25.png")
+	require.NoError(t, err)
+
+	err = db.NewVersionRepository(database).InsertVersionMetadata(&dto.VersionMetadata{
+		VersionID: 1,
+		IGDBID:    1561,
+		Summary:   "The first games",
+		Media:     []dto.VersionMedia{{Kind: dto.MediaCover, ImageID: "co1abc", Width: 32, Height: 32, Path: asset.Path}},
+	})
+	require.NoError(t, err)
+
+	err = db.NewPokemonRepository(database).InsertPokemonSprites(25, []*dto.PokemonSprite{
+		{PokemonID: 25, Generation: 1, VersionGroup: "red-blue", SpriteSet: "red-blue", Side: dto.SpriteFront, Path: asset.Path},
+	})
+	require.NoError(t, err)
+}
+
+// seedMoveEffects gives thunder-shock an effect text and a made up older
+// effect chance from before gold-silver, which isn't synced
+func seedMoveEffects(t *testing.T, database *db.Database) {
+	statements := []string{
+		`UPDATE moves SET effect_chance = 10, effect_short = 'Has a $effect_chance% chance to paralyze the target.' WHERE id = 84`,
+		`INSERT INTO move_past_values (move_id, version_group_id, version_group_order, effect_chance) VALUES (84, 3, 3, 30)`,
+	}
+	for _, stmt := range statements {
+		_, err := database.Exec(stmt)
+		require.NoError(t, err)
+	}
+}
+
+func TestVersionExclusivesEndpoint(t *testing.T) {
+	s := setupServer(t)
+
+	var exclusives []dto.VersionExclusives
+	require.Equal(t, http.StatusOK, do(t, s, "GET", "/api/version-groups/1/exclusives", "", nil, &exclusives))
+	require.Len(t, exclusives, 2)
+	assert.Equal(t, "red", exclusives[0].Version.Name)
+	assert.Equal(t, []dto.ExclusiveSpecies{{ID: 23, Name: "ekans"}}, exclusives[0].Species)
+	assert.Equal(t, "blue", exclusives[1].Version.Name)
+	assert.Empty(t, exclusives[1].Species)
+
+	assert.Equal(t, http.StatusNotFound, do(t, s, "GET", "/api/version-groups/99/exclusives", "", nil, nil))
+}
+
+func TestBreedingChainsEndpoint(t *testing.T) {
+	s := setupServer(t)
+
+	// Red and blue have no breeding
+	status := do(t, s, "GET", "/api/breeding/chains?speciesId=1&moveId=1&versionGroupId=1", "", nil, nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status = do(t, s, "GET", "/api/breeding/chains?speciesId=1&moveId=1", "", nil, nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestVersionMetadataEndpoint(t *testing.T) {
+	s := setupServer(t)
+
+	var metadata map[string]any
+	require.Equal(t, http.StatusOK, do(t, s, "GET", "/api/versions/1/metadata", "", nil, &metadata))
+	media := metadata["media"].([]any)[0].(map[string]any)
+	assert.Regexp(t, `^https://pokemon\.example/assets/[0-9a-f]{2}/[0-9a-f]{64}\.png$`, media["url"])
+	assert.NotContains(t, media, "path")
+
+	assert.Equal(t, http.StatusNotFound, do(t, s, "GET", "/api/versions/2/metadata", "", nil, nil))
+}
+
+func TestPokemonSpritesEndpoint(t *testing.T) {
+	s := setupServer(t)
+
+	var sprites []dto.PokemonSprite
+	require.Equal(t, http.StatusOK, do(t, s, "GET", "/api/pokemon/25/sprites?versionGroupId=1", "", nil, &sprites))
+	require.Len(t, sprites, 1)
+	assert.Equal(t, dto.SpriteFront, sprites[0].Side)
+
+	// The URL is served with caching headers, in the whitelisted sizes
+	path := strings.TrimPrefix(sprites[0].URL, "https://pokemon.example")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+	assert.NotEmpty(t, rec.Header().Get("ETag"))
+	assert.Contains(t, rec.Header().Get("Cache-Control"), "max-age=")
+
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("GET", path+"?w=16", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/webp", rec.Header().Get("Content-Type"))
+	thumb, err := webp.Decode(rec.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 16, thumb.Bounds().Dx())
+
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest("GET", path+"?w=20", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	assert.Equal(t, http.StatusNotFound, do(t, s, "GET", "/api/pokemon/999/sprites?versionGroupId=1", "", nil, nil))
+	assert.Equal(t, http.StatusBadRequest, do(t, s, "GET", "/api/pokemon/25/sprites", "", nil, nil))
+}
+
+func TestMoveEndpoint(t *testing.T) {
+	s := setupServer(t)
+
+	var move dto.Move
+	require.Equal(t, http.StatusOK, do(t, s, "GET", "/api/moves/84?versionGroupId=1", "", nil, &move))
+	assert.Equal(t, "thunder-shock", move.Name)
+	assert.Equal(t, 40, move.Power)
+	assert.Equal(t, 30, move.EffectChance)
+	assert.Equal(t, "Has a 30% chance to paralyze the target.", move.EffectShort)
+
+	assert.Equal(t, http.StatusNotFound, do(t, s, "GET", "/api/moves/999?versionGroupId=1", "", nil, nil))
+	assert.Equal(t, http.StatusNotFound, do(t, s, "GET", "/api/moves/84?versionGroupId=99", "", nil, nil))
+	assert.Equal(t, http.StatusBadRequest, do(t, s, "GET", "/api/moves/84", "", nil, nil))
+}
@@ -0,0 +1,124 @@
+package assets
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// A file never changes under its name, so clients may keep it for a year
+const cacheControl = "public, max-age=31536000, immutable"
+
+// originalName matches the names originals are served under, e.g.
+// "3f/3f9a...e1.png". Anything else, like "../", never reaches the disk.
+var originalName = regexp.MustCompile(`^([0-9a-f]{2})/([0-9a-f]{64})\.(png|jpg|gif)$`)
+
+// Name returns the name a file of the store is served under, e.g.
+// "3f/3f9a...e1.png" for root/3f/3f9a...e1.png. Paths outside the store
+// give false.
+func (s *Store) Name(path string) (string, bool) {
+	rel, err := filepath.Rel(s.root, path)
+	if err != nil || !filepath.IsLocal(rel) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// Handler serves originals by name and their thumbnails with ?w=<width>, for
+// the widths the store makes thumbnails in. It expects the name as the whole
+// request path, see http.StripPrefix.
+func (s *Store) Handler() http.Handler {
+	return http.HandlerFunc(s.serve)
+}
+
+func (s *Store) serve(w http.ResponseWriter, r *http.Request) {
+	m := originalName.FindStringSubmatch(r.URL.Path)
+	if m == nil || m[1] != m[2][:2] {
+		http.NotFound(w, r)
+		return
+	}
+	path := filepath.Join(s.root, filepath.FromSlash(r.URL.Path))
+	etag := m[2]
+
+	if value := r.URL.Query().Get("w"); value != "" {
+		width, err := strconv.Atoi(value)
+		if err != nil || !slices.Contains(s.widths, width) {
+			http.Error(w, fmt.Sprintf("w must be one of %v", s.widths), http.StatusBadRequest)
+			return
+		}
+		path, err = s.thumbnail(path, width)
+		if errors.Is(err, os.ErrNotExist) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			logger().Error("failed to make thumbnail", "path", r.URL.Path, "err", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		etag = fmt.Sprintf("%s-%d", etag, width)
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		logger().Error("failed to open asset", "path", path, "err", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		logger().Error("failed to stat asset", "path", path, "err", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("ETag", `"`+etag+`"`)
+	w.Header().Set("Cache-Control", cacheControl)
+	// ServeContent answers If-None-Match with a 304 and sets the Content-Type
+	http.ServeContent(w, r, path, info.ModTime(), f)
+}
+
+// thumbnail returns the path of an original's thumbnail, making it when it's
+// missing, e.g. for a width added after the image was saved. An original
+// that isn't wider than width is its own thumbnail.
+func (s *Store) thumbnail(original string, width int) (string, error) {
+	path := thumbnailPath(original, width)
+	if fileExists(path) {
+		return path, nil
+	}
+
+	data, err := os.ReadFile(original)
+	if err != nil {
+		return "", err
+	}
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := img.Bounds()
+	if width >= bounds.Dx() {
+		return original, nil
+	}
+	height := max(1, bounds.Dy()*width/bounds.Dx())
+	err = writeOnce(path, func() ([]byte, error) {
+		return encode(resize(img, width, height), thumbnailFormat(format))
+	})
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
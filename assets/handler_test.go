@@ -0,0 +1,96 @@
+package assets
+
+import (
+	"image"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serve requests a name from the store's handler mounted under /assets/
+func serve(store *Store, name string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/assets/"+name, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	http.StripPrefix("/assets/", store.Handler()).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandlerServesOriginals(t *testing.T) {
+	root := t.TempDir()
+	store := NewStore(root, 32)
+	data := testPNG(t, 64, 64)
+	asset, err := store.Save(data, "https://example.com/25.png")
+	require.NoError(t, err)
+
+	name, ok := store.Name(asset.Path)
+	require.True(t, ok)
+	assert.Equal(t, asset.Hash[:2]+"/"+asset.Hash+".png", name)
+
+	rec := serve(store, name, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, data, rec.Body.Bytes())
+	assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `"`+asset.Hash+`"`, rec.Header().Get("ETag"))
+	assert.Equal(t, cacheControl, rec.Header().Get("Cache-Control"))
+
+	rec = serve(store, name, http.Header{"If-None-Match": {`"` + asset.Hash + `"`}})
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.Bytes())
+}
+
+func TestHandlerResizes(t *testing.T) {
+	root := t.TempDir()
+	store := NewStore(root, 32)
+	asset, err := store.Save(testPNG(t, 64, 64), "https://example.com/25.png")
+	require.NoError(t, err)
+	name, _ := store.Name(asset.Path)
+
+	rec := serve(store, name+"?w=32", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"`+asset.Hash+`-32"`, rec.Header().Get("ETag"))
+	assert.Equal(t, "image/webp", rec.Header().Get("Content-Type"))
+	img, _, err := image.Decode(rec.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 32, img.Bounds().Dx())
+
+	// Widths added later are made on the first request and kept
+	wider := NewStore(root, 32, 48)
+	rec = serve(wider, name+"?w=48", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	img, _, err = image.Decode(rec.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 48, img.Bounds().Dx())
+	assert.FileExists(t, filepath.Join(root, asset.Hash[:2], asset.Hash+"_48.webp"))
+
+	// Only whitelisted widths
+	assert.Equal(t, http.StatusBadRequest, serve(store, name+"?w=48", nil).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(store, name+"?w=big", nil).Code)
+}
+
+func TestHandlerRefusesOtherFiles(t *testing.T) {
+	root := t.TempDir()
+	store := NewStore(root)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "secret.png"), []byte("secret"), 0o644))
+
+	hash := "3f9a000000000000000000000000000000000000000000000000000000000000"
+	for _, path := range []string{
+		"secret.png",
+		"../secret.png",
+		"3f/" + hash + ".txt",
+		"aa/" + hash + ".png", // Wrong directory
+		"3f/" + hash + ".png", // Not stored
+	} {
+		assert.Equal(t, http.StatusNotFound, serve(store, path, nil).Code, path)
+	}
+
+	_, ok := store.Name(filepath.Join(root, "..", "pokemon.db"))
+	assert.False(t, ok)
+}
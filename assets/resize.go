@@ -0,0 +1,55 @@
+package assets
+
+import (
+	"image"
+	"image/draw"
+)
+
+// resize scales an image down to width x height by averaging the source
+// pixels each target pixel covers. Colors are averaged premultiplied, so
+// transparent pixels don't darken the edges of sprites.
+func resize(src image.Image, width, height int) *image.RGBA {
+	b := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+
+	srcW, srcH := b.Dx(), b.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		y0, y1 := span(y, height, srcH)
+		for x := range width {
+			x0, x1 := span(x, width, srcW)
+
+			var r, g, bl, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += int(p[0])
+					g += int(p[1])
+					bl += int(p[2])
+					a += int(p[3])
+					n++
+				}
+			}
+
+			i := y*dst.Stride + x*4
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(bl / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// span returns the source pixels [start, end) target pixel i of n covers,
+// at least one
+func span(i, n, srcSize int) (int, int) {
+	start := i * srcSize / n
+	end := (i + 1) * srcSize / n
+	if end <= start {
+		end = start + 1
+	}
+	return start, end
+}
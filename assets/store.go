@@ -0,0 +1,233 @@
+// Package assets downloads images and stores them by the hash of their
+// content, next to thumbnails in a few widths. Thumbnails are lossless WebP,
+// or JPEG for JPEG sources where lossless would be larger than the original.
+package assets
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/metrics"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+)
+
+// DefaultThumbnailWidths suit list rows, cards and detail pages
+var DefaultThumbnailWidths = []int{96, 256, 512}
+
+// Images larger than this are refused rather than read into memory
+const maxImageBytes = 20 << 20
+
+// Store writes images to root/<first 2 hash chars>/<hash>.<ext> and their
+// thumbnails to <hash>_<width>.<ext> next to them. Files are written to a
+// temp file and renamed, so a file that exists is always complete.
+type Store struct {
+	root   string
+	widths []int
+	client *http.Client
+}
+
+// NewStore returns a store that keeps images under root. Thumbnails are only
+// made for widths smaller than the image.
+func NewStore(root string, thumbnailWidths ...int) *Store {
+	if len(thumbnailWidths) == 0 {
+		thumbnailWidths = DefaultThumbnailWidths
+	}
+	return &Store{
+		root:   root,
+		widths: thumbnailWidths,
+		client: &http.Client{Timeout: 30 * time.Second, Transport: metrics.Transport("images", nil)},
+	}
+}
+
+// Fetch downloads an image and saves it, see Save
+func (s *Store) Fetch(sourceURL string) (*dto.Asset, error) {
+	resp, err := s.client.Get(sourceURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: bad status: %s", sourceURL, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", sourceURL, err)
+	}
+	if len(data) > maxImageBytes {
+		return nil, fmt.Errorf("image %s is larger than %d bytes", sourceURL, maxImageBytes)
+	}
+
+	return s.Save(data, sourceURL)
+}
+
+// Save stores an image and its thumbnails. Data that doesn't decode as a
+// PNG, JPEG or GIF, e.g. a truncated download, is refused.
+func (s *Store) Save(data []byte, sourceURL string) (*dto.Asset, error) {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image %s: %w", sourceURL, err)
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	dir := filepath.Join(s.root, hash[:2])
+
+	bounds := img.Bounds()
+	asset := &dto.Asset{
+		Hash:      hash,
+		SourceURL: sourceURL,
+		Format:    format,
+		Width:     bounds.Dx(),
+		Height:    bounds.Dy(),
+		Path:      filepath.Join(dir, hash+extension(format)),
+		Variants:  []dto.AssetVariant{},
+	}
+	if err := writeOnce(asset.Path, func() ([]byte, error) { return data, nil }); err != nil {
+		return nil, err
+	}
+
+	thumbFormat := thumbnailFormat(format)
+	for _, width := range s.widths {
+		if width >= asset.Width {
+			continue
+		}
+		height := max(1, asset.Height*width/asset.Width)
+		variant := dto.AssetVariant{
+			Format: thumbFormat,
+			Width:  width,
+			Height: height,
+			Path:   thumbnailPath(asset.Path, width),
+		}
+		err := writeOnce(variant.Path, func() ([]byte, error) {
+			return encode(resize(img, width, height), thumbFormat)
+		})
+		if err != nil {
+			return nil, err
+		}
+		asset.Variants = append(asset.Variants, variant)
+	}
+
+	return asset, nil
+}
+
+// Has reports whether all files of an asset are on disk
+func (s *Store) Has(a *dto.Asset) bool {
+	if !fileExists(a.Path) {
+		return false
+	}
+	for _, v := range a.Variants {
+		if !fileExists(v.Path) {
+			return false
+		}
+	}
+	return true
+}
+
+// writeOnce writes a content-addressed file unless it's already there, the
+// content is only produced when needed
+func writeOnce(path string, content func() ([]byte, error)) error {
+	if fileExists(path) {
+		return nil
+	}
+	data, err := content()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic writes to a temp file in the same directory and renames it,
+// so readers and interrupted runs never see half a file
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op after the rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// thumbnailFormat keeps JPEG as JPEG, everything else may have transparency
+func thumbnailFormat(format string) string {
+	if format == "jpeg" {
+		return "jpeg"
+	}
+	return "webp"
+}
+
+// thumbnailPath returns where the thumbnail of an original is stored
+func thumbnailPath(original string, width int) string {
+	ext := filepath.Ext(original)
+	thumbExt := ".webp"
+	if ext == ".jpg" {
+		thumbExt = ".jpg"
+	}
+	hash := strings.TrimSuffix(filepath.Base(original), ext)
+	return filepath.Join(filepath.Dir(original), fmt.Sprintf("%s_%d%s", hash, width, thumbExt))
+}
+
+func encode(img image.Image, format string) ([]byte, error) {
+	if format != "jpeg" {
+		data, err := encodeWebP(img)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+		}
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func extension(format string) string {
+	if format == "jpeg" {
+		return ".jpg"
+	}
+	return "." + format
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, os.ErrNotExist)
+}
@@ -0,0 +1,145 @@
+package assets
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "golang.org/x/image/webp"
+)
+
+// testPNG is a width x height image, opaque red on the left half and
+// transparent on the right
+func testPNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width / 2 {
+			img.Set(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestSave(t *testing.T) {
+	root := t.TempDir()
+	store := NewStore(root, 16, 64, 512)
+	data := testPNG(t, 128, 64)
+
+	asset, err := store.Save(data, "https://example.com/25.png")
+	require.NoError(t, err)
+
+	assert.Len(t, asset.Hash, 64)
+	assert.Equal(t, "png", asset.Format)
+	assert.Equal(t, 128, asset.Width)
+	assert.Equal(t, 64, asset.Height)
+	assert.Equal(t, filepath.Join(root, asset.Hash[:2], asset.Hash+".png"), asset.Path)
+
+	stored, err := os.ReadFile(asset.Path)
+	require.NoError(t, err)
+	assert.Equal(t, data, stored)
+
+	// No thumbnail wider than the original
+	require.Len(t, asset.Variants, 2)
+	assert.Equal(t, 16, asset.Variants[0].Width)
+	assert.Equal(t, 8, asset.Variants[0].Height)
+	assert.Equal(t, 64, asset.Variants[1].Width)
+
+	f, err := os.Open(asset.Variants[1].Path)
+	require.NoError(t, err)
+	defer f.Close()
+	thumb, format, err := image.Decode(f)
+	require.NoError(t, err)
+	assert.Equal(t, "webp", format)
+	assert.Equal(t, image.Rect(0, 0, 64, 32), thumb.Bounds())
+	// Halves keep their color and transparency
+	_, _, _, a := thumb.At(63, 0).RGBA()
+	assert.Zero(t, a)
+	r, _, _, a := thumb.At(0, 0).RGBA()
+	assert.Equal(t, uint32(0xffff), r)
+	assert.Equal(t, uint32(0xffff), a)
+
+	assert.True(t, store.Has(asset))
+	os.Remove(asset.Variants[0].Path)
+	assert.False(t, store.Has(asset))
+}
+
+func TestSaveIsContentAddressed(t *testing.T) {
+	root := t.TempDir()
+	store := NewStore(root, 16)
+
+	first, err := store.Save(testPNG(t, 32, 32), "https://example.com/a.png")
+	require.NoError(t, err)
+	second, err := store.Save(testPNG(t, 32, 32), "https://example.com/b.png")
+	require.NoError(t, err)
+	assert.Equal(t, first.Path, second.Path)
+
+	other, err := store.Save(testPNG(t, 32, 16), "https://example.com/a.png")
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Hash, other.Hash)
+
+	// Only finished files, no temp files left behind
+	entries, err := filepath.Glob(filepath.Join(root, "*", ".tmp-*"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestSaveRefusesBrokenImages(t *testing.T) {
+	root := t.TempDir()
+	store := NewStore(root)
+	data := testPNG(t, 32, 32)
+
+	_, err := store.Save(data[:len(data)/2], "https://example.com/truncated.png")
+	assert.ErrorContains(t, err, "failed to decode image")
+	_, err = store.Save([]byte("<html>Not Found</html>"), "https://example.com/404.png")
+	assert.ErrorContains(t, err, "failed to decode image")
+
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestSaveJPEG(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 264, 374))
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+
+	asset, err := NewStore(t.TempDir(), 96).Save(buf.Bytes(), "https://example.com/cover.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", asset.Format)
+	assert.Equal(t, ".jpg", filepath.Ext(asset.Path))
+	require.Len(t, asset.Variants, 1)
+	assert.Equal(t, "jpeg", asset.Variants[0].Format)
+	assert.Equal(t, 136, asset.Variants[0].Height)
+}
+
+func TestFetch(t *testing.T) {
+	data := testPNG(t, 32, 32)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/25.png" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	store := NewStore(t.TempDir(), 16)
+	asset, err := store.Fetch(server.URL + "/25.png")
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/25.png", asset.SourceURL)
+	assert.Len(t, asset.Variants, 1)
+
+	_, err = store.Fetch(server.URL + "/missing.png")
+	assert.ErrorContains(t, err, "404")
+}
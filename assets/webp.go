@@ -0,0 +1,375 @@
+package assets
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"math/bits"
+	"slices"
+)
+
+// Lossless WebP (VP8L), see https://developers.google.com/speed/webp/docs/webp_lossless_bitstream_specification
+const (
+	vp8lSignature = 0x2f
+	// Width and height are stored in 14 bits
+	maxWebPDimension = 1 << 14
+
+	numLiterals      = 256
+	numLengthCodes   = 24
+	numDistanceCodes = 40
+	// Distance codes up to 120 point into the neighborhood of a pixel, larger
+	// codes are the distance plus 120
+	distanceCodeOffset = 120
+
+	maxCopyLength = 4096
+	// Shorter copies cost about as much as the literals
+	minCopyLength = 3
+
+	maxCodeLength           = 15
+	maxCodeLengthCodeLength = 7
+)
+
+// codeLengthCodeOrder is the order the lengths of the code length code are written in
+var codeLengthCodeOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// encodeWebP encodes an image as a lossless WebP. It only uses the subtract
+// green transform and copies from the pixel to the left or above, which is
+// what sprites with flat colors and transparent borders need.
+func encodeWebP(img image.Image) ([]byte, error) {
+	b := img.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width < 1 || height < 1 || width > maxWebPDimension || height > maxWebPDimension {
+		return nil, fmt.Errorf("can't encode a %dx%d image as WebP", width, height)
+	}
+
+	pixels := make([]uint32, 0, width*height)
+	alphaUsed := uint32(0)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A != 0xff {
+				alphaUsed = 1
+			}
+			// Subtract green: red and blue are stored as the difference to green
+			r, bl := c.R-c.G, c.B-c.G
+			pixels = append(pixels, uint32(c.A)<<24|uint32(r)<<16|uint32(c.G)<<8|uint32(bl))
+		}
+	}
+
+	symbols := backwardReferences(pixels, width)
+
+	green := make([]int, numLiterals+numLengthCodes)
+	red := make([]int, numLiterals)
+	blue := make([]int, numLiterals)
+	alpha := make([]int, numLiterals)
+	distance := make([]int, numDistanceCodes)
+	for _, s := range symbols {
+		if s.length == 0 {
+			green[s.argb>>8&0xff]++
+			red[s.argb>>16&0xff]++
+			blue[s.argb&0xff]++
+			alpha[s.argb>>24]++
+			continue
+		}
+		lengthPrefix, _, _ := prefixEncode(s.length)
+		green[numLiterals+lengthPrefix]++
+		distancePrefix, _, _ := prefixEncode(s.distance + distanceCodeOffset)
+		distance[distancePrefix]++
+	}
+
+	w := &bitWriter{}
+	w.write(vp8lSignature, 8)
+	w.write(uint32(width-1), 14)
+	w.write(uint32(height-1), 14)
+	w.write(alphaUsed, 1)
+	w.write(0, 3) // Version
+	w.write(1, 1) // A transform follows
+	w.write(2, 2) // Subtract green
+	w.write(0, 1) // No more transforms
+	w.write(0, 1) // No color cache
+	w.write(0, 1) // One prefix code group for the whole image
+
+	codes := make([]prefixCode, 0, 5)
+	for _, counts := range [][]int{green, red, blue, alpha, distance} {
+		code := newPrefixCode(counts, maxCodeLength)
+		code.writeLengths(w)
+		codes = append(codes, code)
+	}
+	greenCode, redCode, blueCode, alphaCode, distanceCode := codes[0], codes[1], codes[2], codes[3], codes[4]
+
+	for _, s := range symbols {
+		if s.length == 0 {
+			greenCode.writeSymbol(w, int(s.argb>>8&0xff))
+			redCode.writeSymbol(w, int(s.argb>>16&0xff))
+			blueCode.writeSymbol(w, int(s.argb&0xff))
+			alphaCode.writeSymbol(w, int(s.argb>>24))
+			continue
+		}
+		prefix, extraBits, extra := prefixEncode(s.length)
+		greenCode.writeSymbol(w, numLiterals+prefix)
+		w.write(uint32(extra), extraBits)
+		prefix, extraBits, extra = prefixEncode(s.distance + distanceCodeOffset)
+		distanceCode.writeSymbol(w, prefix)
+		w.write(uint32(extra), extraBits)
+	}
+
+	data := w.bytes()
+	padded := len(data) + len(data)&1
+	out := make([]byte, 0, 20+padded)
+	out = append(out, "RIFF"...)
+	out = binary.LittleEndian.AppendUint32(out, uint32(4+8+padded))
+	out = append(out, "WEBPVP8L"...)
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(data)))
+	out = append(out, data...)
+	if len(data)&1 == 1 {
+		out = append(out, 0)
+	}
+	return out, nil
+}
+
+// pixelSymbol is a literal pixel, or a copy of length pixels from distance
+// pixels back when length isn't 0
+type pixelSymbol struct {
+	argb             uint32
+	length, distance int
+}
+
+// backwardReferences turns pixels into literals and copies of the pixel to
+// the left or the pixel above, whichever repeats longer
+func backwardReferences(pixels []uint32, width int) []pixelSymbol {
+	symbols := []pixelSymbol{}
+	for i := 0; i < len(pixels); {
+		best, bestDistance := 0, 0
+		for _, d := range []int{1, width} {
+			if d > i {
+				continue
+			}
+			n := 0
+			for n < maxCopyLength && i+n < len(pixels) && pixels[i+n] == pixels[i+n-d] {
+				n++
+			}
+			if n > best {
+				best, bestDistance = n, d
+			}
+		}
+		if best >= minCopyLength {
+			symbols = append(symbols, pixelSymbol{length: best, distance: bestDistance})
+			i += best
+			continue
+		}
+		symbols = append(symbols, pixelSymbol{argb: pixels[i]})
+		i++
+	}
+	return symbols
+}
+
+// prefixEncode splits a copy length or distance code into its prefix symbol
+// and the extra bits that follow it
+func prefixEncode(value int) (prefix int, extraBits uint, extra int) {
+	n := value - 1
+	if n < 4 {
+		return n, 0, 0
+	}
+	highest := bits.Len(uint(n)) - 1
+	second := n >> (highest - 1) & 1
+	return 2*highest + second, uint(highest - 1), n & (1<<(highest-1) - 1)
+}
+
+// prefixCode is a canonical Huffman code, codes are stored bit-reversed as
+// the stream is read from the least significant bit
+type prefixCode struct {
+	lengths []int
+	codes   []uint32
+}
+
+func newPrefixCode(counts []int, maxLength int) prefixCode {
+	lengths := codeLengths(counts, maxLength)
+
+	var lengthCounts [maxCodeLength + 1]int
+	for _, l := range lengths {
+		lengthCounts[l]++
+	}
+	lengthCounts[0] = 0
+	var next [maxCodeLength + 1]uint32
+	code := uint32(0)
+	for l := 1; l <= maxCodeLength; l++ {
+		code = (code + uint32(lengthCounts[l-1])) << 1
+		next[l] = code
+	}
+
+	codes := make([]uint32, len(lengths))
+	for symbol, l := range lengths {
+		if l == 0 {
+			continue
+		}
+		codes[symbol] = bits.Reverse32(next[l]) >> (32 - l)
+		next[l]++
+	}
+	return prefixCode{lengths: lengths, codes: codes}
+}
+
+func (c prefixCode) writeSymbol(w *bitWriter, symbol int) {
+	w.write(c.codes[symbol], uint(c.lengths[symbol]))
+}
+
+// writeLengths writes the code as a normal code: its code lengths, coded
+// themselves with a code length code. Runs of unused symbols are written
+// with the repeat zero symbols 17 and 18.
+func (c prefixCode) writeLengths(w *bitWriter) {
+	type lengthSymbol struct {
+		symbol    int
+		extraBits uint
+		extra     int
+	}
+	symbols := []lengthSymbol{}
+	for i := 0; i < len(c.lengths); {
+		if c.lengths[i] == 0 {
+			run := 1
+			for i+run < len(c.lengths) && c.lengths[i+run] == 0 {
+				run++
+			}
+			if run >= 11 {
+				run = min(run, 138)
+				symbols = append(symbols, lengthSymbol{18, 7, run - 11})
+				i += run
+				continue
+			}
+			if run >= 3 {
+				symbols = append(symbols, lengthSymbol{17, 3, run - 3})
+				i += run
+				continue
+			}
+		}
+		symbols = append(symbols, lengthSymbol{symbol: c.lengths[i]})
+		i++
+	}
+
+	counts := make([]int, len(codeLengthCodeOrder))
+	for _, s := range symbols {
+		counts[s.symbol]++
+	}
+	lengthCode := newPrefixCode(counts, maxCodeLengthCodeLength)
+
+	numLengths := len(codeLengthCodeOrder)
+	for numLengths > 4 && lengthCode.lengths[codeLengthCodeOrder[numLengths-1]] == 0 {
+		numLengths--
+	}
+
+	w.write(0, 1) // Normal code
+	w.write(uint32(numLengths-4), 4)
+	for _, symbol := range codeLengthCodeOrder[:numLengths] {
+		w.write(uint32(lengthCode.lengths[symbol]), 3)
+	}
+	w.write(0, 1) // Lengths for every symbol of the alphabet follow
+	for _, s := range symbols {
+		lengthCode.writeSymbol(w, s.symbol)
+		w.write(uint32(s.extra), s.extraBits)
+	}
+}
+
+// codeLengths returns Huffman code lengths of at most maxLength bits for the
+// symbol counts. At least two symbols get a code, decoders treat a code with
+// a single symbol as zero bits long.
+func codeLengths(counts []int, maxLength int) []int {
+	counts = slices.Clone(counts)
+	for i := 0; countUsed(counts) < 2; i++ {
+		counts[i] = max(counts[i], 1)
+	}
+
+	for {
+		lengths := huffmanLengths(counts)
+		if slices.Max(lengths) <= maxLength {
+			return lengths
+		}
+		// Flatten the counts until the tree is shallow enough
+		for i, c := range counts {
+			if c > 0 {
+				counts[i] = max(1, c/2)
+			}
+		}
+	}
+}
+
+func countUsed(counts []int) int {
+	used := 0
+	for _, c := range counts {
+		if c > 0 {
+			used++
+		}
+	}
+	return used
+}
+
+// huffmanLengths builds a Huffman tree for the used symbols and returns the
+// depth of every symbol in it
+func huffmanLengths(counts []int) []int {
+	type node struct {
+		weight, parent int
+	}
+	nodes := []node{}
+	leaves := []int{}
+	for symbol, c := range counts {
+		if c > 0 {
+			leaves = append(leaves, symbol)
+		}
+	}
+	slices.SortStableFunc(leaves, func(a, b int) int { return counts[a] - counts[b] })
+
+	leafNodes := make(map[int]int, len(leaves))
+	for _, symbol := range leaves {
+		leafNodes[symbol] = len(nodes)
+		nodes = append(nodes, node{weight: counts[symbol], parent: -1})
+	}
+
+	// Leaves are sorted and merged nodes only get heavier, so the lightest
+	// node is always at the front of one of the two queues
+	leafQueue, mergedQueue := 0, len(nodes)
+	pop := func() int {
+		if leafQueue < len(leaves) && (mergedQueue == len(nodes) || nodes[leafQueue].weight <= nodes[mergedQueue].weight) {
+			leafQueue++
+			return leafQueue - 1
+		}
+		mergedQueue++
+		return mergedQueue - 1
+	}
+	for range len(leaves) - 1 {
+		a, b := pop(), pop()
+		nodes = append(nodes, node{weight: nodes[a].weight + nodes[b].weight, parent: -1})
+		nodes[a].parent, nodes[b].parent = len(nodes)-1, len(nodes)-1
+	}
+
+	lengths := make([]int, len(counts))
+	for symbol, n := range leafNodes {
+		for p := nodes[n].parent; p >= 0; p = nodes[p].parent {
+			lengths[symbol]++
+		}
+	}
+	return lengths
+}
+
+// bitWriter packs values least significant bit first
+type bitWriter struct {
+	buf  []byte
+	acc  uint64
+	bits uint
+}
+
+func (w *bitWriter) write(value uint32, n uint) {
+	w.acc |= uint64(value) << w.bits
+	w.bits += n
+	for w.bits >= 8 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc >>= 8
+		w.bits -= 8
+	}
+}
+
+// bytes flushes the remaining bits, padded with zeros
+func (w *bitWriter) bytes() []byte {
+	if w.bits > 0 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc, w.bits = 0, 0
+	}
+	return w.buf
+}
@@ -0,0 +1,119 @@
+package assets
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+)
+
+// roundTrip encodes an image as WebP and checks it decodes to the same pixels
+func roundTrip(t *testing.T, img *image.NRGBA) []byte {
+	data, err := encodeWebP(img)
+	require.NoError(t, err)
+
+	decoded, err := webp.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, img.Bounds(), decoded.Bounds())
+	for y := range img.Bounds().Dy() {
+		for x := range img.Bounds().Dx() {
+			want := img.NRGBAAt(x, y)
+			got := color.NRGBAModel.Convert(decoded.At(x, y)).(color.NRGBA)
+			if want.A == 0 {
+				// Fully transparent pixels may decode with any color
+				assert.Zero(t, got.A, "pixel %d,%d", x, y)
+				continue
+			}
+			require.Equal(t, want, got, "pixel %d,%d", x, y)
+		}
+	}
+	return data
+}
+
+func TestEncodeWebP(t *testing.T) {
+	t.Run("Noise with transparency", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(1, 2))
+		img := image.NewNRGBA(image.Rect(0, 0, 37, 23))
+		for i := range img.Pix {
+			img.Pix[i] = byte(rng.IntN(256))
+		}
+		roundTrip(t, img)
+	})
+
+	t.Run("Flat colors are copied", func(t *testing.T) {
+		img := image.NewNRGBA(image.Rect(0, 0, 256, 256))
+		for y := range 256 {
+			for x := range 128 {
+				img.SetNRGBA(x, y, color.NRGBA{R: 255, G: 203, B: 5, A: 255})
+			}
+		}
+		data := roundTrip(t, img)
+		assert.Less(t, len(data), 1024)
+	})
+
+	t.Run("Skewed colors need length limited codes", func(t *testing.T) {
+		// Color i appears fib(i) times, a plain Huffman code for them would
+		// be deeper than 15 bits
+		values := []byte{}
+		a, b := 1, 1
+		for v := range 24 {
+			for range a {
+				values = append(values, byte(v))
+			}
+			a, b = b, a+b
+		}
+		rng := rand.New(rand.NewPCG(3, 4))
+		rng.Shuffle(len(values), func(i, j int) { values[i], values[j] = values[j], values[i] })
+
+		img := image.NewNRGBA(image.Rect(0, 0, 256, (len(values)+255)/256))
+		for i, v := range values {
+			img.Pix[i*4], img.Pix[i*4+1], img.Pix[i*4+2], img.Pix[i*4+3] = v, v*3, 255-v, 255
+		}
+		roundTrip(t, img)
+	})
+
+	t.Run("Single pixel", func(t *testing.T) {
+		img := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+		img.SetNRGBA(0, 0, color.NRGBA{R: 1, G: 2, B: 3, A: 4})
+		roundTrip(t, img)
+	})
+
+	t.Run("Too large", func(t *testing.T) {
+		_, err := encodeWebP(image.NewNRGBA(image.Rect(0, 0, maxWebPDimension+1, 1)))
+		assert.Error(t, err)
+	})
+}
+
+func TestCodeLengths(t *testing.T) {
+	counts := make([]int, 280)
+	a, b := 1, 1
+	for i := range 30 {
+		counts[i] = a
+		a, b = b, a+b
+	}
+
+	lengths := codeLengths(counts, maxCodeLength)
+	assert.LessOrEqual(t, slices.Max(lengths), maxCodeLength)
+	// The code is complete, every bit sequence decodes to a symbol
+	kraft := 0
+	for _, l := range lengths {
+		if l > 0 {
+			kraft += 1 << (maxCodeLength - l)
+		}
+	}
+	assert.Equal(t, 1<<maxCodeLength, kraft)
+
+	// A single symbol still gets a one bit code
+	counts = make([]int, 19)
+	counts[8] = 3
+	lengths = codeLengths(counts, maxCodeLengthCodeLength)
+	assert.Equal(t, 1, lengths[8])
+	assert.Equal(t, 2, countUsed(lengths))
+	assert.Equal(t, 1, slices.Max(lengths))
+}
@@ -0,0 +1,101 @@
+// igdb-match finds the IGDB games of versions that aren't in the igdb
+// package's built-in map.
+//
+//	igdb-match match                      search IGDB for unmapped versions
+//	igdb-match review                     list matches waiting for a review
+//	igdb-match confirm <version> <id>     map a version to an IGDB game by hand
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/db"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/igdb"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/services"
+	_ "github.com/glebarez/go-sqlite"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: igdb-match match | review | confirm <version> <igdb-id>")
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// The IGDB credentials may come from the environment instead
+	_ = godotenv.Load()
+
+	database, err := db.New("pokemon.db")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer database.Close()
+
+	igdbClient := igdb.NewIGDBClient(os.Getenv("IGDB_CLIENT_ID"), os.Getenv("IGDB_CLIENT_SECRET"))
+	igdbClient.TokenCachePath = ".cache/igdb_token.json"
+	matcher := services.NewGameMatcher(igdbClient, db.NewVersionRepository(database))
+
+	ctx := context.Background()
+	switch flag.Arg(0) {
+	case "match":
+		mappings, err := matcher.MatchUnmapped(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		printMappings(mappings)
+
+	case "review":
+		mappings, err := matcher.PendingMappings()
+		if err != nil {
+			log.Fatal(err)
+		}
+		printMappings(mappings)
+		if len(mappings) > 0 {
+			fmt.Println("\nConfirm a match with: igdb-match confirm <version> <igdb-id>")
+		}
+
+	case "confirm":
+		if flag.NArg() != 3 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		igdbID, err := strconv.Atoi(flag.Arg(2))
+		if err != nil {
+			log.Fatalf("invalid IGDB id %q", flag.Arg(2))
+		}
+		mapping, err := matcher.Confirm(ctx, flag.Arg(1), igdbID)
+		if err != nil {
+			log.Fatal(err)
+		}
+		printMappings([]*dto.GameMapping{mapping})
+
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func printMappings(mappings []*dto.GameMapping) {
+	if len(mappings) == 0 {
+		fmt.Println("Nothing to show")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tIGDB ID\tIGDB NAME\tCONFIDENCE\tSTATUS")
+	for _, m := range mappings {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%.2f\t%s\n", m.VersionName, m.IGDBID, m.IGDBName, m.Confidence, m.Status)
+	}
+	w.Flush()
+}
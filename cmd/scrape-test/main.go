@@ -2,17 +2,26 @@ package main
 
 import (
 	"log"
+	"time"
 
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/scraper"
 )
 
 func main() {
-	s := scraper.NewScraper()
+	rateLimiter := time.NewTicker(time.Second)
+	defer rateLimiter.Stop()
+	s := scraper.NewScraper(scraper.NewCachedFetcher(".cache/bulbapedia", scraper.NewHTTPFetcher(rateLimiter)))
 
-	// Test with Pokemon Red/Blue page
-	url := "https://bulbapedia.bulbagarden.net/wiki/Pokémon_Red_and_Blue_Versions"
-
-	if err := s.ScrapeGamePage(url); err != nil {
+	// Test with Pokemon Red/Blue
+	trainers, err := s.ScrapeTrainers("red-blue")
+	if err != nil {
 		log.Fatal(err)
 	}
+
+	for _, t := range trainers {
+		log.Printf("%s (%s) %s %s %s, %d teams", t.Name, t.Role, t.Location, t.Badge, t.TypeSpecialty, len(t.Teams))
+		for _, team := range t.Teams {
+			log.Printf("  %v battle %d: %+v", team.Games, team.Battle, team.Pokemon)
+		}
+	}
 }
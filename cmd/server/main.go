@@ -0,0 +1,95 @@
+package main
+
+import (
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/api"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/assets"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/db"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/logging"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/services"
+	_ "github.com/glebarez/go-sqlite"
+)
+
+func main() {
+	// LOG_LEVEL is debug, info, warn or error, LOG_FORMAT is text or json
+	logLevel, err := logging.ParseLevel(envOr("LOG_LEVEL", "info"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := logging.Setup(os.Stderr, logLevel, envOr("LOG_FORMAT", "text")); err != nil {
+		log.Fatal(err)
+	}
+
+	database, err := db.New("pokemon.db")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer database.Close()
+
+	// User data lives in its own file so resetting pokemon.db never loses it
+	userDatabase, err := db.NewUserDatabase("users.db")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer userDatabase.Close()
+
+	versionRepo := db.NewVersionRepository(database)
+	pokedexRepo := db.NewPokedexRepository(database)
+	encounterRepo := db.NewEncounterRepository(database)
+	pokemonRepo := db.NewPokemonRepository(database)
+	moveRepo := db.NewMoveRepository(database)
+	natureRepo := db.NewNatureRepository(database)
+	typeRepo := db.NewTypeRepository(database)
+	trainerRepo := db.NewTrainerRepository(database)
+	userRepo := db.NewUserRepository(userDatabase)
+	playthroughRepo := db.NewPlaythroughRepository(userDatabase)
+	livingDexRepo := db.NewLivingDexRepository(userDatabase)
+	nuzlockeRepo := db.NewNuzlockeRepository(userDatabase)
+
+	authService := services.NewAuthService(userRepo, services.DefaultSessionTTL)
+	playthroughService := services.NewPlaythroughService(playthroughRepo, versionRepo)
+	livingDexService := services.NewLivingDexService(livingDexRepo, pokedexRepo, versionRepo, encounterRepo)
+	versionService := services.NewVersionService(versionRepo, encounterRepo, pokemonRepo, moveRepo)
+	nuzlockeService := services.NewNuzlockeService(nuzlockeRepo, versionRepo, encounterRepo, pokemonRepo)
+	breedingPlanner := services.NewBreedingPlanner(pokemonRepo, versionRepo)
+	statCalculator := services.NewStatCalculator(pokemonRepo, versionRepo, natureRepo)
+	battlePlanner := services.NewBattlePlanner(
+		trainerRepo,
+		playthroughRepo,
+		pokemonRepo,
+		moveRepo,
+		versionRepo,
+		typeRepo,
+		encounterRepo,
+		statCalculator,
+	)
+
+	addr := envOr("ADDR", ":8080")
+	// Asset URLs in responses start with PUBLIC_URL, set it when the server
+	// runs behind a proxy or on another host
+	publicURL := os.Getenv("PUBLIC_URL")
+	if publicURL == "" {
+		publicURL = "http://" + addr
+		if strings.HasPrefix(addr, ":") {
+			publicURL = "http://localhost" + addr
+		}
+	}
+
+	// Serves the images sync downloaded, IMAGE_DIR has to match the one sync used
+	assetStore := assets.NewStore(envOr("IMAGE_DIR", "images/assets"))
+	server := api.NewServer(authService, playthroughService, livingDexService, versionService, nuzlockeService, breedingPlanner, battlePlanner, assetStore, publicURL)
+	slog.Info("listening", "addr", addr, "metrics", publicURL+"/metrics")
+	log.Fatal(http.ListenAndServe(addr, server))
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/db"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/pokeapi"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
+)
+
+func (s *syncers) syncAll() error {
+	// Everything is synced again, so are earlier failures
+	if err := s.failures.Clear(); err != nil {
+		return err
+	}
+
+	if err := s.games.SyncAllGames(versionLimit); err != nil {
+		return err
+	}
+
+	// Items not taught by machines (evolution stones, held items, ...)
+	if err := s.items.SyncAll(itemLimit); err != nil {
+		return err
+	}
+
+	if err := s.natures.SyncAll(natureLimit); err != nil {
+		return err
+	}
+
+	if err := s.types.SyncAll(typeLimit); err != nil {
+		return err
+	}
+
+	return s.trainers.SyncAll()
+}
+
+func (s *syncers) syncGame(client *pokeapi.Client, game string) error {
+	id, err := versionID(client, game)
+	if err != nil {
+		return err
+	}
+	return s.games.SyncGame(id)
+}
+
+func (s *syncers) syncPokemon(arg string) error {
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		return fmt.Errorf("invalid pokemon id %q", arg)
+	}
+	_, err = s.pokemon.SyncPokemonWithSpecies(id)
+	return err
+}
+
+// retryFailed syncs the failures of earlier runs again, one request per
+// -rate apart. Failures that sync are dropped from sync_failures.
+func (s *syncers) retryFailed() error {
+	first := true
+	fixed, failed, err := s.failures.Retry(func(f *dto.SyncFailure) error {
+		if !first {
+			<-s.rateLimiter.C
+		}
+		first = false
+		return s.retry(f)
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.Info("retried failures", "fixed", fixed, "still_failing", failed)
+	return nil
+}
+
+// retry syncs the resource of a failure the way the sync that recorded it did
+func (s *syncers) retry(f *dto.SyncFailure) error {
+	switch f.Resource {
+	case dto.ResourceGame:
+		return s.games.SyncGame(f.ResourceID)
+	case dto.ResourceSpecies:
+		return s.games.SyncGameSpecies(f.VersionID, f.ResourceID)
+	case dto.ResourceMove:
+		return s.moves.SyncMove(f.ResourceID)
+	case dto.ResourceItem:
+		return s.items.SyncItem(f.ResourceID)
+	case dto.ResourceNature:
+		return s.natures.SyncNature(f.ResourceID)
+	case dto.ResourceCharacteristic:
+		return s.natures.SyncCharacteristic(f.ResourceID)
+	case dto.ResourceType:
+		return s.types.SyncType(f.ResourceID)
+	case dto.ResourceTrainers:
+		return s.trainers.SyncVersionGroupByID(f.ResourceID)
+	}
+	return fmt.Errorf("unknown resource %q", f.Resource)
+}
+
+// versionID returns the ID of a version given by ID or by name like "red"
+func versionID(client *pokeapi.Client, game string) (int, error) {
+	if id, err := strconv.Atoi(game); err == nil {
+		return id, nil
+	}
+
+	versions, err := client.FetchAll(fmt.Sprintf("version?limit=%d", versionLimit))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch versions: %w", err)
+	}
+	for _, v := range versions {
+		if v.Name == game {
+			return utils.ExtractIDFromURL(v.Url)
+		}
+	}
+	return 0, fmt.Errorf("unknown game %q", game)
+}
+
+// dryRun reports what a command would sync. It only fetches the lists and
+// the game or pokemon it reports on, and only reads the database for the
+// failures retry-failed would retry.
+func dryRun(cfg *config, client *pokeapi.Client, command string, args []string) error {
+	switch command {
+	case "all":
+		versions, err := client.FetchAll(fmt.Sprintf("version?limit=%d", versionLimit))
+		if err != nil {
+			return fmt.Errorf("failed to fetch versions: %w", err)
+		}
+		fmt.Printf("Would sync %d games:\n", len(versions))
+		for _, v := range versions {
+			fmt.Printf("  %s\n", v.Name)
+		}
+		fmt.Println("then items, natures, types and the trainers of every game with a Bulbapedia page")
+
+	case "game":
+		id, err := versionID(client, args[0])
+		if err != nil {
+			return err
+		}
+		version, err := client.FetchVersion(id)
+		if err != nil {
+			return err
+		}
+		versionGroupID, err := utils.ExtractIDFromURL(version.VersionGroup.Url)
+		if err != nil {
+			return err
+		}
+		versionGroup, err := client.FetchVersionGroup(versionGroupID)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Would sync %s (version %d) of version group %s with its pokedexes:\n", version.Name, version.ID, versionGroup.Name)
+		for _, p := range versionGroup.Pokedexes {
+			fmt.Printf("  %s\n", p.Name)
+		}
+		fmt.Println("and every species in them with their forms, moves, abilities and encounters")
+
+	case "pokemon":
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid pokemon id %q", args[0])
+		}
+		pokemon, err := client.FetchPokemon(id)
+		if err != nil {
+			return err
+		}
+		games := 0
+		for _, sets := range pokemon.Sprites.Versions {
+			games += len(sets)
+		}
+		fmt.Printf("Would sync %s (pokemon %d) of species %s with %d forms, %d types and %d abilities,\n",
+			pokemon.Name, pokemon.ID, pokemon.Species.Name, len(pokemon.Forms), len(pokemon.Types), len(pokemon.Abilities))
+		fmt.Printf("and download its artwork and the sprites of up to %d games to %s\n", games, cfg.imageDir)
+
+	case "moves":
+		moves, err := client.FetchAll(fmt.Sprintf("move?limit=%d", moveLimit))
+		if err != nil {
+			return fmt.Errorf("failed to fetch moves: %w", err)
+		}
+		fmt.Printf("Would sync %d moves with %d workers, one request every %s\n", len(moves), cfg.concurrency, cfg.rate)
+	case "retry-failed":
+		if _, err := os.Stat(cfg.dbPath); errors.Is(err, fs.ErrNotExist) {
+			fmt.Printf("No database at %s, nothing to retry\n", cfg.dbPath)
+			return nil
+		}
+		database, err := db.New(cfg.dbPath)
+		if err != nil {
+			return err
+		}
+		defer database.Close()
+		failures, err := db.NewSyncFailureRepository(database).GetSyncFailures()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Would retry %d failures:\n", len(failures))
+		for _, f := range failures {
+			fmt.Printf("  %s %d\n", f.Resource, f.ResourceID)
+		}
+	}
+
+	fmt.Printf("into %s from %s\n", cfg.dbPath, cfg.baseURL)
+	return nil
+}
+
+// statusTables are the tables status counts, roughly in sync order
+var statusTables = []string{
+	"version_groups",
+	"versions",
+	"version_metadata",
+	"pokedexes",
+	"species",
+	"pokemon",
+	"pokemon_forms",
+	"pokemon_sprites",
+	"moves",
+	"pokemon_moves",
+	"encounters",
+	"items",
+	"machines",
+	"natures",
+	"types",
+	"trainers",
+	"assets",
+}
+
+// status prints the row counts of the synced tables
+func status(cfg *config) error {
+	// db.New would create an empty database
+	if _, err := os.Stat(cfg.dbPath); errors.Is(err, fs.ErrNotExist) {
+		fmt.Printf("No database at %s, run \"sync all\" first\n", cfg.dbPath)
+		return nil
+	}
+
+	database, err := db.New(cfg.dbPath)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	fmt.Printf("Database %s\n\n", cfg.dbPath)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tROWS")
+	for _, table := range statusTables {
+		n, err := database.CountRows(table)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s\t%d\n", table, n)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	run, err := db.NewSyncRunRepository(database).GetLatestSyncRun()
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		fmt.Println("\nNo sync runs recorded")
+	case err != nil:
+		// Databases from before sync_runs existed have no history
+		fmt.Printf("\nNo sync runs recorded: %v\n", err)
+	default:
+		printSyncRun(run)
+	}
+
+	// Databases from before sync_failures existed have none
+	if failures, err := db.NewSyncFailureRepository(database).GetSyncFailures(); err == nil && len(failures) > 0 {
+		printSyncFailures(failures)
+	}
+	return nil
+}
+
+// maxPrintedFailures limits the failures status lists
+const maxPrintedFailures = 20
+
+// printSyncFailures lists failures waiting for "sync retry-failed"
+func printSyncFailures(failures []*dto.SyncFailure) {
+	fmt.Printf("\n%d failures, run \"sync retry-failed\" to try them again\n", len(failures))
+	for _, f := range failures[:min(len(failures), maxPrintedFailures)] {
+		fmt.Printf("  %s %d", f.Resource, f.ResourceID)
+		if f.VersionID != 0 {
+			fmt.Printf(" of version %d", f.VersionID)
+		}
+		fmt.Printf(", %d attempts, last %s: %s\n", f.Attempts, f.FailedAt.Format(time.DateTime), f.Error)
+	}
+	if len(failures) > maxPrintedFailures {
+		fmt.Printf("  and %d more\n", len(failures)-maxPrintedFailures)
+	}
+}
+
+// printSyncRun prints the summary of a recorded run
+func printSyncRun(run *dto.SyncRun) {
+	fmt.Printf("\nLast run: sync %s, started %s, %s", run.Command, run.StartedAt.Format(time.DateTime), run.Status)
+	if run.FinishedAt != nil {
+		fmt.Printf(" after %s", run.Duration.Round(time.Millisecond))
+	}
+	fmt.Println()
+	if run.Error != "" {
+		fmt.Printf("  error: %s\n", run.Error)
+	}
+	fmt.Printf("  %d fetched, %d inserted, %d skipped, %d failed, %d cache hits\n",
+		run.Fetched, run.Inserted, run.Skipped, run.Failed, run.CacheHits)
+}
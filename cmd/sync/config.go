@@ -0,0 +1,139 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/logging"
+)
+
+const usage = `usage: sync [flags] <command>
+
+commands:
+  all                  sync every game, then items, natures, types and trainers
+  game <name|id>       sync one game, e.g. "sync game red" or "sync game 1"
+  pokemon <id>         sync one pokemon with its species, forms, types and abilities
+  moves                sync every move
+  retry-failed         sync again what failed in runs with -continue-on-error
+  status               show what the database holds and how the last run went
+
+Progress is drawn as a bar when stderr is a terminal, otherwise it's written
+to stdout as JSON lines. Every run is recorded in the sync_runs table, and
+its metrics are pushed to a Prometheus Pushgateway when one is set.
+
+flags (environment variables in brackets, a .env file is read when present):
+`
+
+// config is where sync reads from and writes to, and how fast
+type config struct {
+	dbPath          string
+	baseURL         string
+	imageDir        string
+	rate            time.Duration // Between PokeAPI requests
+	concurrency     int           // Moves synced at once
+	dryRun          bool
+	continueOnError bool // Record failures in sync_failures and carry on
+	logLevel        slog.Level
+	logFormat       string // text or json
+	pushURL         string // Pushgateway the metrics are pushed to, none when empty
+}
+
+// parseConfig reads flags from args on top of the environment, flags may
+// come before or after the command. It returns the command and its arguments.
+func parseConfig(args []string, getenv func(string) string, output io.Writer) (*config, []string, error) {
+	rate, err := envDuration(getenv, "SYNC_RATE", 650*time.Millisecond)
+	if err != nil {
+		return nil, nil, err
+	}
+	concurrency, err := envInt(getenv, "SYNC_CONCURRENCY", 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	logLevel, err := logging.ParseLevel(envString(getenv, "LOG_LEVEL", "info"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := &config{}
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&c.dbPath, "db", envString(getenv, "POKEMON_DB", "pokemon.db"), "SQLite database to sync into [POKEMON_DB]")
+	fs.StringVar(&c.baseURL, "base-url", envString(getenv, "POKEAPI_URL", "https://pokeapi.co"), "PokeAPI base URL [POKEAPI_URL]")
+	fs.StringVar(&c.imageDir, "images", envString(getenv, "IMAGE_DIR", "images/assets"), "directory downloaded images are stored in [IMAGE_DIR]")
+	fs.DurationVar(&c.rate, "rate", rate, "time between PokeAPI requests [SYNC_RATE]")
+	fs.IntVar(&c.concurrency, "concurrency", concurrency, "moves synced at once, still started at -rate [SYNC_CONCURRENCY]")
+	fs.BoolVar(&c.dryRun, "dry-run", false, "report what would be fetched without writing anything")
+	fs.BoolVar(&c.continueOnError, "continue-on-error", false, "record resources that fail to sync and carry on, see retry-failed")
+	fs.TextVar(&c.logLevel, "log-level", logLevel, "least severe level logged: debug, info, warn or error [LOG_LEVEL]")
+	fs.StringVar(&c.logFormat, "log-format", envString(getenv, "LOG_FORMAT", "text"), "log as text or json [LOG_FORMAT]")
+	fs.StringVar(&c.pushURL, "pushgateway", getenv("PUSHGATEWAY_URL"), "Prometheus Pushgateway URL to push metrics to when done [PUSHGATEWAY_URL]")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+
+	// flag stops at the first argument that isn't a flag, pick those up and
+	// carry on so "sync game red -dry-run" works too
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, nil, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if c.rate <= 0 {
+		return nil, nil, fmt.Errorf("rate must be positive, got %s", c.rate)
+	}
+	if c.concurrency < 1 {
+		return nil, nil, fmt.Errorf("concurrency must be at least 1, got %d", c.concurrency)
+	}
+	if c.logFormat != "text" && c.logFormat != "json" {
+		return nil, nil, fmt.Errorf("log format must be text or json, got %q", c.logFormat)
+	}
+	if len(positional) == 0 {
+		fs.Usage()
+		return nil, nil, errUsage
+	}
+
+	return c, positional, nil
+}
+
+func envString(getenv func(string) string, key, fallback string) string {
+	if v := getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func envDuration(getenv func(string) string, key string, fallback time.Duration) (time.Duration, error) {
+	v := getenv(key)
+	if v == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", key, v, err)
+	}
+	return d, nil
+}
+
+func envInt(getenv func(string) string, key string, fallback int) (int, error) {
+	v := getenv(key)
+	if v == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", key, v, err)
+	}
+	return n, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func env(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func TestParseConfigDefaults(t *testing.T) {
+	cfg, args, err := parseConfig([]string{"all"}, env(nil), io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"all"}, args)
+	assert.Equal(t, &config{
+		dbPath:      "pokemon.db",
+		baseURL:     "https://pokeapi.co",
+		imageDir:    "images/assets",
+		rate:        650 * time.Millisecond,
+		concurrency: 1,
+		logLevel:    slog.LevelInfo,
+		logFormat:   "text",
+	}, cfg)
+}
+
+func TestParseConfigFlagsOverrideEnvironment(t *testing.T) {
+	vars := env(map[string]string{
+		"POKEMON_DB":       "env.db",
+		"SYNC_RATE":        "1s",
+		"SYNC_CONCURRENCY": "4",
+		"LOG_LEVEL":        "warn",
+		"PUSHGATEWAY_URL":  "http://pushgateway:9091",
+	})
+	// Flags may follow the command and its argument
+	cfg, args, err := parseConfig([]string{"-rate", "200ms", "game", "red", "-dry-run", "-continue-on-error", "-log-level", "debug"}, vars, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"game", "red"}, args)
+	assert.Equal(t, "env.db", cfg.dbPath)
+	assert.Equal(t, 200*time.Millisecond, cfg.rate)
+	assert.Equal(t, 4, cfg.concurrency)
+	assert.True(t, cfg.dryRun)
+	assert.True(t, cfg.continueOnError)
+	assert.Equal(t, slog.LevelDebug, cfg.logLevel)
+	assert.Equal(t, "http://pushgateway:9091", cfg.pushURL)
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	_, _, err := parseConfig(nil, env(nil), io.Discard)
+	assert.ErrorIs(t, err, errUsage)
+
+	_, _, err = parseConfig([]string{"all"}, env(map[string]string{"SYNC_RATE": "fast"}), io.Discard)
+	assert.ErrorContains(t, err, "invalid SYNC_RATE")
+
+	_, _, err = parseConfig([]string{"-concurrency", "0", "moves"}, env(nil), io.Discard)
+	assert.ErrorContains(t, err, "concurrency must be at least 1")
+
+	_, _, err = parseConfig([]string{"all"}, env(map[string]string{"LOG_LEVEL": "loud"}), io.Discard)
+	assert.ErrorContains(t, err, "invalid log level")
+
+	_, _, err = parseConfig([]string{"-log-format", "xml", "all"}, env(nil), io.Discard)
+	assert.ErrorContains(t, err, "log format must be text or json")
+}
@@ -1,67 +1,314 @@
+// sync fills pokemon.db from PokeAPI, IGDB and Bulbapedia. Run "sync -h" for
+// the commands and settings.
 package main
 
 import (
+	"errors"
 	"flag"
+	"fmt"
+	"io/fs"
 	"log"
+	"log/slog"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/assets"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/db"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/igdb"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/logging"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/metrics"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/pokeapi"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/progress"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/scraper"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/services"
 	_ "github.com/glebarez/go-sqlite"
 	"github.com/joho/godotenv"
 )
 
+// Limits for PokeAPI's list endpoints, above the number of resources
+const (
+	versionLimit = 100
+	moveLimit    = 2000
+	itemLimit    = 3000
+	natureLimit  = 100
+	typeLimit    = 100
+)
+
+// How often progress is reported, JSON events go to logs so less often
+const (
+	barInterval  = 200 * time.Millisecond
+	jsonInterval = 10 * time.Second
+)
+
+// errUsage is returned for a missing or malformed command
+var errUsage = errors.New("invalid command")
+
 func main() {
-	flag.Parse()
+	// Settings may come from the environment alone, so .env is optional
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Fatal(err)
+	}
 
-	err := godotenv.Load()
+	cfg, args, err := parseConfig(os.Args[1:], os.Getenv, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if errors.Is(err, errUsage) {
+		os.Exit(2)
+	}
 	if err != nil {
-		log.Fatal(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	// The format was checked by parseConfig
+	logging.Setup(os.Stderr, cfg.logLevel, cfg.logFormat)
+
+	if err := run(cfg, args[0], args[1:]); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprintf(os.Stderr, "%v, see sync -h\n", err)
+			os.Exit(2)
+		}
+		slog.Error("sync failed", "err", err)
+		os.Exit(1)
+	}
+}
+
+// commandArgs is the number of arguments each command takes
+var commandArgs = map[string]int{
+	"all":          0,
+	"game":         1,
+	"pokemon":      1,
+	"moves":        0,
+	"retry-failed": 0,
+	"status":       0,
+}
+
+func run(cfg *config, command string, args []string) error {
+	n, ok := commandArgs[command]
+	if !ok {
+		return fmt.Errorf("%w: unknown command %q", errUsage, command)
+	}
+	if len(args) != n {
+		return fmt.Errorf("%w: %s takes %d argument(s), got %d", errUsage, command, n, len(args))
+	}
+
+	client := pokeapi.NewClient(cfg.baseURL)
+	if command == "status" {
+		return status(cfg)
+	}
+	if cfg.dryRun {
+		return dryRun(cfg, client, command, args)
 	}
 
-	database, err := db.New("pokemon.db")
+	database, err := db.New(cfg.dbPath)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	defer database.Close()
+	// SQLite allows one writer, concurrent workers would otherwise get SQLITE_BUSY
+	database.SetMaxOpenConns(1)
+
+	tracker := progress.NewTracker()
+	client.Progress = tracker
+
+	// Retries carry on past what still fails, like -continue-on-error
+	var failures *services.Failures
+	if cfg.continueOnError || command == "retry-failed" {
+		// Databases from before sync_failures existed can't record failures
+		if _, err := database.CountRows("sync_failures"); err != nil {
+			return fmt.Errorf("%s can't record failures, delete it and sync again: %w", cfg.dbPath, err)
+		}
+		failures = services.NewFailures(db.NewSyncFailureRepository(database))
+		failures.Progress = tracker
+	}
+
+	s := newSyncers(cfg, client, database, tracker, failures)
+	defer s.stop()
+
+	// The run is recorded from the start so one that dies still shows up.
+	// Databases from before sync_runs existed sync without a record.
+	syncRuns := db.NewSyncRunRepository(database)
+	record := &dto.SyncRun{Command: strings.Join(append([]string{command}, args...), " ")}
+	if err := syncRuns.StartSyncRun(record, time.Now()); err != nil {
+		slog.Warn("not recording this run", "err", err)
+		record = nil
+	}
 
-	client := pokeapi.NewClient("https://pokeapi.co")
-	rateLimiter := time.NewTicker(650 * time.Millisecond)
-	defer rateLimiter.Stop()
+	reporter := startReporter(cfg, tracker)
 
-	igdbClientID := os.Getenv("IGDB_CLIENT_ID")
-	igdbClientSecret := os.Getenv("IGDB_CLIENT_SECRET")
-	igdbClient := igdb.NewIGDBClient(igdbClientID, igdbClientSecret)
+	switch command {
+	case "all":
+		err = s.syncAll()
+	case "game":
+		err = s.syncGame(client, args[0])
+	case "pokemon":
+		err = s.syncPokemon(args[0])
+	case "moves":
+		err = s.moves.SyncAll(moveLimit, cfg.concurrency)
+	case "retry-failed":
+		err = s.retryFailed()
+	}
+
+	reporter.Stop()
+	logging.Setup(os.Stderr, cfg.logLevel, cfg.logFormat)
+
+	summary := tracker.Snapshot()
+	status := dto.SyncSucceeded
+	if err != nil {
+		status = dto.SyncFailed
+	}
+	slog.Info("sync finished",
+		"status", status,
+		"elapsed", summary.Elapsed.Round(time.Millisecond).String(),
+		"fetched", summary.Fetched,
+		"inserted", summary.Inserted,
+		"skipped", summary.Skipped,
+		"failed", summary.Failed,
+		"cache_hits", summary.CacheHits)
+
+	if record != nil {
+		finishRecord(record, summary, err)
+		if err := syncRuns.FinishSyncRun(record); err != nil {
+			slog.Warn("failed to record this run", "err", err)
+		}
+	}
+	if failures != nil {
+		if n, countErr := database.CountRows("sync_failures"); countErr == nil && n > 0 {
+			slog.Warn(`resources failed to sync, run "sync retry-failed" to try them again`, "failures", n)
+		}
+	}
+	if cfg.pushURL != "" {
+		if err := metrics.Push(cfg.pushURL, "pokemon_sync"); err != nil {
+			slog.Warn("failed to push metrics", "err", err)
+		}
+	}
+	return err
+}
+
+// startReporter reports progress as a bar on a terminal, log lines are
+// drawn above it. Otherwise JSON events go to stdout and logs to stderr.
+func startReporter(cfg *config, tracker *progress.Tracker) *progress.Reporter {
+	if progress.IsTerminal(os.Stderr) {
+		reporter := progress.NewBar(tracker, os.Stderr)
+		logging.Setup(reporter, cfg.logLevel, cfg.logFormat)
+		reporter.Start(barInterval)
+		return reporter
+	}
+	reporter := progress.NewJSON(tracker, os.Stdout)
+	reporter.Start(jsonInterval)
+	return reporter
+}
+
+// finishRecord fills in the outcome and counts of a run
+func finishRecord(record *dto.SyncRun, summary progress.Snapshot, err error) {
+	finishedAt := record.StartedAt.Add(summary.Elapsed)
+	record.FinishedAt = &finishedAt
+	record.Duration = summary.Elapsed
+	record.Status = dto.SyncSucceeded
+	if err != nil {
+		record.Status = dto.SyncFailed
+		record.Error = err.Error()
+	}
+	record.Fetched = summary.Fetched
+	record.Inserted = summary.Inserted
+	record.Skipped = summary.Skipped
+	record.Failed = summary.Failed
+	record.CacheHits = summary.CacheHits
+}
+
+// syncers are the syncers of a run, wired to one database and rate limiter
+type syncers struct {
+	games    *services.GameSyncer
+	pokemon  *services.PokemonSyncer
+	moves    *services.MoveSyncer
+	items    *services.ItemSyncer
+	natures  *services.NatureSyncer
+	types    *services.TypeSyncer
+	trainers *services.TrainerSyncer
+	failures *services.Failures // nil when the first error stops a sync
+
+	rateLimiter   *time.Ticker
+	scrapeLimiter *time.Ticker
+}
+
+func newSyncers(cfg *config, client *pokeapi.Client, database *db.Database, tracker *progress.Tracker, failures *services.Failures) *syncers {
+	rateLimiter := time.NewTicker(cfg.rate)
+
+	igdbClient := igdb.NewIGDBClient(os.Getenv("IGDB_CLIENT_ID"), os.Getenv("IGDB_CLIENT_SECRET"))
+	// Twitch tokens last about two months, reuse one across runs
+	igdbClient.TokenCachePath = ".cache/igdb_token.json"
 
 	versionRepo := db.NewVersionRepository(database)
 	pokedexRepo := db.NewPokedexRepository(database)
 	pokemonRepo := db.NewPokemonRepository(database)
 	moveRepo := db.NewMoveRepository(database)
+	encounterRepo := db.NewEncounterRepository(database)
+	itemRepo := db.NewItemRepository(database)
+	natureRepo := db.NewNatureRepository(database)
+	typeRepo := db.NewTypeRepository(database)
+	trainerRepo := db.NewTrainerRepository(database)
+	assetRepo := db.NewAssetRepository(database)
+
+	// Images are stored by content hash with thumbnails next to them
+	images := services.NewAssetService(assets.NewStore(cfg.imageDir), assetRepo)
 
-	versionSyncer := services.NewVersionSyncer(client, igdbClient, versionRepo, rateLimiter)
+	versionSyncer := services.NewVersionSyncer(client, igdbClient, versionRepo, images, rateLimiter)
 	pokedexSyncer := services.NewPokedexSyncer(client, pokedexRepo, rateLimiter)
-	pokemonSyncer := services.NewPokemonSyncer(client, pokemonRepo, rateLimiter)
+	pokemonSyncer := services.NewPokemonSyncer(client, pokemonRepo, images, rateLimiter)
 	moveSyncer := services.NewMoveSyncer(client, moveRepo, rateLimiter)
+	encounterSyncer := services.NewEncounterSyncer(client, encounterRepo, rateLimiter)
+	itemSyncer := services.NewItemSyncer(client, itemRepo, images, rateLimiter)
 
-	gameSyncer := services.NewGameSyncer(
-		versionSyncer,
-		pokedexSyncer,
-		pokemonSyncer,
-		moveSyncer,
-		rateLimiter,
-	)
+	// Bulbapedia pages are cached so a re-sync only fetches new pages
+	scrapeLimiter := time.NewTicker(time.Second)
+	fetcher := scraper.NewCachedFetcher(".cache/bulbapedia", scraper.NewHTTPFetcher(scrapeLimiter))
 
-	startTime := time.Now()
-
-	if err := gameSyncer.SyncAllGames(100); err != nil {
-		log.Fatal(err)
+	s := &syncers{
+		games: services.NewGameSyncer(
+			versionSyncer,
+			pokedexSyncer,
+			pokemonSyncer,
+			moveSyncer,
+			encounterSyncer,
+			itemSyncer,
+			rateLimiter,
+		),
+		pokemon:       pokemonSyncer,
+		moves:         moveSyncer,
+		items:         itemSyncer,
+		natures:       services.NewNatureSyncer(client, natureRepo, rateLimiter),
+		types:         services.NewTypeSyncer(client, typeRepo, rateLimiter),
+		trainers:      services.NewTrainerSyncer(scraper.NewScraper(fetcher), versionRepo, trainerRepo),
+		failures:      failures,
+		rateLimiter:   rateLimiter,
+		scrapeLimiter: scrapeLimiter,
 	}
 
-	// scraper := scraper.NewScraper()
-	// scraper.ScrapeGamePage("https://bulbapedia.bulbagarden.net/wiki/Pokémon_Gold_and_Silver_Versions")
+	// Every syncer counts into the same tracker
+	versionSyncer.Progress = tracker
+	pokemonSyncer.Progress = tracker
+	moveSyncer.Progress = tracker
+	encounterSyncer.Progress = tracker
+	itemSyncer.Progress = tracker
+	s.games.Progress = tracker
+	s.natures.Progress = tracker
+	s.types.Progress = tracker
+	s.trainers.Progress = tracker
+
+	// and records its failures in the same place
+	s.games.Failures = failures
+	moveSyncer.Failures = failures
+	itemSyncer.Failures = failures
+	s.natures.Failures = failures
+	s.types.Failures = failures
+	s.trainers.Failures = failures
+
+	return s
+}
 
-	log.Printf("Sync complete! Time taken: %v", time.Since(startTime))
+func (s *syncers) stop() {
+	s.rateLimiter.Stop()
+	s.scrapeLimiter.Stop()
 }
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/queries"
+)
+
+type AssetRepository struct {
+	db *Database
+}
+
+func NewAssetRepository(db *Database) *AssetRepository {
+	return &AssetRepository{db: db}
+}
+
+// InsertAsset replaces the rows of an asset with its original and variants
+func (r *AssetRepository) InsertAsset(a *dto.Asset) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(queries.DeleteAsset, a.Hash); err != nil {
+		return fmt.Errorf("asset delete failed: %w", err)
+	}
+
+	if _, err := tx.Exec(queries.InsertAsset, a.Hash, a.Width, a.Height, true, a.Format, a.Path, a.SourceURL); err != nil {
+		return fmt.Errorf("asset insert failed: %w", err)
+	}
+	for _, v := range a.Variants {
+		if _, err := tx.Exec(queries.InsertAsset, a.Hash, v.Width, v.Height, false, v.Format, v.Path, a.SourceURL); err != nil {
+			return fmt.Errorf("asset variant insert failed: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetAsset returns an asset by the hash of its original
+func (r *AssetRepository) GetAsset(hash string) (*dto.Asset, error) {
+	rows, err := r.db.Query(queries.GetAsset, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var asset *dto.Asset
+
+	for rows.Next() {
+		var v dto.AssetVariant
+		var isOriginal bool
+		var h, sourceURL string
+		if err := rows.Scan(&h, &v.Width, &v.Height, &isOriginal, &v.Format, &v.Path, &sourceURL); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		// The original comes first
+		if isOriginal {
+			asset = &dto.Asset{
+				Hash:      h,
+				SourceURL: sourceURL,
+				Format:    v.Format,
+				Width:     v.Width,
+				Height:    v.Height,
+				Path:      v.Path,
+				Variants:  []dto.AssetVariant{},
+			}
+			continue
+		}
+		if asset != nil {
+			asset.Variants = append(asset.Variants, v)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if asset == nil {
+		return nil, fmt.Errorf("asset %s %w", hash, ErrNotFound)
+	}
+
+	return asset, nil
+}
+
+// GetAssetBySourceURL returns the asset last downloaded from a URL
+func (r *AssetRepository) GetAssetBySourceURL(sourceURL string) (*dto.Asset, error) {
+	var hash string
+	err := r.db.QueryRow(queries.GetAssetBySourceURL, sourceURL).Scan(&hash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("asset from %s %w", sourceURL, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	return r.GetAsset(hash)
+}
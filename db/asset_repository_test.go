@@ -0,0 +1,47 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssets(t *testing.T) {
+	db := setupTest(t)
+	repo := NewAssetRepository(db)
+
+	_, err := repo.GetAssetBySourceURL("https://example.com/25.png")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	asset := &dto.Asset{
+		Hash:      "3f9a",
+		SourceURL: "https://example.com/25.png",
+		Format:    "png",
+		Width:     475,
+		Height:    475,
+		Path:      "images/assets/3f/3f9a.png",
+		Variants: []dto.AssetVariant{
+			{Format: "png", Width: 96, Height: 96, Path: "images/assets/3f/3f9a_96.png"},
+			{Format: "png", Width: 256, Height: 256, Path: "images/assets/3f/3f9a_256.png"},
+		},
+	}
+	require.NoError(t, repo.InsertAsset(asset))
+
+	got, err := repo.GetAssetBySourceURL("https://example.com/25.png")
+	require.NoError(t, err)
+	assert.Equal(t, asset, got)
+
+	// The same image from another URL replaces the rows
+	asset.SourceURL = "https://mirror.example.com/25.png"
+	asset.Variants = asset.Variants[:1]
+	require.NoError(t, repo.InsertAsset(asset))
+
+	got, err = repo.GetAsset("3f9a")
+	require.NoError(t, err)
+	assert.Equal(t, asset, got)
+
+	_, err = repo.GetAsset("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
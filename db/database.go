@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	_ "embed"
+	"errors"
 	"fmt"
 	"os"
 
@@ -12,6 +13,15 @@ import (
 //go:embed schema.sql
 var schemaSQL string
 
+//go:embed user_schema.sql
+var userSchemaSQL string
+
+// ErrNotFound is wrapped by lookups of rows that do not exist
+var ErrNotFound = errors.New("not found")
+
+// ErrConflict is wrapped by inserts that violate a unique constraint
+var ErrConflict = errors.New("already exists")
+
 type Database struct {
 	*sql.DB
 }
@@ -46,27 +56,61 @@ func New(dbPath string) (*Database, error) {
 	return db, nil
 }
 
+// NewUserDatabase opens the database holding user data. It is kept apart from
+// the reference data so resetting pokemon.db never loses it. The user schema
+// is idempotent and applied on every open.
+func NewUserDatabase(dbPath string) (*Database, error) {
+	sqlDB, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if dbPath == ":memory:" {
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	if _, err := sqlDB.Exec("PRAGMA foreign_keys = ON"); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to enable foreign keys: %w", err)
+	}
+	if _, err := sqlDB.Exec(userSchemaSQL); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("user schema initialization failed: %w", err)
+	}
+
+	return &Database{sqlDB}, nil
+}
+
 func (db *Database) initSchema() error {
 	_, err := db.Exec(schemaSQL)
 	if err != nil {
 		return fmt.Errorf("schema initialization failed: %w", err)
 	}
-	fmt.Println("Schema initialized successfully")
+	logger().Info("schema initialized")
 	return nil
 }
 
 func (db *Database) Reset() error {
-	fmt.Println("🔄 Resetting database...")
+	logger().Info("resetting database")
 
 	_, err := db.Exec(schemaSQL)
 	if err != nil {
 		return fmt.Errorf("schema reset failed: %w", err)
 	}
 
-	fmt.Println("✅ Database reset complete")
+	logger().Info("database reset complete")
 	return nil
 }
 
+// CountRows returns the number of rows in a table. The name is put into the
+// query as is, so it must not come from user input.
+func (db *Database) CountRows(table string) (int, error) {
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", table, err)
+	}
+	return n, nil
+}
+
 func fileExists(path string) bool {
 	_, err := os.Stat(path)
 	return !os.IsNotExist(err)
@@ -0,0 +1,252 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/queries"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
+)
+
+type EncounterRepository struct {
+	db *Database
+}
+
+func NewEncounterRepository(db *Database) *EncounterRepository {
+	return &EncounterRepository{db: db}
+}
+
+func (r *EncounterRepository) InsertLocation(l *external.Location) error {
+	var regionName *string
+	if l.Region != nil {
+		regionName = &l.Region.Name
+	}
+
+	_, err := r.db.Exec(queries.InsertLocation, l.ID, l.Name, regionName, englishName(l.Names, l.Name))
+	if err != nil {
+		return fmt.Errorf("location insert failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *EncounterRepository) InsertLocationArea(la *external.LocationArea) error {
+	locationID, err := utils.ExtractIDFromURL(la.Location.Url)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(queries.InsertLocationArea, la.ID, la.Name, locationID, englishName(la.Names, la.Name))
+	if err != nil {
+		return fmt.Errorf("location area insert failed: %w", err)
+	}
+
+	return nil
+}
+
+// InsertEncounter inserts one encounter slot of a Pokemon in a location area for a version
+func (r *EncounterRepository) InsertEncounter(pokemonID, locationAreaID, versionID int, detail *external.EncounterDetail) error {
+	conditions := make([]string, 0, len(detail.ConditionValues))
+	for _, cv := range detail.ConditionValues {
+		conditions = append(conditions, cv.Name)
+	}
+	slices.Sort(conditions)
+
+	_, err := r.db.Exec(queries.InsertEncounter,
+		pokemonID,
+		locationAreaID,
+		versionID,
+		detail.Method.Name,
+		detail.MinLevel,
+		detail.MaxLevel,
+		detail.Chance,
+		strings.Join(conditions, ","),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert encounter: %w", err)
+	}
+
+	return nil
+}
+
+// GetEncounters returns where a Pokemon can be found in a version
+func (r *EncounterRepository) GetEncounters(pokemonID, versionID int) ([]*dto.Encounter, error) {
+	return r.queryEncounters(queries.GetEncounters, pokemonID, versionID)
+}
+
+// GetLocationEncounters returns which Pokemon can be found in a location area in a version
+func (r *EncounterRepository) GetLocationEncounters(locationAreaID, versionID int) ([]*dto.Encounter, error) {
+	return r.queryEncounters(queries.GetLocationEncounters, locationAreaID, versionID)
+}
+
+func (r *EncounterRepository) queryEncounters(query string, args ...any) ([]*dto.Encounter, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var encounters []*dto.Encounter
+
+	for rows.Next() {
+		e := &dto.Encounter{}
+		var conditions string
+		err = rows.Scan(
+			&e.PokemonID,
+			&e.PokemonName,
+			&e.LocationAreaID,
+			&e.LocationAreaName,
+			&e.LocationAreaDisplayName,
+			&e.LocationID,
+			&e.LocationName,
+			&e.LocationDisplayName,
+			&e.VersionID,
+			&e.Method,
+			&e.MinLevel,
+			&e.MaxLevel,
+			&e.Chance,
+			&conditions,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		e.Conditions = []string{}
+		if conditions != "" {
+			e.Conditions = strings.Split(conditions, ",")
+		}
+		encounters = append(encounters, e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return encounters, nil
+}
+
+// englishName picks the English display name, falling back to the resource name
+func englishName(names []external.LocalizedName, fallback string) string {
+	idx := slices.IndexFunc(names, func(n external.LocalizedName) bool { return n.Language.Name == "en" })
+	if idx >= 0 && names[idx].Name != "" {
+		return names[idx].Name
+	}
+	return fallback
+}
+
+// GetSpeciesLocations returns where every species can be encountered in the
+// versions of a version group, ordered by species, version and location
+func (r *EncounterRepository) GetSpeciesLocations(versionGroupID int) ([]*dto.SpeciesLocation, error) {
+	rows, err := r.db.Query(queries.GetSpeciesLocations, versionGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	locations := []*dto.SpeciesLocation{}
+
+	for rows.Next() {
+		l := &dto.SpeciesLocation{}
+		err = rows.Scan(
+			&l.SpeciesID,
+			&l.SpeciesName,
+			&l.EvolutionChainID,
+			&l.VersionID,
+			&l.VersionName,
+			&l.LocationName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		locations = append(locations, l)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return locations, nil
+}
+
+// GetVersionExclusives returns the species only found in each version of a
+// version group, based on the synced encounters. Species of the group's
+// pokedexes share the versions of their evolution chain. Every version of the
+// group is listed, versions without exclusives have an empty Species list.
+func (r *EncounterRepository) GetVersionExclusives(versionGroupID int) ([]*dto.VersionExclusives, error) {
+	rows, err := r.db.Query(queries.GetVersionExclusives, versionGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	exclusives := []*dto.VersionExclusives{}
+
+	for rows.Next() {
+		var v dto.Version
+		var speciesID sql.NullInt64
+		var speciesName sql.NullString
+		err = rows.Scan(
+			&v.ID,
+			&v.Name,
+			&v.DisplayName,
+			&v.VersionGroupID,
+			&speciesID,
+			&speciesName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		// Rows are ordered by version
+		if len(exclusives) == 0 || exclusives[len(exclusives)-1].Version.ID != v.ID {
+			exclusives = append(exclusives, &dto.VersionExclusives{Version: v, Species: []dto.ExclusiveSpecies{}})
+		}
+		if speciesID.Valid {
+			last := exclusives[len(exclusives)-1]
+			last.Species = append(last.Species, dto.ExclusiveSpecies{ID: int(speciesID.Int64), Name: speciesName.String})
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return exclusives, nil
+}
+
+func (r *EncounterRepository) GetLocationByID(id int) (*dto.Location, error) {
+	var l dto.Location
+	err := r.db.QueryRow(queries.GetLocationByID, id).Scan(&l.ID, &l.Name, &l.RegionName, &l.DisplayName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("location %d %w", id, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+	return &l, nil
+}
+
+// GetVersionRegions returns the regions a version takes place in: those of
+// its pokedexes and those it has encounters in, e.g. kanto for gold
+func (r *EncounterRepository) GetVersionRegions(versionID int) ([]string, error) {
+	rows, err := r.db.Query(queries.GetVersionRegions, versionID, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	regions := []string{}
+	for rows.Next() {
+		var region string
+		if err := rows.Scan(&region); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		regions = append(regions, region)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return regions, nil
+}
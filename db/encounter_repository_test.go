@@ -0,0 +1,173 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertAndGetEncounters(t *testing.T) {
+	db := setupTest(t)
+
+	_, err := db.Exec(`INSERT INTO version_groups (id, name, generation_name) VALUES (3, "gold-silver", "generation-ii")`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO versions (id, name, version_group_id) VALUES (4, "gold", 3), (5, "silver", 3)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO species (id, name) VALUES (16, 'pidgey'), (19, 'rattata')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO pokemon (id, species_id, name, is_default) VALUES (16, 16, 'pidgey', 1), (19, 19, 'rattata', 1)`)
+	require.NoError(t, err)
+
+	repo := NewEncounterRepository(db)
+
+	require.NoError(t, repo.InsertLocation(&external.Location{
+		ID:     182,
+		Name:   "johto-route-29",
+		Region: &external.Response{Name: "johto"},
+		Names: []external.LocalizedName{
+			{Name: "Route 29", Language: external.Response{Name: "en"}},
+		},
+	}))
+	require.NoError(t, repo.InsertLocationArea(&external.LocationArea{
+		ID:       201,
+		Name:     "johto-route-29-area",
+		Location: external.Response{Name: "johto-route-29", Url: "https://pokeapi.co/api/v2/location/182/"},
+	}))
+
+	morning := &external.EncounterDetail{
+		MinLevel: 2,
+		MaxLevel: 2,
+		Chance:   30,
+		Method:   external.Response{Name: "walk"},
+		ConditionValues: []external.Response{
+			{Name: "time-morning"},
+		},
+	}
+	require.NoError(t, repo.InsertEncounter(16, 201, 4, morning))
+	require.NoError(t, repo.InsertEncounter(19, 201, 4, &external.EncounterDetail{
+		MinLevel: 2, MaxLevel: 3, Chance: 20, Method: external.Response{Name: "walk"},
+		ConditionValues: []external.Response{{Name: "time-night"}, {Name: "time-day"}},
+	}))
+	// Same slot inserted twice must not duplicate
+	require.NoError(t, repo.InsertEncounter(16, 201, 4, morning))
+
+	encounters, err := repo.GetEncounters(16, 4)
+	require.NoError(t, err)
+	expected := []*dto.Encounter{
+		{
+			PokemonID:               16,
+			PokemonName:             "pidgey",
+			LocationAreaID:          201,
+			LocationAreaName:        "johto-route-29-area",
+			LocationAreaDisplayName: "johto-route-29-area",
+			LocationID:              182,
+			LocationName:            "johto-route-29",
+			LocationDisplayName:     "Route 29",
+			VersionID:               4,
+			Method:                  "walk",
+			MinLevel:                2,
+			MaxLevel:                2,
+			Chance:                  30,
+			Conditions:              []string{"time-morning"},
+		},
+	}
+	assert.Equal(t, expected, encounters)
+
+	encounters, err = repo.GetEncounters(16, 5)
+	require.NoError(t, err)
+	assert.Empty(t, encounters)
+
+	encounters, err = repo.GetLocationEncounters(201, 4)
+	require.NoError(t, err)
+	require.Len(t, encounters, 2)
+	assert.Equal(t, "pidgey", encounters[0].PokemonName)
+	assert.Equal(t, "rattata", encounters[1].PokemonName)
+	assert.Equal(t, []string{"time-day", "time-night"}, encounters[1].Conditions)
+
+	locations, err := repo.GetSpeciesLocations(3)
+	require.NoError(t, err)
+	assert.Equal(t, []*dto.SpeciesLocation{
+		{SpeciesID: 16, SpeciesName: "pidgey", VersionID: 4, VersionName: "gold", LocationName: "Route 29"},
+		{SpeciesID: 19, SpeciesName: "rattata", VersionID: 4, VersionName: "gold", LocationName: "Route 29"},
+	}, locations)
+
+	exclusives, err := repo.GetVersionExclusives(3)
+	require.NoError(t, err)
+	require.Len(t, exclusives, 2)
+	// Silver has no encounters synced, so nothing counts as exclusive yet
+	assert.Empty(t, exclusives[0].Species)
+	assert.Empty(t, exclusives[1].Species)
+
+	require.NoError(t, repo.InsertEncounter(16, 201, 5, morning))
+	exclusives, err = repo.GetVersionExclusives(3)
+	require.NoError(t, err)
+	assert.Equal(t, []*dto.VersionExclusives{
+		{Version: dto.Version{ID: 4, Name: "gold", DisplayName: "gold", VersionGroupID: 3}, Species: []dto.ExclusiveSpecies{{ID: 19, Name: "rattata"}}},
+		{Version: dto.Version{ID: 5, Name: "silver", DisplayName: "silver", VersionGroupID: 3}, Species: []dto.ExclusiveSpecies{}},
+	}, exclusives)
+
+	// Regions come from the encounters and the pokedexes of the version group,
+	// the national dex has none
+	_, err = db.Exec(`INSERT INTO pokedexes (id, name, region_name) VALUES (1, 'national', NULL), (2, 'kanto', 'kanto')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO version_group_pokedexes (version_group_id, pokedex_id) VALUES (3, 1), (3, 2)`)
+	require.NoError(t, err)
+	regions, err := repo.GetVersionRegions(4)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"johto", "kanto"}, regions)
+	regions, err = repo.GetVersionRegions(99)
+	require.NoError(t, err)
+	assert.Empty(t, regions)
+}
+
+func TestGetVersionExclusivesThroughEvolutionChains(t *testing.T) {
+	db := setupTest(t)
+
+	_, err := db.Exec(`INSERT INTO version_groups (id, name, generation_name) VALUES (1, "red-blue", "generation-i")`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO versions (id, name, version_group_id) VALUES (1, "red", 1), (2, "blue", 1)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO evolution_chains (id) VALUES (10), (11), (13)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO species (id, name, evolution_chain_id) VALUES
+		(23, 'ekans', 10), (24, 'arbok', 10),
+		(25, 'pikachu', 11), (26, 'raichu', 11), (172, 'pichu', 11),
+		(27, 'sandshrew', 13), (28, 'sandslash', 13)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO pokemon (id, species_id, name, is_default) VALUES
+		(23, 23, 'ekans', 1), (25, 25, 'pikachu', 1), (27, 27, 'sandshrew', 1)`)
+	require.NoError(t, err)
+	// Pichu isn't in the Kanto dex, Gen 2 introduced it
+	_, err = db.Exec(`INSERT INTO pokedexes (id, name, region_name) VALUES (2, 'kanto', 'kanto')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO version_group_pokedexes (version_group_id, pokedex_id) VALUES (1, 2)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO pokedex_entries (pokedex_id, species_id, entry_number) VALUES
+		(2, 23, 23), (2, 24, 24), (2, 25, 25), (2, 26, 26), (2, 27, 27), (2, 28, 28)`)
+	require.NoError(t, err)
+
+	repo := NewEncounterRepository(db)
+	require.NoError(t, repo.InsertLocation(&external.Location{ID: 86, Name: "kanto-route-4"}))
+	require.NoError(t, repo.InsertLocationArea(&external.LocationArea{
+		ID:       298,
+		Name:     "kanto-route-4-area",
+		Location: external.Response{Name: "kanto-route-4", Url: "https://pokeapi.co/api/v2/location/86/"},
+	}))
+
+	walk := &external.EncounterDetail{MinLevel: 6, MaxLevel: 12, Chance: 35, Method: external.Response{Name: "walk"}}
+	require.NoError(t, repo.InsertEncounter(23, 298, 1, walk))
+	require.NoError(t, repo.InsertEncounter(27, 298, 2, walk))
+	require.NoError(t, repo.InsertEncounter(25, 298, 1, walk))
+	require.NoError(t, repo.InsertEncounter(25, 298, 2, walk))
+
+	// Arbok and Sandslash are never encountered, they evolve from the exclusives
+	exclusives, err := repo.GetVersionExclusives(1)
+	require.NoError(t, err)
+	assert.Equal(t, []*dto.VersionExclusives{
+		{Version: dto.Version{ID: 1, Name: "red", DisplayName: "red", VersionGroupID: 1}, Species: []dto.ExclusiveSpecies{{ID: 23, Name: "ekans"}, {ID: 24, Name: "arbok"}}},
+		{Version: dto.Version{ID: 2, Name: "blue", DisplayName: "blue", VersionGroupID: 1}, Species: []dto.ExclusiveSpecies{{ID: 27, Name: "sandshrew"}, {ID: 28, Name: "sandslash"}}},
+	}, exclusives)
+}
@@ -13,3 +13,15 @@ func setupTest(t *testing.T) *Database {
 
 	return database
 }
+
+// setupUserTest creates an in-memory user database for testing
+func setupUserTest(t *testing.T) *Database {
+	database, err := NewUserDatabase(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { database.Close() })
+
+	return database
+}
@@ -0,0 +1,125 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/queries"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
+)
+
+type ItemRepository struct {
+	db *Database
+}
+
+func NewItemRepository(db *Database) *ItemRepository {
+	return &ItemRepository{db: db}
+}
+
+func (r *ItemRepository) InsertItem(item *external.Item) error {
+	var effectShort, effectFull string
+	if entry := englishEffect(item.EffectEntries); entry != nil {
+		effectShort = entry.ShortEffect
+		effectFull = entry.Effect
+	}
+
+	_, err := r.db.Exec(queries.InsertItem,
+		item.ID,
+		item.Name,
+		englishName(item.Names, item.Name),
+		item.Category.Name,
+		item.Cost,
+		item.FlingPower,
+		effectShort,
+		effectFull,
+		item.Sprites.Default,
+	)
+	if err != nil {
+		return fmt.Errorf("item insert failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ItemRepository) InsertMachine(m *external.Machine) error {
+	itemID, err := utils.ExtractIDFromURL(m.Item.Url)
+	if err != nil {
+		return err
+	}
+	moveID, err := utils.ExtractIDFromURL(m.Move.Url)
+	if err != nil {
+		return err
+	}
+	versionGroupID, err := utils.ExtractIDFromURL(m.VersionGroup.Url)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(queries.InsertMachine, m.ID, itemID, moveID, versionGroupID)
+	if err != nil {
+		return fmt.Errorf("machine insert failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetItemByName looks up an item by its PokeAPI name, e.g. the item_name of an evolution
+func (r *ItemRepository) GetItemByName(name string) (*dto.Item, error) {
+	var item dto.Item
+	var displayName, category, effectShort, effectFull, sprite sql.NullString
+	var cost, flingPower sql.NullInt64
+
+	err := r.db.QueryRow(queries.GetItemByName, name).Scan(
+		&item.ID,
+		&item.Name,
+		&displayName,
+		&category,
+		&cost,
+		&flingPower,
+		&effectShort,
+		&effectFull,
+		&sprite,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("item %s %w", name, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	item.DisplayName = displayName.String
+	item.Category = category.String
+	item.Cost = int(cost.Int64)
+	item.FlingPower = int(flingPower.Int64)
+	item.EffectShort = effectShort.String
+	item.EffectFull = effectFull.String
+	item.Sprite = sprite.String
+
+	return &item, nil
+}
+
+// GetMachinesByVersionGroup lists the TMs/HMs/TRs of a version group and the moves they teach
+func (r *ItemRepository) GetMachinesByVersionGroup(versionGroupID int) ([]*dto.Machine, error) {
+	rows, err := r.db.Query(queries.GetMachinesByVersionGroup, versionGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var machines []*dto.Machine
+
+	for rows.Next() {
+		m := &dto.Machine{}
+		if err = rows.Scan(&m.Name, &m.MoveID, &m.MoveName); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		machines = append(machines, m)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return machines, nil
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertItem(t *testing.T) {
+	db := setupTest(t)
+	repo := NewItemRepository(db)
+
+	flingPower := 30
+	item := &external.Item{
+		ID:         83,
+		Name:       "thunder-stone",
+		Cost:       3000,
+		FlingPower: &flingPower,
+		Category:   external.Response{Name: "evolution"},
+		EffectEntries: []external.EffectEntry{
+			{
+				Effect:      "Used on a party Pokémon: Evolves a Pikachu into Raichu.",
+				Language:    external.Response{Name: "en"},
+				ShortEffect: "Evolves a Pikachu into Raichu.",
+			},
+		},
+		Names: []external.LocalizedName{
+			{Name: "Donnerstein", Language: external.Response{Name: "de"}},
+			{Name: "Thunder Stone", Language: external.Response{Name: "en"}},
+		},
+		Sprites: external.ItemSprites{Default: "images/items/thunder-stone.png"},
+	}
+	require.NoError(t, repo.InsertItem(item))
+
+	actual, err := repo.GetItemByName("thunder-stone")
+	require.NoError(t, err)
+
+	expected := &dto.Item{
+		ID:          83,
+		Name:        "thunder-stone",
+		DisplayName: "Thunder Stone",
+		Category:    "evolution",
+		Cost:        3000,
+		FlingPower:  30,
+		EffectShort: "Evolves a Pikachu into Raichu.",
+		EffectFull:  "Used on a party Pokémon: Evolves a Pikachu into Raichu.",
+		Sprite:      "images/items/thunder-stone.png",
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestGetItemByNameNotFound(t *testing.T) {
+	db := setupTest(t)
+	repo := NewItemRepository(db)
+
+	_, err := repo.GetItemByName("moon-stone")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestInsertMachine(t *testing.T) {
+	db := setupTest(t)
+
+	_, err := db.Exec(`INSERT INTO version_groups (id, name, generation_name) VALUES (1, "red-blue", "generation-i")`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO moves (id, name, type_name, pp, damage_class) VALUES (85, 'thunderbolt', 'electric', 15, 'special')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO species (id, name) VALUES (25, 'pikachu')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO pokemon (id, species_id, name, is_default) VALUES (25, 25, 'pikachu', 1)`)
+	require.NoError(t, err)
+
+	repo := NewItemRepository(db)
+	moveRepo := NewMoveRepository(db)
+
+	require.NoError(t, repo.InsertItem(&external.Item{ID: 328, Name: "tm24"}))
+	require.NoError(t, repo.InsertMachine(&external.Machine{
+		ID:           24,
+		Item:         external.Response{Name: "tm24", Url: "https://pokeapi.co/api/v2/item/328/"},
+		Move:         external.Response{Name: "thunderbolt", Url: "https://pokeapi.co/api/v2/move/85/"},
+		VersionGroup: external.Response{Name: "red-blue", Url: "https://pokeapi.co/api/v2/version-group/1/"},
+	}))
+
+	machines, err := repo.GetMachinesByVersionGroup(1)
+	require.NoError(t, err)
+	assert.Equal(t, []*dto.Machine{{Name: "TM24", MoveID: 85, MoveName: "thunderbolt"}}, machines)
+
+	require.NoError(t, moveRepo.InsertPokemonMove(25, 85, 1, "machine", 0))
+	learnset, err := moveRepo.GetPokemonMoves(25, 1)
+	require.NoError(t, err)
+	require.Len(t, learnset, 1)
+	assert.Equal(t, "TM24", learnset[0].Machine)
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/queries"
+)
+
+// LivingDexRepository works on the user database, see NewUserDatabase
+type LivingDexRepository struct {
+	db *Database
+}
+
+func NewLivingDexRepository(db *Database) *LivingDexRepository {
+	return &LivingDexRepository{db: db}
+}
+
+// InsertCaught marks a pokedex entry as caught, marking it again keeps the first caught_at
+func (r *LivingDexRepository) InsertCaught(userID, pokedexID, speciesID int, now time.Time) error {
+	if _, err := r.db.Exec(queries.InsertLivingDexEntry, userID, pokedexID, speciesID, now.Unix()); err != nil {
+		return fmt.Errorf("living dex entry insert failed: %w", err)
+	}
+	return nil
+}
+
+func (r *LivingDexRepository) DeleteCaught(userID, pokedexID, speciesID int) error {
+	if _, err := r.db.Exec(queries.DeleteLivingDexEntry, userID, pokedexID, speciesID); err != nil {
+		return fmt.Errorf("living dex entry delete failed: %w", err)
+	}
+	return nil
+}
+
+// GetCaughtSpecies returns the IDs of the species a user has caught for a pokedex
+func (r *LivingDexRepository) GetCaughtSpecies(userID, pokedexID int) (map[int]bool, error) {
+	rows, err := r.db.Query(queries.GetLivingDexEntries, userID, pokedexID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	caught := make(map[int]bool)
+	for rows.Next() {
+		var speciesID int
+		if err := rows.Scan(&speciesID); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		caught[speciesID] = true
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return caught, nil
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLivingDexEntries(t *testing.T) {
+	db := setupUserTest(t)
+	users := NewUserRepository(db)
+	repo := NewLivingDexRepository(db)
+	now := time.Unix(1700000000, 0)
+
+	ash, err := users.InsertUser("ash", "hash", now)
+	require.NoError(t, err)
+	gary, err := users.InsertUser("gary", "hash", now)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.InsertCaught(ash.ID, 2, 25, now))
+	require.NoError(t, repo.InsertCaught(ash.ID, 2, 1, now))
+	// Marking twice is a no-op
+	require.NoError(t, repo.InsertCaught(ash.ID, 2, 25, now.Add(time.Hour)))
+	// Other pokedexes and users are separate
+	require.NoError(t, repo.InsertCaught(ash.ID, 1, 150, now))
+	require.NoError(t, repo.InsertCaught(gary.ID, 2, 4, now))
+
+	caught, err := repo.GetCaughtSpecies(ash.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]bool{1: true, 25: true}, caught)
+
+	require.NoError(t, repo.DeleteCaught(ash.ID, 2, 25))
+	caught, err = repo.GetCaughtSpecies(ash.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]bool{1: true}, caught)
+}
@@ -0,0 +1,8 @@
+package db
+
+import "log/slog"
+
+// logger returns the default logger tagged with this package as component
+func logger() *slog.Logger {
+	return slog.Default().With("component", "db")
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/metrics"
+)
+
+// Exec runs a query like sql.DB.Exec. Writes are timed and their rows
+// counted per table.
+func (db *Database) Exec(query string, args ...any) (sql.Result, error) {
+	return observeExec(query, func() (sql.Result, error) { return db.DB.Exec(query, args...) })
+}
+
+// Prepare creates a prepared statement whose Exec is timed like Database.Exec
+func (db *Database) Prepare(query string) (*Stmt, error) {
+	stmt, err := db.DB.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	return &Stmt{Stmt: stmt, query: query}, nil
+}
+
+// Begin starts a transaction whose Exec is timed like Database.Exec
+func (db *Database) Begin() (*Tx, error) {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{tx}, nil
+}
+
+type Tx struct {
+	*sql.Tx
+}
+
+func (tx *Tx) Exec(query string, args ...any) (sql.Result, error) {
+	return observeExec(query, func() (sql.Result, error) { return tx.Tx.Exec(query, args...) })
+}
+
+type Stmt struct {
+	*sql.Stmt
+	query string
+}
+
+func (s *Stmt) Exec(args ...any) (sql.Result, error) {
+	return observeExec(s.query, func() (sql.Result, error) { return s.Stmt.Exec(args...) })
+}
+
+// observeExec runs exec and records it when query writes to a table, failed
+// writes are timed but change no rows
+func observeExec(query string, exec func() (sql.Result, error)) (sql.Result, error) {
+	table := metrics.WriteTable(query)
+	if table == "" {
+		return exec()
+	}
+
+	start := time.Now()
+	res, err := exec()
+	var rows int64
+	if err == nil {
+		rows, _ = res.RowsAffected()
+	}
+	metrics.ObserveWrite(table, time.Since(start), rows)
+
+	return res, err
+}
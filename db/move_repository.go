@@ -3,10 +3,14 @@ package db
 import (
 	"database/sql"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/queries"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
 )
 
 type MoveRepository struct {
@@ -24,10 +28,33 @@ func (r *MoveRepository) InsertMove(move *external.Move) error {
 	}
 	defer stmt.Close()
 
-	var effect string
-	if len(move.EffectEntries) > 0 {
-		effect = move.EffectEntries[0].ShortEffect
+	// The $effect_chance placeholder is kept and filled in on reads, the
+	// chance can differ between version groups
+	var effectShort, effectFull string
+	if entry := englishEffect(move.EffectEntries); entry != nil {
+		effectShort = entry.ShortEffect
+		effectFull = entry.Effect
 	}
+
+	var (
+		metaCategory, ailment                                       *string
+		ailmentChance, critRate, drain, healing, flinch, statChance *int
+		minHits, maxHits, minTurns, maxTurns                        *int
+	)
+	if m := move.Meta; m != nil {
+		metaCategory, ailment = &m.Category.Name, &m.Ailment.Name
+		ailmentChance, critRate, drain, healing = &m.AilmentChance, &m.CritRate, &m.Drain, &m.Healing
+		flinch, statChance = &m.FlinchChance, &m.StatChance
+		minHits, maxHits, minTurns, maxTurns = m.MinHits, m.MaxHits, m.MinTurns, m.MaxTurns
+	}
+
+	var contestType *string
+	if move.ContestType != nil {
+		contestType = &move.ContestType.Name
+	}
+	contestEffectID := optionalIDFromURL(move.ContestEffect)
+	superContestEffectID := optionalIDFromURL(move.SuperContestEffect)
+
 	_, err = stmt.Exec(
 		&move.ID,
 		&move.Name,
@@ -36,8 +63,26 @@ func (r *MoveRepository) InsertMove(move *external.Move) error {
 		&move.Accuracy,
 		&move.PP,
 		&move.DamageClass.Name,
-		effect,
+		effectShort,
 		&move.Priority,
+		move.EffectChance,
+		effectFull,
+		move.Target.Name,
+		metaCategory,
+		ailment,
+		ailmentChance,
+		critRate,
+		drain,
+		healing,
+		flinch,
+		statChance,
+		minHits,
+		maxHits,
+		minTurns,
+		maxTurns,
+		contestType,
+		contestEffectID,
+		superContestEffectID,
 	)
 	if err != nil {
 		return err
@@ -46,28 +91,74 @@ func (r *MoveRepository) InsertMove(move *external.Move) error {
 	return nil
 }
 
-func (r *MoveRepository) GetMoveByID(id int) (*dto.Move, error) {
-	var move dto.Move
+// InsertMoveStatChanges stores the stat stage changes a move applies
+func (r *MoveRepository) InsertMoveStatChanges(move *external.Move) error {
+	stmt, err := r.db.Prepare(queries.InsertMoveStatChange)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
 
-	err := r.db.QueryRow(queries.GetMoveByID, id).Scan(
-		&move.ID,
-		&move.Name,
-		&move.Type,
-		&move.Power,
-		&move.Accuracy,
-		&move.PP,
-		&move.DamageClass,
-		&move.EffectShort,
-		&move.Priority,
-	)
+	for _, sc := range move.StatChanges {
+		_, err = stmt.Exec(move.ID, sc.Stat.Name, sc.Change)
+		if err != nil {
+			return fmt.Errorf("failed to insert move stat change: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// InsertMovePastValues stores the move's past_values so reads can resolve
+// the stats that applied in older version groups. versionGroupOrders holds
+// the order of every past_values version group by ID.
+func (r *MoveRepository) InsertMovePastValues(move *external.Move, versionGroupOrders map[int]int) error {
+	stmt, err := r.db.Prepare(queries.InsertMovePastValue)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, pv := range move.PastValues {
+		versionGroupID, err := utils.ExtractIDFromURL(pv.VersionGroup.Url)
+		if err != nil {
+			return err
+		}
+
+		var typeName *string
+		if pv.Type != nil {
+			typeName = &pv.Type.Name
+		}
+
+		order, ok := versionGroupOrders[versionGroupID]
+		if !ok {
+			return fmt.Errorf("no order for version group %d of move %d", versionGroupID, move.ID)
+		}
+
+		_, err = stmt.Exec(move.ID, versionGroupID, order, typeName, pv.Power, pv.Accuracy, pv.PP, pv.EffectChance)
+		if err != nil {
+			return fmt.Errorf("failed to insert move past value: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *MoveRepository) GetMoveByID(id int) (*dto.Move, error) {
+	move, err := scanMove(r.db.QueryRow(queries.GetMoveByID, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("move %d not found", id)
+			return nil, fmt.Errorf("move %d %w", id, ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to scan row: %w", err)
 	}
 
-	return &move, nil
+	move.StatChanges, err = r.getMoveStatChanges(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return move, nil
 }
 
 // InsertPokemonMove inserts a pokemon_move relationship
@@ -85,3 +176,181 @@ func (r *MoveRepository) InsertPokemonMove(pokemonID, moveID, versionGroupID int
 
 	return nil
 }
+
+// GetMoveForVersionGroup returns a move with the type, power, accuracy, PP
+// and effect chance it had in the given version group
+func (r *MoveRepository) GetMoveForVersionGroup(id, versionGroupID int) (*dto.Move, error) {
+	move, err := scanMove(r.db.QueryRow(queries.GetMoveForVersionGroup, id, versionGroupID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("move %d in version group %d %w", id, versionGroupID, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	move.StatChanges, err = r.getMoveStatChanges(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return move, nil
+}
+
+// GetPokemonMoves returns the learnset of a Pokemon in a version group,
+// with move stats as they were in that version group
+func (r *MoveRepository) GetPokemonMoves(pokemonID, versionGroupID int) ([]*dto.PokemonMove, error) {
+	rows, err := r.db.Query(queries.GetPokemonMoves, pokemonID, versionGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var pokemonMoves []*dto.PokemonMove
+
+	for rows.Next() {
+		pm := &dto.PokemonMove{}
+		var effectChance sql.NullInt64
+		err = rows.Scan(
+			&pm.Move.ID,
+			&pm.Move.Name,
+			&pm.Move.Type,
+			&pm.Move.Power,
+			&pm.Move.Accuracy,
+			&pm.Move.PP,
+			&pm.Move.DamageClass,
+			&pm.Move.EffectShort,
+			&pm.Move.Priority,
+			&effectChance,
+			&pm.LearnMethod,
+			&pm.LevelLearnedAt,
+			&pm.Machine,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		pm.Move.EffectShort = substituteEffectChance(pm.Move.EffectShort, effectChance)
+		pm.Move.EffectChance = int(effectChance.Int64)
+		pokemonMoves = append(pokemonMoves, pm)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return pokemonMoves, nil
+}
+
+func (r *MoveRepository) getMoveStatChanges(moveID int) ([]dto.MoveStatChange, error) {
+	rows, err := r.db.Query(queries.GetMoveStatChanges, moveID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var statChanges []dto.MoveStatChange
+
+	for rows.Next() {
+		var sc dto.MoveStatChange
+		if err = rows.Scan(&sc.Stat, &sc.Change); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		statChanges = append(statChanges, sc)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return statChanges, nil
+}
+
+// scanMove scans a row selected with the column list of get_move.sql
+func scanMove(row *sql.Row) (*dto.Move, error) {
+	var move dto.Move
+
+	var effectChance, ailmentChance, critRate, drain, healing, flinch, statChance sql.NullInt64
+	var minHits, maxHits, minTurns, maxTurns sql.NullInt64
+	var effectShort, effectFull, target, metaCategory, ailment, contestType sql.NullString
+
+	err := row.Scan(
+		&move.ID,
+		&move.Name,
+		&move.Type,
+		&move.Power,
+		&move.Accuracy,
+		&move.PP,
+		&move.DamageClass,
+		&effectShort,
+		&move.Priority,
+		&effectChance,
+		&effectFull,
+		&target,
+		&metaCategory,
+		&ailment,
+		&ailmentChance,
+		&critRate,
+		&drain,
+		&healing,
+		&flinch,
+		&statChance,
+		&minHits,
+		&maxHits,
+		&minTurns,
+		&maxTurns,
+		&contestType,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	move.EffectShort = substituteEffectChance(effectShort.String, effectChance)
+	move.EffectChance = int(effectChance.Int64)
+	move.EffectFull = substituteEffectChance(effectFull.String, effectChance)
+	move.Target = target.String
+	move.ContestType = contestType.String
+
+	if metaCategory.Valid {
+		move.Meta = &dto.MoveMeta{
+			Category:      metaCategory.String,
+			Ailment:       ailment.String,
+			AilmentChance: int(ailmentChance.Int64),
+			CritRate:      int(critRate.Int64),
+			Drain:         int(drain.Int64),
+			Healing:       int(healing.Int64),
+			FlinchChance:  int(flinch.Int64),
+			StatChance:    int(statChance.Int64),
+			MinHits:       int(minHits.Int64),
+			MaxHits:       int(maxHits.Int64),
+			MinTurns:      int(minTurns.Int64),
+			MaxTurns:      int(maxTurns.Int64),
+		}
+	}
+
+	return &move, nil
+}
+
+// englishEffect picks the English entry, PokeAPI does not guarantee it comes first
+func englishEffect(entries []external.EffectEntry) *external.EffectEntry {
+	idx := slices.IndexFunc(entries, func(e external.EffectEntry) bool { return e.Language.Name == "en" })
+	if idx >= 0 {
+		return &entries[idx]
+	}
+	return nil
+}
+
+// substituteEffectChance fills in the $effect_chance placeholder PokeAPI uses in effect texts
+func substituteEffectChance(text string, effectChance sql.NullInt64) string {
+	if !effectChance.Valid {
+		return text
+	}
+	return strings.ReplaceAll(text, "$effect_chance", strconv.FormatInt(effectChance.Int64, 10))
+}
+
+func optionalIDFromURL(u *external.URL) *int {
+	if u == nil {
+		return nil
+	}
+	id, err := utils.ExtractIDFromURL(u.URL)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
@@ -68,3 +68,267 @@ func TestInsertMove(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestGetMoveForVersionGroup(t *testing.T) {
+	db := setupTest(t)
+
+	_, err := db.Exec(`INSERT INTO version_groups (id, name, generation_name, sort_order) VALUES
+		(1, "red-blue", "generation-i", 1),
+		(3, "gold-silver", "generation-ii", 3),
+		(11, "black-white", "generation-v", 14)`)
+	require.NoError(t, err)
+
+	repo := NewMoveRepository(db)
+
+	// Bite was Normal in Gen 1; the power change is made up to cover a second boundary
+	oldPower := 60
+	move := &external.Move{
+		ID:          44,
+		Name:        "bite",
+		Type:        external.Response{Name: "dark"},
+		Power:       80,
+		Accuracy:    100,
+		PP:          25,
+		DamageClass: external.Response{Name: "physical"},
+		PastValues: []external.MovePastValue{
+			{
+				Type:         &external.Response{Name: "normal"},
+				VersionGroup: external.Response{Name: "gold-silver", Url: "https://pokeapi.co/api/v2/version-group/3/"},
+			},
+			{
+				Power:        &oldPower,
+				VersionGroup: external.Response{Name: "black-white", Url: "https://pokeapi.co/api/v2/version-group/11/"},
+			},
+		},
+	}
+	require.NoError(t, repo.InsertMove(move))
+	require.NoError(t, repo.InsertMovePastValues(move, map[int]int{3: 3, 11: 14}))
+
+	tests := []struct {
+		name           string
+		versionGroupID int
+		expectedType   string
+		expectedPower  int
+	}{
+		{name: "Gen 1 uses oldest values", versionGroupID: 1, expectedType: "normal", expectedPower: 60},
+		{name: "Gen 2 uses new type, old power", versionGroupID: 3, expectedType: "dark", expectedPower: 60},
+		{name: "Gen 5 uses current values", versionGroupID: 11, expectedType: "dark", expectedPower: 80},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := repo.GetMoveForVersionGroup(44, tt.versionGroupID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedType, actual.Type)
+			assert.Equal(t, tt.expectedPower, actual.Power)
+			assert.Equal(t, 100, actual.Accuracy)
+			assert.Equal(t, 25, actual.PP)
+		})
+	}
+}
+
+func TestGetMoveForVersionGroupSingleGame(t *testing.T) {
+	db := setupTest(t)
+
+	// A single game sync of red stores red-blue only, not the version group
+	// where Bite became Dark
+	_, err := db.Exec(`INSERT INTO version_groups (id, name, generation_name, sort_order) VALUES
+		(1, "red-blue", "generation-i", 1)`)
+	require.NoError(t, err)
+
+	repo := NewMoveRepository(db)
+
+	move := &external.Move{
+		ID:          44,
+		Name:        "bite",
+		Type:        external.Response{Name: "dark"},
+		Power:       60,
+		Accuracy:    100,
+		PP:          25,
+		DamageClass: external.Response{Name: "physical"},
+		PastValues: []external.MovePastValue{
+			{
+				Type:         &external.Response{Name: "normal"},
+				VersionGroup: external.Response{Name: "gold-silver", Url: "https://pokeapi.co/api/v2/version-group/3/"},
+			},
+		},
+	}
+	require.NoError(t, repo.InsertMove(move))
+	require.NoError(t, repo.InsertMovePastValues(move, map[int]int{3: 3}))
+
+	actual, err := repo.GetMoveForVersionGroup(44, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "normal", actual.Type)
+
+	// Every past value needs its order
+	assert.Error(t, repo.InsertMovePastValues(move, map[int]int{}))
+}
+
+func TestGetPokemonMoves(t *testing.T) {
+	db := setupTest(t)
+
+	_, err := db.Exec(`INSERT INTO version_groups (id, name, generation_name, sort_order) VALUES
+		(1, "red-blue", "generation-i", 1),
+		(3, "gold-silver", "generation-ii", 3)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO species (id, name) VALUES (52, 'meowth')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO pokemon (id, species_id, name, is_default) VALUES (52, 52, 'meowth', 1)`)
+	require.NoError(t, err)
+
+	repo := NewMoveRepository(db)
+
+	move := &external.Move{
+		ID:          44,
+		Name:        "bite",
+		Type:        external.Response{Name: "dark"},
+		Power:       60,
+		Accuracy:    100,
+		PP:          25,
+		DamageClass: external.Response{Name: "physical"},
+		PastValues: []external.MovePastValue{
+			{
+				Type:         &external.Response{Name: "normal"},
+				VersionGroup: external.Response{Name: "gold-silver", Url: "https://pokeapi.co/api/v2/version-group/3/"},
+			},
+		},
+	}
+	require.NoError(t, repo.InsertMove(move))
+	require.NoError(t, repo.InsertMovePastValues(move, map[int]int{3: 3}))
+	require.NoError(t, repo.InsertPokemonMove(52, 44, 1, "level-up", 12))
+
+	learnset, err := repo.GetPokemonMoves(52, 1)
+	require.NoError(t, err)
+	require.Len(t, learnset, 1)
+
+	assert.Equal(t, "bite", learnset[0].Move.Name)
+	assert.Equal(t, "normal", learnset[0].Move.Type)
+	assert.Equal(t, "level-up", learnset[0].LearnMethod)
+	assert.Equal(t, 12, learnset[0].LevelLearnedAt)
+
+	learnset, err = repo.GetPokemonMoves(52, 3)
+	require.NoError(t, err)
+	assert.Empty(t, learnset)
+}
+
+func TestInsertMoveMetadata(t *testing.T) {
+	db := setupTest(t)
+	repo := NewMoveRepository(db)
+
+	effectChance := 100
+	move := &external.Move{
+		ID:           196,
+		Name:         "icy-wind",
+		Type:         external.Response{Name: "ice"},
+		Power:        55,
+		Accuracy:     95,
+		PP:           15,
+		DamageClass:  external.Response{Name: "special"},
+		EffectChance: &effectChance,
+		EffectEntries: []external.EffectEntry{
+			{
+				Effect:      "Senkt mit einer Chance von $effect_chance% die Initiative.",
+				Language:    external.Response{Name: "de"},
+				ShortEffect: "Senkt die Initiative.",
+			},
+			{
+				Effect:      "Inflicts regular damage. Has a $effect_chance% chance to lower the target's Speed by one stage.",
+				Language:    external.Response{Name: "en"},
+				ShortEffect: "Has a $effect_chance% chance to lower the target's Speed by one stage.",
+			},
+		},
+		Target: external.Response{Name: "all-opponents"},
+		Meta: &external.MoveMeta{
+			Ailment:    external.Response{Name: "none"},
+			Category:   external.Response{Name: "damage+lower"},
+			StatChance: 100,
+		},
+		StatChanges: []external.MoveStatChange{
+			{Change: -1, Stat: external.Response{Name: "speed"}},
+		},
+		ContestType:   &external.Response{Name: "beauty"},
+		ContestEffect: &external.URL{URL: "https://pokeapi.co/api/v2/contest-effect/10/"},
+	}
+
+	require.NoError(t, repo.InsertMove(move))
+	require.NoError(t, repo.InsertMoveStatChanges(move))
+
+	actual, err := repo.GetMoveByID(196)
+	require.NoError(t, err)
+
+	expected := &dto.Move{
+		ID:           196,
+		Name:         "icy-wind",
+		Type:         "ice",
+		Power:        55,
+		Accuracy:     95,
+		PP:           15,
+		DamageClass:  "special",
+		EffectShort:  "Has a 100% chance to lower the target's Speed by one stage.",
+		EffectChance: 100,
+		EffectFull:   "Inflicts regular damage. Has a 100% chance to lower the target's Speed by one stage.",
+		Target:       "all-opponents",
+		ContestType:  "beauty",
+		Meta: &dto.MoveMeta{
+			Category:   "damage+lower",
+			Ailment:    "none",
+			StatChance: 100,
+		},
+		StatChanges: []dto.MoveStatChange{
+			{Stat: "speed", Change: -1},
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestGetMoveForVersionGroupEffectChance(t *testing.T) {
+	db := setupTest(t)
+
+	_, err := db.Exec(`INSERT INTO version_groups (id, name, generation_name, sort_order) VALUES
+		(1, "red-blue", "generation-i", 1),
+		(3, "gold-silver", "generation-ii", 3)`)
+	require.NoError(t, err)
+
+	repo := NewMoveRepository(db)
+
+	// The effect chance changes are made up, the effect text follows them
+	effectChance, oldEffectChance := 30, 10
+	move := &external.Move{
+		ID:           44,
+		Name:         "bite",
+		Type:         external.Response{Name: "dark"},
+		Power:        60,
+		Accuracy:     100,
+		PP:           25,
+		DamageClass:  external.Response{Name: "physical"},
+		EffectChance: &effectChance,
+		EffectEntries: []external.EffectEntry{
+			{
+				Effect:      "Inflicts regular damage. Has a $effect_chance% chance to make the target flinch.",
+				Language:    external.Response{Name: "en"},
+				ShortEffect: "Has a $effect_chance% chance to make the target flinch.",
+			},
+		},
+		PastValues: []external.MovePastValue{
+			{
+				EffectChance: &oldEffectChance,
+				VersionGroup: external.Response{Name: "gold-silver", Url: "https://pokeapi.co/api/v2/version-group/3/"},
+			},
+		},
+	}
+	require.NoError(t, repo.InsertMove(move))
+	require.NoError(t, repo.InsertMovePastValues(move, map[int]int{3: 3}))
+
+	old, err := repo.GetMoveForVersionGroup(44, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 10, old.EffectChance)
+	assert.Equal(t, "Has a 10% chance to make the target flinch.", old.EffectShort)
+	assert.Equal(t, "Inflicts regular damage. Has a 10% chance to make the target flinch.", old.EffectFull)
+
+	current, err := repo.GetMoveForVersionGroup(44, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "Has a 30% chance to make the target flinch.", current.EffectShort)
+
+	_, err = repo.GetMoveForVersionGroup(44, 99)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/queries"
+)
+
+type NatureRepository struct {
+	db *Database
+}
+
+func NewNatureRepository(db *Database) *NatureRepository {
+	return &NatureRepository{db: db}
+}
+
+func (r *NatureRepository) InsertNature(n *external.Nature) error {
+	_, err := r.db.Exec(queries.InsertNature,
+		n.ID,
+		n.Name,
+		optionalName(n.IncreasedStat),
+		optionalName(n.DecreasedStat),
+		optionalName(n.LikesFlavor),
+		optionalName(n.HatesFlavor),
+	)
+	if err != nil {
+		return fmt.Errorf("nature insert failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *NatureRepository) InsertCharacteristic(c *external.Characteristic) error {
+	possibleValues := make([]string, 0, len(c.PossibleValues))
+	for _, v := range c.PossibleValues {
+		possibleValues = append(possibleValues, strconv.Itoa(v))
+	}
+
+	var description string
+	for _, d := range c.Descriptions {
+		if d.Language.Name == "en" {
+			description = d.Description
+			break
+		}
+	}
+
+	_, err := r.db.Exec(queries.InsertCharacteristic,
+		c.ID,
+		c.HighestStat.Name,
+		c.GeneModulo,
+		strings.Join(possibleValues, ","),
+		description,
+	)
+	if err != nil {
+		return fmt.Errorf("characteristic insert failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *NatureRepository) GetNatureByName(name string) (*dto.Nature, error) {
+	var nature dto.Nature
+
+	err := r.db.QueryRow(queries.GetNatureByName, name).Scan(
+		&nature.ID,
+		&nature.Name,
+		&nature.IncreasedStat,
+		&nature.DecreasedStat,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("nature %s not found", name)
+		}
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	return &nature, nil
+}
+
+func (r *NatureRepository) GetCharacteristicByID(id int) (*dto.Characteristic, error) {
+	var characteristic dto.Characteristic
+	var possibleValues string
+
+	err := r.db.QueryRow(queries.GetCharacteristicByID, id).Scan(
+		&characteristic.ID,
+		&characteristic.HighestStat,
+		&characteristic.GeneModulo,
+		&possibleValues,
+		&characteristic.Description,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("characteristic %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	for _, v := range strings.Split(possibleValues, ",") {
+		iv, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid possible value %q for characteristic %d: %w", v, id, err)
+		}
+		characteristic.PossibleValues = append(characteristic.PossibleValues, iv)
+	}
+
+	return &characteristic, nil
+}
+
+func optionalName(r *external.Response) *string {
+	if r == nil {
+		return nil
+	}
+	return &r.Name
+}
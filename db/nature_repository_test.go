@@ -0,0 +1,65 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertNature(t *testing.T) {
+	db := setupTest(t)
+	repo := NewNatureRepository(db)
+
+	adamant := &external.Nature{
+		ID:            3,
+		Name:          "adamant",
+		IncreasedStat: &external.Response{Name: "attack"},
+		DecreasedStat: &external.Response{Name: "special-attack"},
+		LikesFlavor:   &external.Response{Name: "spicy"},
+		HatesFlavor:   &external.Response{Name: "dry"},
+	}
+	hardy := &external.Nature{ID: 1, Name: "hardy"}
+	require.NoError(t, repo.InsertNature(adamant))
+	require.NoError(t, repo.InsertNature(hardy))
+
+	actual, err := repo.GetNatureByName("adamant")
+	require.NoError(t, err)
+	assert.Equal(t, &dto.Nature{ID: 3, Name: "adamant", IncreasedStat: "attack", DecreasedStat: "special-attack"}, actual)
+
+	actual, err = repo.GetNatureByName("hardy")
+	require.NoError(t, err)
+	assert.Equal(t, &dto.Nature{ID: 1, Name: "hardy"}, actual)
+
+	_, err = repo.GetNatureByName("grumpy")
+	assert.Error(t, err)
+}
+
+func TestInsertCharacteristic(t *testing.T) {
+	db := setupTest(t)
+	repo := NewNatureRepository(db)
+
+	characteristic := &external.Characteristic{
+		ID:             1,
+		GeneModulo:     0,
+		PossibleValues: []int{0, 5, 10, 15, 20, 25, 30},
+		HighestStat:    external.Response{Name: "hp"},
+		Descriptions: []external.CharacteristicDescription{
+			{Description: "Aime manger", Language: external.Response{Name: "fr"}},
+			{Description: "Loves to eat", Language: external.Response{Name: "en"}},
+		},
+	}
+	require.NoError(t, repo.InsertCharacteristic(characteristic))
+
+	actual, err := repo.GetCharacteristicByID(1)
+	require.NoError(t, err)
+	assert.Equal(t, &dto.Characteristic{
+		ID:             1,
+		HighestStat:    "hp",
+		GeneModulo:     0,
+		PossibleValues: []int{0, 5, 10, 15, 20, 25, 30},
+		Description:    "Loves to eat",
+	}, actual)
+}
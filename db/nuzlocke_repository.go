@@ -0,0 +1,269 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/queries"
+)
+
+// NuzlockeRepository works on the user database, see NewUserDatabase.
+// Runs are always looked up together with their owner.
+type NuzlockeRepository struct {
+	db *Database
+}
+
+func NewNuzlockeRepository(db *Database) *NuzlockeRepository {
+	return &NuzlockeRepository{db: db}
+}
+
+// InsertRun stores run and sets its ID and timestamps
+func (r *NuzlockeRepository) InsertRun(run *dto.NuzlockeRun, now time.Time) error {
+	result, err := r.db.Exec(
+		queries.InsertNuzlockeRun,
+		run.UserID,
+		run.VersionID,
+		run.Name,
+		run.Rules.DupesClause,
+		run.Rules.ShinyClause,
+		run.Rules.GiftClause,
+		now.Unix(),
+		now.Unix(),
+	)
+	if err != nil {
+		return fmt.Errorf("nuzlocke run insert failed: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get nuzlocke run id: %w", err)
+	}
+	run.ID = int(id)
+	run.CreatedAt = time.Unix(now.Unix(), 0)
+	run.UpdatedAt = run.CreatedAt
+
+	return nil
+}
+
+// GetRuns returns the runs of a user without encounters, most recently updated first
+func (r *NuzlockeRepository) GetRuns(userID int) ([]*dto.NuzlockeRun, error) {
+	rows, err := r.db.Query(queries.GetNuzlockeRuns, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	runs := []*dto.NuzlockeRun{}
+
+	for rows.Next() {
+		run, err := scanNuzlockeRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return runs, nil
+}
+
+// GetRun returns a run of a user with its encounters in the order they happened
+func (r *NuzlockeRepository) GetRun(userID, id int) (*dto.NuzlockeRun, error) {
+	run, err := scanNuzlockeRun(r.db.QueryRow(queries.GetNuzlockeRun, id, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("nuzlocke run %d %w", id, ErrNotFound)
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(queries.GetNuzlockeEncounters, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query encounters: %w", err)
+	}
+	defer rows.Close()
+
+	run.Encounters = []dto.NuzlockeEncounter{}
+	for rows.Next() {
+		e, err := scanNuzlockeEncounter(rows)
+		if err != nil {
+			return nil, err
+		}
+		run.Encounters = append(run.Encounters, *e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return run, nil
+}
+
+// DeleteRun deletes a run of a user with its encounters
+func (r *NuzlockeRepository) DeleteRun(userID, id int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Encounters go first, the run delete decides whether to commit
+	if _, err := tx.Exec(queries.DeleteAllNuzlockeEncounters, id); err != nil {
+		return fmt.Errorf("nuzlocke encounters delete failed: %w", err)
+	}
+	result, err := tx.Exec(queries.DeleteNuzlockeRun, id, userID)
+	if err != nil {
+		return fmt.Errorf("nuzlocke run delete failed: %w", err)
+	}
+	if err := expectRow(result, "nuzlocke run", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// InsertEncounter stores e and sets its ID and EncounteredAt
+func (r *NuzlockeRepository) InsertEncounter(e *dto.NuzlockeEncounter, now time.Time) error {
+	result, err := r.db.Exec(
+		queries.InsertNuzlockeEncounter,
+		e.RunID,
+		e.LocationID,
+		e.LocationName,
+		e.SpeciesID,
+		e.SpeciesName,
+		e.EvolutionChainID,
+		e.Nickname,
+		e.Level,
+		e.Status,
+		e.CauseOfDeath,
+		e.Shiny,
+		e.Gift,
+		now.Unix(),
+		unixOrNil(e.DiedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("nuzlocke encounter insert failed: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get nuzlocke encounter id: %w", err)
+	}
+	e.ID = int(id)
+	e.EncounteredAt = time.Unix(now.Unix(), 0)
+
+	return r.touch(e.RunID, now)
+}
+
+// UpdateEncounter saves the nickname, level, status and death of e
+func (r *NuzlockeRepository) UpdateEncounter(e *dto.NuzlockeEncounter, now time.Time) error {
+	result, err := r.db.Exec(
+		queries.UpdateNuzlockeEncounter,
+		e.Nickname,
+		e.Level,
+		e.Status,
+		e.CauseOfDeath,
+		unixOrNil(e.DiedAt),
+		e.ID,
+		e.RunID,
+	)
+	if err != nil {
+		return fmt.Errorf("nuzlocke encounter update failed: %w", err)
+	}
+	if err := expectRow(result, "nuzlocke encounter", e.ID); err != nil {
+		return err
+	}
+
+	return r.touch(e.RunID, now)
+}
+
+func (r *NuzlockeRepository) DeleteEncounter(runID, id int, now time.Time) error {
+	result, err := r.db.Exec(queries.DeleteNuzlockeEncounter, id, runID)
+	if err != nil {
+		return fmt.Errorf("nuzlocke encounter delete failed: %w", err)
+	}
+	if err := expectRow(result, "nuzlocke encounter", id); err != nil {
+		return err
+	}
+
+	return r.touch(runID, now)
+}
+
+func (r *NuzlockeRepository) touch(runID int, now time.Time) error {
+	if _, err := r.db.Exec(queries.TouchNuzlockeRun, now.Unix(), runID); err != nil {
+		return fmt.Errorf("nuzlocke run update failed: %w", err)
+	}
+	return nil
+}
+
+func scanNuzlockeRun(row rowScanner) (*dto.NuzlockeRun, error) {
+	var run dto.NuzlockeRun
+	var createdAt, updatedAt int64
+
+	err := row.Scan(
+		&run.ID,
+		&run.UserID,
+		&run.VersionID,
+		&run.Name,
+		&run.Rules.DupesClause,
+		&run.Rules.ShinyClause,
+		&run.Rules.GiftClause,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+	run.CreatedAt = time.Unix(createdAt, 0)
+	run.UpdatedAt = time.Unix(updatedAt, 0)
+
+	return &run, nil
+}
+
+func scanNuzlockeEncounter(row rowScanner) (*dto.NuzlockeEncounter, error) {
+	var e dto.NuzlockeEncounter
+	var encounteredAt int64
+	var diedAt sql.NullInt64
+
+	err := row.Scan(
+		&e.ID,
+		&e.RunID,
+		&e.LocationID,
+		&e.LocationName,
+		&e.SpeciesID,
+		&e.SpeciesName,
+		&e.EvolutionChainID,
+		&e.Nickname,
+		&e.Level,
+		&e.Status,
+		&e.CauseOfDeath,
+		&e.Shiny,
+		&e.Gift,
+		&encounteredAt,
+		&diedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+	e.EncounteredAt = time.Unix(encounteredAt, 0)
+	if diedAt.Valid {
+		t := time.Unix(diedAt.Int64, 0)
+		e.DiedAt = &t
+	}
+
+	return &e, nil
+}
+
+func unixOrNil(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.Unix()
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNuzlockeRuns(t *testing.T) {
+	db := setupUserTest(t)
+	users := NewUserRepository(db)
+	repo := NewNuzlockeRepository(db)
+	now := time.Unix(1700000000, 0)
+
+	ash, err := users.InsertUser("ash", "hash", now)
+	require.NoError(t, err)
+	gary, err := users.InsertUser("gary", "hash", now)
+	require.NoError(t, err)
+
+	run := &dto.NuzlockeRun{UserID: ash.ID, VersionID: 1, Name: "Red", Rules: dto.NuzlockeRules{DupesClause: true, ShinyClause: true}}
+	require.NoError(t, repo.InsertRun(run, now))
+	assert.NotZero(t, run.ID)
+
+	e := &dto.NuzlockeEncounter{
+		RunID: run.ID, LocationID: 88, LocationName: "Route 1", SpeciesID: 16, SpeciesName: "pidgey",
+		EvolutionChainID: 6, Nickname: "Birdie", Level: 3, Status: dto.NuzlockeAlive,
+	}
+	require.NoError(t, repo.InsertEncounter(e, now.Add(time.Minute)))
+	assert.NotZero(t, e.ID)
+
+	died := now.Add(time.Hour)
+	e.Status, e.CauseOfDeath, e.DiedAt = dto.NuzlockeDead, "Brock", &died
+	require.NoError(t, repo.UpdateEncounter(e, died))
+
+	loaded, err := repo.GetRun(ash.ID, run.ID)
+	require.NoError(t, err)
+	assert.Equal(t, run.Rules, loaded.Rules)
+	assert.Equal(t, died, loaded.UpdatedAt)
+	require.Len(t, loaded.Encounters, 1)
+	assert.Equal(t, *e, loaded.Encounters[0])
+
+	_, err = repo.GetRun(gary.ID, run.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	runs, err := repo.GetRuns(gary.ID)
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+
+	assert.ErrorIs(t, repo.DeleteEncounter(run.ID, 999, now), ErrNotFound)
+	assert.ErrorIs(t, repo.DeleteRun(gary.ID, run.ID), ErrNotFound)
+	require.NoError(t, repo.DeleteRun(ash.ID, run.ID))
+	_, err = repo.GetRun(ash.ID, run.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,255 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/queries"
+)
+
+// PlaythroughRepository works on the user database, see NewUserDatabase.
+// Playthroughs are always looked up together with their owner.
+type PlaythroughRepository struct {
+	db *Database
+}
+
+func NewPlaythroughRepository(db *Database) *PlaythroughRepository {
+	return &PlaythroughRepository{db: db}
+}
+
+// InsertPlaythrough stores p and sets its ID and timestamps
+func (r *PlaythroughRepository) InsertPlaythrough(p *dto.Playthrough, now time.Time) error {
+	result, err := r.db.Exec(queries.InsertPlaythrough, p.UserID, p.VersionID, p.Name, p.Notes, now.Unix(), now.Unix())
+	if err != nil {
+		return fmt.Errorf("playthrough insert failed: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get playthrough id: %w", err)
+	}
+	p.ID = int(id)
+	p.CreatedAt = time.Unix(now.Unix(), 0)
+	p.UpdatedAt = p.CreatedAt
+
+	return nil
+}
+
+// GetPlaythroughs returns the playthroughs of a user, most recently updated first
+func (r *PlaythroughRepository) GetPlaythroughs(userID int) ([]*dto.Playthrough, error) {
+	rows, err := r.db.Query(queries.GetPlaythroughs, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	playthroughs := []*dto.Playthrough{}
+
+	for rows.Next() {
+		p, err := scanPlaythrough(rows)
+		if err != nil {
+			return nil, err
+		}
+		playthroughs = append(playthroughs, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return playthroughs, nil
+}
+
+// GetPlaythrough returns a playthrough of a user with its species and party
+func (r *PlaythroughRepository) GetPlaythrough(userID, id int) (*dto.Playthrough, error) {
+	p, err := scanPlaythrough(r.db.QueryRow(queries.GetPlaythrough, id, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("playthrough %d %w", id, ErrNotFound)
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(queries.GetPlaythroughSpecies, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query species: %w", err)
+	}
+	defer rows.Close()
+
+	p.Caught, p.Seen = []int{}, []int{}
+	for rows.Next() {
+		var speciesID int
+		var status string
+		if err := rows.Scan(&speciesID, &status); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if status == "caught" {
+			p.Caught = append(p.Caught, speciesID)
+		} else {
+			p.Seen = append(p.Seen, speciesID)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	p.Party, err = r.getParty(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (r *PlaythroughRepository) getParty(playthroughID int) ([]dto.PartyMember, error) {
+	rows, err := r.db.Query(queries.GetParty, playthroughID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query party: %w", err)
+	}
+	defer rows.Close()
+
+	party := []dto.PartyMember{}
+	for rows.Next() {
+		var m dto.PartyMember
+		if err := rows.Scan(&m.Slot, &m.PokemonID, &m.Nickname, &m.Level); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		party = append(party, m)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return party, nil
+}
+
+// UpdatePlaythrough saves the name and notes of p
+func (r *PlaythroughRepository) UpdatePlaythrough(p *dto.Playthrough, now time.Time) error {
+	result, err := r.db.Exec(queries.UpdatePlaythrough, p.Name, p.Notes, now.Unix(), p.ID, p.UserID)
+	if err != nil {
+		return fmt.Errorf("playthrough update failed: %w", err)
+	}
+	if err := expectRow(result, "playthrough", p.ID); err != nil {
+		return err
+	}
+	p.UpdatedAt = time.Unix(now.Unix(), 0)
+
+	return nil
+}
+
+// DeletePlaythrough deletes a playthrough of a user with its species and party
+func (r *PlaythroughRepository) DeletePlaythrough(userID, id int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Children go first, the playthrough delete decides whether to commit
+	if _, err := tx.Exec(queries.DeleteAllPlaythroughSpecies, id); err != nil {
+		return fmt.Errorf("playthrough species delete failed: %w", err)
+	}
+	if _, err := tx.Exec(queries.DeleteParty, id); err != nil {
+		return fmt.Errorf("party delete failed: %w", err)
+	}
+	result, err := tx.Exec(queries.DeletePlaythrough, id, userID)
+	if err != nil {
+		return fmt.Errorf("playthrough delete failed: %w", err)
+	}
+	if err := expectRow(result, "playthrough", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetSpeciesStatus marks a species as "seen" or "caught"
+func (r *PlaythroughRepository) SetSpeciesStatus(playthroughID, speciesID int, status string, now time.Time) error {
+	if _, err := r.db.Exec(queries.InsertPlaythroughSpecies, playthroughID, speciesID, status); err != nil {
+		return fmt.Errorf("playthrough species insert failed: %w", err)
+	}
+	return r.touch(playthroughID, now)
+}
+
+// DeleteSpeciesStatus marks a species as unseen
+func (r *PlaythroughRepository) DeleteSpeciesStatus(playthroughID, speciesID int, now time.Time) error {
+	if _, err := r.db.Exec(queries.DeletePlaythroughSpecies, playthroughID, speciesID); err != nil {
+		return fmt.Errorf("playthrough species delete failed: %w", err)
+	}
+	return r.touch(playthroughID, now)
+}
+
+// SetParty replaces the whole party of a playthrough
+func (r *PlaythroughRepository) SetParty(playthroughID int, party []dto.PartyMember, now time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(queries.DeleteParty, playthroughID); err != nil {
+		return fmt.Errorf("party delete failed: %w", err)
+	}
+	for _, m := range party {
+		if _, err := tx.Exec(queries.InsertPartyMember, playthroughID, m.Slot, m.PokemonID, m.Nickname, m.Level); err != nil {
+			return fmt.Errorf("party member insert failed: %w", err)
+		}
+	}
+	if _, err := tx.Exec(queries.TouchPlaythrough, now.Unix(), playthroughID); err != nil {
+		return fmt.Errorf("playthrough update failed: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *PlaythroughRepository) touch(playthroughID int, now time.Time) error {
+	if _, err := r.db.Exec(queries.TouchPlaythrough, now.Unix(), playthroughID); err != nil {
+		return fmt.Errorf("playthrough update failed: %w", err)
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPlaythrough(row rowScanner) (*dto.Playthrough, error) {
+	var p dto.Playthrough
+	var createdAt, updatedAt int64
+
+	err := row.Scan(
+		&p.ID,
+		&p.UserID,
+		&p.VersionID,
+		&p.Name,
+		&p.Notes,
+		&createdAt,
+		&updatedAt,
+		&p.CaughtCount,
+		&p.SeenCount,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+	p.CreatedAt = time.Unix(createdAt, 0)
+	p.UpdatedAt = time.Unix(updatedAt, 0)
+
+	return &p, nil
+}
+
+// expectRow turns an update or delete that matched nothing into ErrNotFound
+func expectRow(result sql.Result, resource string, id int) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%s %d %w", resource, id, ErrNotFound)
+	}
+	return nil
+}
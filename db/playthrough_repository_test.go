@@ -0,0 +1,87 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaythroughs(t *testing.T) {
+	db := setupUserTest(t)
+	users := NewUserRepository(db)
+	repo := NewPlaythroughRepository(db)
+	now := time.Unix(1700000000, 0)
+
+	ash, err := users.InsertUser("ash", "hash", now)
+	require.NoError(t, err)
+	gary, err := users.InsertUser("gary", "hash", now)
+	require.NoError(t, err)
+
+	p := &dto.Playthrough{UserID: ash.ID, VersionID: 1, Name: "Red nuzlocke", Notes: "No items"}
+	require.NoError(t, repo.InsertPlaythrough(p, now))
+	assert.NotZero(t, p.ID)
+
+	require.NoError(t, repo.SetSpeciesStatus(p.ID, 25, "seen", now))
+	require.NoError(t, repo.SetSpeciesStatus(p.ID, 1, "caught", now))
+	require.NoError(t, repo.SetSpeciesStatus(p.ID, 16, "seen", now))
+	require.NoError(t, repo.SetSpeciesStatus(p.ID, 16, "caught", now))
+	require.NoError(t, repo.SetParty(p.ID, []dto.PartyMember{
+		{Slot: 2, PokemonID: 16, Nickname: "Birb", Level: 9},
+		{Slot: 1, PokemonID: 1, Level: 12},
+	}, now.Add(time.Minute)))
+
+	actual, err := repo.GetPlaythrough(ash.ID, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Red nuzlocke", actual.Name)
+	assert.Equal(t, []int{1, 16}, actual.Caught)
+	assert.Equal(t, []int{25}, actual.Seen)
+	assert.Equal(t, 2, actual.CaughtCount)
+	assert.Equal(t, 1, actual.SeenCount)
+	assert.Equal(t, []dto.PartyMember{
+		{Slot: 1, PokemonID: 1, Level: 12},
+		{Slot: 2, PokemonID: 16, Nickname: "Birb", Level: 9},
+	}, actual.Party)
+	assert.Equal(t, now.Add(time.Minute).Unix(), actual.UpdatedAt.Unix())
+
+	t.Run("Other users cannot see it", func(t *testing.T) {
+		_, err := repo.GetPlaythrough(gary.ID, p.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		list, err := repo.GetPlaythroughs(gary.ID)
+		require.NoError(t, err)
+		assert.Empty(t, list)
+
+		assert.ErrorIs(t, repo.DeletePlaythrough(gary.ID, p.ID), ErrNotFound)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		actual.Name = "Red"
+		require.NoError(t, repo.UpdatePlaythrough(actual, now))
+
+		list, err := repo.GetPlaythroughs(ash.ID)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.Equal(t, "Red", list[0].Name)
+		assert.Equal(t, 2, list[0].CaughtCount)
+	})
+
+	t.Run("Unsee species", func(t *testing.T) {
+		require.NoError(t, repo.DeleteSpeciesStatus(p.ID, 25, now))
+		actual, err := repo.GetPlaythrough(ash.ID, p.ID)
+		require.NoError(t, err)
+		assert.Empty(t, actual.Seen)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, repo.DeletePlaythrough(ash.ID, p.ID))
+		_, err := repo.GetPlaythrough(ash.ID, p.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		var remaining int
+		require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM playthrough_species`).Scan(&remaining))
+		assert.Zero(t, remaining)
+	})
+}
@@ -2,7 +2,6 @@ package db
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
@@ -21,7 +20,7 @@ func NewPokedexRepository(db *Database) *PokedexRepository {
 func (r *PokedexRepository) InsertPokedex(p *external.Pokedex) error {
 	stmt, err := r.db.Prepare(queries.InsertPokedex)
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("failed to prepare statement: %w", err)
 	}
 	defer stmt.Close()
 
@@ -31,7 +30,7 @@ func (r *PokedexRepository) InsertPokedex(p *external.Pokedex) error {
 		p.Region.Name,
 	)
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("failed to insert pokedex: %w", err)
 	}
 
 	return nil
@@ -54,7 +53,7 @@ func (r *PokedexRepository) InsertVersionGroupPokedex(versionGroupPokedex *exter
 			pokedexId,
 		)
 		if err != nil {
-			log.Fatal(err)
+			return fmt.Errorf("failed to insert version group pokedex: %w", err)
 		}
 	}
 
@@ -85,7 +84,7 @@ func (r *PokedexRepository) InsertPokedexPokemonEntry(pokemonEntry []external.Po
 	for _, pe := range pokemonEntry {
 		pokemonID, err := utils.ExtractIDFromURL(pe.PokemonSpecies.Url)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		_, err = stmt.Exec(pe.PokemonSpecies.Name, pe.EntryNumber, pokemonID, pokedexID)
 		if err != nil {
@@ -200,8 +199,63 @@ func (r *PokedexRepository) GetPokedexByID(id int) (*dto.Pokedex, error) {
 		return nil, fmt.Errorf("error iterationg rows: %w", err)
 	}
 	if pokedex == nil {
-		return nil, fmt.Errorf("pokedex %d not founed", id)
+		return nil, fmt.Errorf("pokedex %d %w", id, ErrNotFound)
 	}
 
 	return pokedex, nil
 }
+
+// GetPokedexEntries returns the species of a pokedex ordered by entry number
+func (r *PokedexRepository) GetPokedexEntries(pokedexID int) ([]*dto.PokemonEntry, error) {
+	rows, err := r.db.Query(queries.GetPokedexEntries, pokedexID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*dto.PokemonEntry{}
+
+	for rows.Next() {
+		entry := &dto.PokemonEntry{}
+		err = rows.Scan(
+			&entry.EntryNumber,
+			&entry.Name,
+			&entry.SpeciesID,
+			&entry.EvolutionChainID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetVersionGroupPokedexes returns the pokedexes used by a version group,
+// e.g. the central, coastal and mountain kalos pokedexes for x-y
+func (r *PokedexRepository) GetVersionGroupPokedexes(versionGroupID int) ([]*dto.Pokedex, error) {
+	rows, err := r.db.Query(queries.GetVersionGroupPokedexes, versionGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	pokedexes := []*dto.Pokedex{}
+
+	for rows.Next() {
+		pokedex := &dto.Pokedex{}
+		if err := rows.Scan(&pokedex.ID, &pokedex.Name, &pokedex.RegionName); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		pokedexes = append(pokedexes, pokedex)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return pokedexes, nil
+}
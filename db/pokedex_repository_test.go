@@ -60,4 +60,26 @@ func TestInsertPokedexEntries(t *testing.T) {
 		t.Fatalf("Failed to insert: %v", err)
 	}
 	require.NoError(t, err)
+
+	entries, err := repo.GetPokedexEntries(1)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "pikachu", entries[0].Name)
+	require.Equal(t, 1, entries[0].EntryNumber)
+}
+
+func TestGetVersionGroupPokedexes(t *testing.T) {
+	db := setupTest(t)
+
+	_, err := db.Exec(`INSERT INTO version_groups (id, name, generation_name) VALUES (1, 'red-blue', 'generation-i')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO pokedexes (id, name, region_name) VALUES (1, 'national', NULL), (2, 'kanto', 'kanto')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO version_group_pokedexes (version_group_id, pokedex_id) VALUES (1, 2)`)
+	require.NoError(t, err)
+
+	pokedexes, err := NewPokedexRepository(db).GetVersionGroupPokedexes(1)
+	require.NoError(t, err)
+	require.Len(t, pokedexes, 1)
+	require.Equal(t, "kanto", pokedexes[0].Name)
 }
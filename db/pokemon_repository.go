@@ -2,9 +2,10 @@ package db
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
-	"log"
 	"slices"
+	"strings"
 
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
@@ -23,7 +24,7 @@ func NewPokemonRepository(db *Database) *PokemonRepository {
 func (r *PokemonRepository) InsertSpecies(s *external.Species) error {
 	stmt, err := r.db.Prepare(queries.InsertSpecies)
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("failed to prepare statement: %w", err)
 	}
 
 	defer stmt.Close()
@@ -45,10 +46,18 @@ func (r *PokemonRepository) InsertSpecies(s *external.Species) error {
 		s.IsMythical,
 		s.GrowthRate.Name,
 		s.Generation.Name,
+		s.HatchCounter,
+		s.HasGenderDifferences,
 	)
 
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("species insert failed: %w", err)
+	}
+
+	for _, eggGroup := range s.EggGroups {
+		if _, err := r.db.Exec(queries.InsertSpeciesEggGroup, s.ID, eggGroup.Name); err != nil {
+			return fmt.Errorf("species egg group insert failed: %w", err)
+		}
 	}
 	return nil
 }
@@ -68,9 +77,10 @@ func (r *PokemonRepository) InsertPokemon(p *external.Pokemon) error {
 		getStat(p.Stats, "special-attack"),
 		getStat(p.Stats, "special-defense"),
 		getStat(p.Stats, "speed"),
+		p.Sprites.FrontDefault,
+		p.Sprites.FrontShiny,
 		p.Sprites.Other.OfficialArtwork.FrontDefault,
 		p.Sprites.Other.OfficialArtwork.FrontShiny,
-		p.Sprites.Other.OfficialArtwork.FrontDefault,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to exec: %w", err)
@@ -118,13 +128,205 @@ func (r *PokemonRepository) InsertType(t *external.PokemonType, pokemonId int) e
 	return nil
 }
 
+// InsertPastTypes stores the types a pokemon had in earlier generations
+func (r *PokemonRepository) InsertPastTypes(p *external.Pokemon) error {
+	for _, past := range p.PastTypes {
+		generationID, err := utils.ExtractIDFromURL(past.Generation.Url)
+		if err != nil {
+			return err
+		}
+		for _, t := range past.Types {
+			if _, err := r.db.Exec(queries.InsertPokemonPastType, p.ID, generationID, t.Type.Name, t.Slot); err != nil {
+				return fmt.Errorf("failed to insert past type %s for pokemon %d: %w", t.Type.Name, p.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetPokemonTypes returns the type names of a pokemon in the given generation, ordered by slot
+func (r *PokemonRepository) GetPokemonTypes(pokemonID, generation int) ([]string, error) {
+	rows, err := r.db.Query(queries.GetPokemonTypes, pokemonID, generation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var types []string
+
+	for rows.Next() {
+		var typeName string
+		var slot int
+		if err := rows.Scan(&typeName, &slot); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		types = append(types, typeName)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	if len(types) == 0 {
+		return nil, fmt.Errorf("types for pokemon %d not found", pokemonID)
+	}
+
+	return types, nil
+}
+
+func (r *PokemonRepository) InsertPokemonForm(f *external.PokemonForm) error {
+	pokemonID, err := utils.ExtractIDFromURL(f.Pokemon.Url)
+	if err != nil {
+		return err
+	}
+
+	var introducedVersionGroupID *int
+	if f.VersionGroup.Url != "" {
+		id, err := utils.ExtractIDFromURL(f.VersionGroup.Url)
+		if err != nil {
+			return err
+		}
+		introducedVersionGroupID = &id
+	}
+
+	_, err = r.db.Exec(queries.InsertPokemonForm,
+		f.ID,
+		pokemonID,
+		f.Name,
+		f.FormName,
+		f.FormOrder,
+		f.IsDefault,
+		f.IsBattleOnly,
+		f.IsMega,
+		introducedVersionGroupID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert pokemon form: %w", err)
+	}
+
+	return nil
+}
+
+// InsertPokemonFormVersionGroup marks a form as available in a version group
+func (r *PokemonRepository) InsertPokemonFormVersionGroup(formID, versionGroupID int) error {
+	_, err := r.db.Exec(queries.InsertPokemonFormVersionGroup, formID, versionGroupID)
+	if err != nil {
+		return fmt.Errorf("failed to insert pokemon_form_version_group: %w", err)
+	}
+
+	return nil
+}
+
+// GetSpeciesForms returns all forms of all varieties of a species that are
+// available in the given version group, default variety first
+func (r *PokemonRepository) GetSpeciesForms(speciesID, versionGroupID int) ([]*dto.PokemonForm, error) {
+	rows, err := r.db.Query(queries.GetSpeciesForms, speciesID, versionGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var forms []*dto.PokemonForm
+
+	for rows.Next() {
+		form := &dto.PokemonForm{}
+		err = rows.Scan(
+			&form.ID,
+			&form.PokemonID,
+			&form.Name,
+			&form.FormName,
+			&form.IsDefault,
+			&form.PokemonIsDefault,
+			&form.IsBattleOnly,
+			&form.IsMega,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		forms = append(forms, form)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return forms, nil
+}
+
+// InsertPokemonSprites replaces the in-game sprites of a pokemon
+func (r *PokemonRepository) InsertPokemonSprites(pokemonID int, sprites []*dto.PokemonSprite) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(queries.DeletePokemonSprites, pokemonID); err != nil {
+		return fmt.Errorf("pokemon sprites delete failed: %w", err)
+	}
+
+	for _, sp := range sprites {
+		_, err := tx.Exec(
+			queries.InsertPokemonSprite,
+			pokemonID,
+			sp.Generation,
+			sp.VersionGroup,
+			sp.SpriteSet,
+			sp.Side,
+			sp.Shiny,
+			sp.Female,
+			sp.Animated,
+			sp.Path,
+		)
+		if err != nil {
+			return fmt.Errorf("pokemon sprite insert failed: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetPokemonSprites returns the in-game sprites of a pokemon in a version
+// group, front sprites before back sprites and still before animated
+func (r *PokemonRepository) GetPokemonSprites(pokemonID int, versionGroup string) ([]*dto.PokemonSprite, error) {
+	rows, err := r.db.Query(queries.GetPokemonSprites, pokemonID, versionGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	sprites := []*dto.PokemonSprite{}
+
+	for rows.Next() {
+		sp := &dto.PokemonSprite{}
+		err = rows.Scan(
+			&sp.PokemonID,
+			&sp.Generation,
+			&sp.VersionGroup,
+			&sp.SpriteSet,
+			&sp.Side,
+			&sp.Shiny,
+			&sp.Female,
+			&sp.Animated,
+			&sp.Path,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		sprites = append(sprites, sp)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return sprites, nil
+}
+
 func (r *PokemonRepository) GetPokemonByID(id int) (*dto.Pokemon, error) {
 	var pokemon dto.Pokemon
 
 	var height, weight, baseExp, hp, attack, defense, spatk, spdef, speed sql.NullInt64
 	var isDefault bool
 
-	queryStr := `SELECT id, species_id, name, is_default, height, weight, base_experience, hp, attack, defense, special_attack, special_defense, speed, sprite_front_default, sprite_front_shiny, sprite_artwork FROM pokemon WHERE id = ?`
+	queryStr := `SELECT id, species_id, name, is_default, height, weight, base_experience, hp, attack, defense, special_attack, special_defense, speed, sprite_front_default, sprite_front_shiny, sprite_artwork, sprite_artwork_shiny FROM pokemon WHERE id = ?`
 
 	err := r.db.QueryRow(queryStr, id).Scan(
 		&pokemon.ID,
@@ -143,10 +345,11 @@ func (r *PokemonRepository) GetPokemonByID(id int) (*dto.Pokemon, error) {
 		&pokemon.SpriteFrontDefault,
 		&pokemon.SpriteFrontShiny,
 		&pokemon.SpriteArtwork,
+		&pokemon.SpriteArtworkShiny,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("pokemon %d not found", id)
+			return nil, fmt.Errorf("pokemon %d %w", id, ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to scan row: %w", err)
 	}
@@ -166,6 +369,54 @@ func (r *PokemonRepository) GetPokemonByID(id int) (*dto.Pokemon, error) {
 	return &pokemon, nil
 }
 
+func (r *PokemonRepository) GetSpeciesByID(id int) (*dto.Species, error) {
+	var s dto.Species
+	err := r.db.QueryRow(queries.GetSpeciesByID, id).Scan(&s.ID, &s.Name, &s.EvolutionChainID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("species %d %w", id, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+	return &s, nil
+}
+
+// GetBreedingCandidates returns the species that learn a move by level-up or
+// as an egg move in a version group
+func (r *PokemonRepository) GetBreedingCandidates(moveID, versionGroupID int) ([]*dto.BreedingCandidate, error) {
+	rows, err := r.db.Query(queries.GetBreedingCandidates, moveID, versionGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := []*dto.BreedingCandidate{}
+
+	for rows.Next() {
+		c := &dto.BreedingCandidate{}
+		var eggGroups string
+		var level sql.NullInt64
+		err = rows.Scan(&c.SpeciesID, &c.Name, &c.GenderRate, &eggGroups, &level, &c.EggMove)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if eggGroups != "" {
+			c.EggGroups = strings.Split(eggGroups, ",")
+			slices.Sort(c.EggGroups)
+		}
+		if level.Valid {
+			l := int(level.Int64)
+			c.LevelLearnedAt = &l
+		}
+		candidates = append(candidates, c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return candidates, nil
+}
+
 func getStat(stats []external.Stat, key string) int {
 	idx := slices.IndexFunc(stats, func(c external.Stat) bool { return c.Stat.Name == key })
 	if idx >= 0 {
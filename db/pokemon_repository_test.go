@@ -118,6 +118,7 @@ func TestInsertPokemon(t *testing.T) {
 		SpriteFrontDefault: "",
 		SpriteFrontShiny:   "",
 		SpriteArtwork:      "",
+		SpriteArtworkShiny: "",
 	}
 	assert.Equal(t, expected, actual)
 }
@@ -151,10 +152,38 @@ func TestInsertSpecies(t *testing.T) {
 			Name: "generation-ii",
 			Url:  "https://pokeapi.co/api/v2/generation/2/",
 		},
+		EggGroups:    []external.Response{{Name: "plant"}, {Name: "monster"}},
+		HatchCounter: 20,
 	}
 
 	err = repo.InsertSpecies(species)
 	require.NoError(t, err)
+
+	var hatchCounter int
+	require.NoError(t, db.QueryRow(`SELECT hatch_counter FROM species WHERE id = 152`).Scan(&hatchCounter))
+	assert.Equal(t, 20, hatchCounter)
+
+	_, err = db.Exec(`INSERT INTO version_groups (id, name, generation_name) VALUES (8, 'diamond-pearl', 'generation-iv')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO pokemon (id, species_id, name, is_default) VALUES (152, 152, 'chikorita', 1)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO moves (id, name, type_name, pp, damage_class, priority) VALUES (113, 'light-screen', 'psychic', 30, 'status', 0)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO pokemon_moves (pokemon_id, move_id, version_group_id, learn_method, level_learned_at)
+		VALUES (152, 113, 8, 'level-up', 31), (152, 113, 8, 'egg', 0), (152, 113, 8, 'machine', 0)`)
+	require.NoError(t, err)
+
+	candidates, err := repo.GetBreedingCandidates(113, 8)
+	require.NoError(t, err)
+	level := 31
+	assert.Equal(t, []*dto.BreedingCandidate{{
+		SpeciesID:      152,
+		Name:           "chikorita",
+		GenderRate:     1,
+		EggGroups:      []string{"monster", "plant"},
+		LevelLearnedAt: &level,
+		EggMove:        true,
+	}}, candidates)
 }
 
 func TestInsertVersionGroupPokedex(t *testing.T) {
@@ -385,3 +414,140 @@ func TestInsertAbility(t *testing.T) {
 	assert.NotNil(t, got)
 	assert.Equal(t, expected, got)
 }
+
+func TestGetSpeciesForms(t *testing.T) {
+	db := setupTest(t)
+
+	_, err := db.Exec(`INSERT INTO version_groups (id, name, generation_name) VALUES
+		(1, "red-blue", "generation-i"),
+		(17, "sun-moon", "generation-vii")`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO species (id, name) VALUES (26, 'raichu')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO pokemon (id, species_id, name, is_default) VALUES
+		(26, 26, 'raichu', 1),
+		(10100, 26, 'raichu-alola', 0)`)
+	require.NoError(t, err)
+
+	repo := NewPokemonRepository(db)
+
+	forms := []*external.PokemonForm{
+		{
+			ID:           26,
+			Name:         "raichu",
+			IsDefault:    true,
+			Pokemon:      external.Response{Name: "raichu", Url: "https://pokeapi.co/api/v2/pokemon/26/"},
+			VersionGroup: external.Response{Name: "red-blue", Url: "https://pokeapi.co/api/v2/version-group/1/"},
+		},
+		{
+			ID:           10100,
+			Name:         "raichu-alola",
+			FormName:     "alola",
+			IsDefault:    true,
+			Pokemon:      external.Response{Name: "raichu-alola", Url: "https://pokeapi.co/api/v2/pokemon/10100/"},
+			VersionGroup: external.Response{Name: "sun-moon", Url: "https://pokeapi.co/api/v2/version-group/17/"},
+		},
+	}
+	for _, f := range forms {
+		require.NoError(t, repo.InsertPokemonForm(f))
+	}
+	require.NoError(t, repo.InsertPokemonFormVersionGroup(26, 1))
+	require.NoError(t, repo.InsertPokemonFormVersionGroup(26, 17))
+	require.NoError(t, repo.InsertPokemonFormVersionGroup(10100, 17))
+
+	actual, err := repo.GetSpeciesForms(26, 1)
+	require.NoError(t, err)
+	require.Len(t, actual, 1)
+	assert.Equal(t, "raichu", actual[0].Name)
+
+	actual, err = repo.GetSpeciesForms(26, 17)
+	require.NoError(t, err)
+	expected := []*dto.PokemonForm{
+		{ID: 26, PokemonID: 26, Name: "raichu", FormName: "", IsDefault: true, PokemonIsDefault: true},
+		{ID: 10100, PokemonID: 10100, Name: "raichu-alola", FormName: "alola", IsDefault: true, PokemonIsDefault: false},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestGetPokemonTypes(t *testing.T) {
+	db := setupTest(t)
+	repo := NewPokemonRepository(db)
+
+	_, err := db.Exec(`INSERT INTO species (id, name) VALUES (35, 'clefairy')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO pokemon (id, species_id, name, is_default) VALUES (35, 35, 'clefairy', TRUE)`)
+	require.NoError(t, err)
+
+	clefairy := &external.Pokemon{
+		ID:    35,
+		Types: []external.PokemonType{{Type: external.Response{Name: "fairy"}, Slot: 1}},
+		PastTypes: []external.PastType{
+			{
+				Generation: external.Response{Name: "generation-v", Url: "https://pokeapi.co/api/v2/generation/5/"},
+				Types:      []external.PokemonType{{Type: external.Response{Name: "normal"}, Slot: 1}},
+			},
+		},
+	}
+	require.NoError(t, repo.InsertType(&clefairy.Types[0], 35))
+	require.NoError(t, repo.InsertPastTypes(clefairy))
+
+	types, err := repo.GetPokemonTypes(35, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"normal"}, types)
+
+	types, err = repo.GetPokemonTypes(35, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"normal"}, types)
+
+	types, err = repo.GetPokemonTypes(35, 6)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fairy"}, types)
+
+	_, err = repo.GetPokemonTypes(36, 6)
+	assert.Error(t, err)
+}
+
+func TestPokemonSprites(t *testing.T) {
+	db := setupTest(t)
+
+	_, err := db.Exec(`INSERT INTO species (id, name) VALUES (25, 'pikachu')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO pokemon (id, species_id, name, is_default) VALUES (25, 25, 'pikachu', 1)`)
+	require.NoError(t, err)
+
+	repo := NewPokemonRepository(db)
+
+	sprite := func(set, versionGroup, side string, shiny, animated bool) *dto.PokemonSprite {
+		return &dto.PokemonSprite{
+			PokemonID:    25,
+			Generation:   2,
+			VersionGroup: versionGroup,
+			SpriteSet:    set,
+			Side:         side,
+			Shiny:        shiny,
+			Animated:     animated,
+			Path:         "images/assets/" + set + "_" + side + ".png",
+		}
+	}
+
+	require.NoError(t, repo.InsertPokemonSprites(25, []*dto.PokemonSprite{
+		sprite("crystal", "crystal", dto.SpriteFront, false, false),
+	}))
+
+	// Syncing again replaces the old sprites
+	sprites := []*dto.PokemonSprite{
+		sprite("silver", "gold-silver", dto.SpriteBack, false, false),
+		sprite("gold", "gold-silver", dto.SpriteFront, true, false),
+		sprite("gold", "gold-silver", dto.SpriteBack, false, false),
+		sprite("gold", "gold-silver", dto.SpriteFront, false, false),
+	}
+	require.NoError(t, repo.InsertPokemonSprites(25, sprites))
+
+	actual, err := repo.GetPokemonSprites(25, "gold-silver")
+	require.NoError(t, err)
+	assert.Equal(t, []*dto.PokemonSprite{sprites[3], sprites[1], sprites[2], sprites[0]}, actual)
+
+	actual, err = repo.GetPokemonSprites(25, "crystal")
+	require.NoError(t, err)
+	assert.Empty(t, actual)
+}
@@ -3,6 +3,7 @@ DROP TABLE IF EXISTS version;
 DROP TABLE IF EXISTS pokedexes;
 DROP TABLE IF EXISTS version_group_pokedexes;
 DROP TABLE IF EXISTS species;
+DROP TABLE IF EXISTS species_egg_groups;
 DROP TABLE IF EXISTS pokedex_entries;
 DROP TABLE IF EXISTS pokemon;
 DROP TABLE IF EXISTS pokemon_types;
@@ -14,6 +15,29 @@ DROP TABLE IF EXISTS pokemon_moves;
 DROP TABLE IF EXISTS type_effectiveness;
 DROP TABLE IF EXISTS abilities;
 DROP TABLE IF EXISTS flavor_texts;
+DROP VIEW IF EXISTS move_stats_by_version_group;
+DROP TABLE IF EXISTS move_past_values;
+DROP TABLE IF EXISTS move_stat_changes;
+DROP TABLE IF EXISTS pokemon_forms;
+DROP TABLE IF EXISTS pokemon_form_version_groups;
+DROP TABLE IF EXISTS locations;
+DROP TABLE IF EXISTS location_areas;
+DROP TABLE IF EXISTS encounters;
+DROP TABLE IF EXISTS items;
+DROP TABLE IF EXISTS machines;
+DROP TABLE IF EXISTS natures;
+DROP TABLE IF EXISTS characteristics;
+DROP TABLE IF EXISTS types;
+DROP TABLE IF EXISTS type_effectiveness_past;
+DROP TABLE IF EXISTS pokemon_past_types;
+DROP TABLE IF EXISTS trainers;
+DROP TABLE IF EXISTS trainer_pokemon;
+DROP TABLE IF EXISTS version_metadata;
+DROP TABLE IF EXISTS version_release_dates;
+DROP TABLE IF EXISTS version_media;
+DROP TABLE IF EXISTS assets;
+DROP TABLE IF EXISTS pokemon_sprites;
+DROP TABLE IF EXISTS sync_failures;
 
 -- ============================================================================
 -- GAME STRUCTURE TABLES
@@ -25,7 +49,8 @@ DROP TABLE IF EXISTS flavor_texts;
 CREATE TABLE version_groups (
     id INTEGER PRIMARY KEY,
     name TEXT UNIQUE NOT NULL,           -- e.g., "black-white", "sword-shield"
-    generation_name TEXT NOT NULL        -- e.g., "generation-v"
+    generation_name TEXT NOT NULL,       -- e.g., "generation-v"
+    sort_order INTEGER                   -- version-group.order, chronological release order
 );
 
 -- Populated from: GET /version?limit=100
@@ -39,6 +64,51 @@ CREATE TABLE versions (
     version_group_id INTEGER NOT NULL REFERENCES version_groups(id)
 );
 
+-- Populated from: IGDB games, one batched query per sync
+-- What IGDB knows about the game of a version
+CREATE TABLE version_metadata (
+    version_id INTEGER PRIMARY KEY REFERENCES versions(id),
+    igdb_id INTEGER NOT NULL,
+    summary TEXT NOT NULL DEFAULT '',
+    storyline TEXT NOT NULL DEFAULT '',
+    platforms TEXT NOT NULL DEFAULT '',  -- Comma-separated, e.g. "Game Boy,Super Game Boy"
+    developers TEXT NOT NULL DEFAULT '', -- Comma-separated, e.g. "Game Freak"
+    publishers TEXT NOT NULL DEFAULT ''  -- Comma-separated, e.g. "Nintendo"
+);
+
+-- Populated from: IGDB games.release_dates
+CREATE TABLE version_release_dates (
+    version_id INTEGER NOT NULL REFERENCES versions(id),
+    region TEXT NOT NULL,                -- e.g., "japan", "north-america"
+    platform TEXT NOT NULL DEFAULT '',   -- e.g., "Game Boy"
+    release_date INTEGER NOT NULL,       -- Unix timestamp
+    PRIMARY KEY (version_id, region, platform)
+);
+
+-- Populated from: IGDB games.cover, games.screenshots, games.artworks
+-- Images downloaded to disk, position keeps IGDB's order within a kind
+CREATE TABLE version_media (
+    version_id INTEGER NOT NULL REFERENCES versions(id),
+    kind TEXT NOT NULL,                  -- "cover", "screenshot", "artwork"
+    position INTEGER NOT NULL,
+    igdb_image_id TEXT NOT NULL,
+    width INTEGER NOT NULL DEFAULT 0,
+    height INTEGER NOT NULL DEFAULT 0,
+    path TEXT NOT NULL,                  -- Local file, e.g. "images/games/red/screenshot_abc.jpg"
+    PRIMARY KEY (version_id, kind, position)
+);
+
+-- Populated from: igdb-match, confirmed rows override the IGDB IDs built into
+-- the igdb package. Curated by hand, so there's no DROP for it above.
+CREATE TABLE IF NOT EXISTS igdb_game_mappings (
+    version_name TEXT PRIMARY KEY,       -- versions.name, e.g. "shining-pearl"
+    igdb_id INTEGER NOT NULL,
+    igdb_name TEXT NOT NULL DEFAULT '',  -- e.g. "Pokémon Shining Pearl"
+    confidence REAL NOT NULL DEFAULT 0,  -- 0-1, how well the search result matched
+    status TEXT NOT NULL,                -- "confirmed" or "pending" review
+    updated_at INTEGER NOT NULL
+);
+
 -- Populated from: GET /pokedex?limit=100
 -- Regional Pokedexes - each contains a list of Pokemon
 CREATE TABLE pokedexes (
@@ -73,7 +143,17 @@ CREATE TABLE species (
     is_legendary BOOLEAN DEFAULT FALSE,
     is_mythical BOOLEAN DEFAULT FALSE,
     growth_rate_name TEXT,               -- e.g., "medium-fast"
-    generation_name TEXT                 -- When this species was introduced
+    generation_name TEXT,                -- When this species was introduced
+    hatch_counter INTEGER,               -- Egg cycles, steps to hatch vary per generation
+    has_gender_differences BOOLEAN DEFAULT FALSE
+);
+
+-- Populated from: pokemon-species.egg_groups array
+-- Two pokemon can breed when they share an egg group, "no-eggs" never breeds
+CREATE TABLE species_egg_groups (
+    species_id INTEGER NOT NULL REFERENCES species(id),
+    egg_group TEXT NOT NULL,             -- e.g., "monster", "field", "ditto", "no-eggs"
+    PRIMARY KEY (species_id, egg_group)
 );
 
 -- Populated from: pokedex.pokemon_entries array
@@ -103,10 +183,50 @@ CREATE TABLE pokemon (
     special_attack INTEGER,
     special_defense INTEGER,
     speed INTEGER,
-    -- Sprite URLs
-    sprite_front_default TEXT,
-    sprite_front_shiny TEXT,
-    sprite_artwork TEXT                  -- official-artwork.front_default
+    -- Local sprite paths
+    sprite_front_default TEXT,           -- sprites.front_default, the latest in-game sprite
+    sprite_front_shiny TEXT,             -- sprites.front_shiny
+    sprite_artwork TEXT,                 -- official-artwork.front_default
+    sprite_artwork_shiny TEXT            -- official-artwork.front_shiny
+);
+
+-- Populated from: GET /pokemon-form/{id} for every entry in pokemon.forms
+-- Forms of a pokemon variety - cosmetic (unown-b) or battle forms (charizard-mega-x)
+-- Every pokemon has at least its default form
+CREATE TABLE pokemon_forms (
+    id INTEGER PRIMARY KEY,
+    pokemon_id INTEGER NOT NULL REFERENCES pokemon(id),
+    name TEXT UNIQUE NOT NULL,           -- e.g., "raichu-alola", "unown-b"
+    form_name TEXT,                      -- e.g., "alola", "b", "" for the default form
+    form_order INTEGER,
+    is_default BOOLEAN NOT NULL,         -- TRUE for the default form of its pokemon
+    is_battle_only BOOLEAN NOT NULL,     -- Megas, Gmax, etc.
+    is_mega BOOLEAN NOT NULL,
+    introduced_version_group_id INTEGER  -- pokemon-form.version_group (no FK, may not be synced yet)
+);
+
+-- Which forms are available in which version group
+-- Alolan forms only from sun-moon onward, Megas from x-y, etc.
+CREATE TABLE pokemon_form_version_groups (
+    pokemon_form_id INTEGER NOT NULL REFERENCES pokemon_forms(id),
+    version_group_id INTEGER NOT NULL REFERENCES version_groups(id),
+    PRIMARY KEY (pokemon_form_id, version_group_id)
+);
+
+-- Populated from: pokemon.sprites.versions
+-- In-game sprites of a pokemon per game. Gold and Silver have their own
+-- sprites, so a version group can have more than one sprite set.
+CREATE TABLE pokemon_sprites (
+    pokemon_id INTEGER NOT NULL REFERENCES pokemon(id),
+    generation INTEGER NOT NULL,         -- e.g., 5
+    version_group TEXT NOT NULL,         -- version_groups.name (no FK, may not be synced yet)
+    sprite_set TEXT NOT NULL,            -- PokeAPI's name, e.g., "gold", "omegaruby-alphasapphire"
+    side TEXT NOT NULL,                  -- "front" or "back"
+    shiny BOOLEAN NOT NULL,
+    female BOOLEAN NOT NULL,
+    animated BOOLEAN NOT NULL,           -- Generation V GIFs
+    path TEXT NOT NULL,                  -- Local asset path
+    PRIMARY KEY (pokemon_id, sprite_set, side, shiny, female, animated)
 );
 
 -- Populated from: pokemon.types array
@@ -117,6 +237,16 @@ CREATE TABLE pokemon_types (
     PRIMARY KEY (pokemon_id, slot)
 );
 
+-- Populated from: pokemon.past_types array
+-- Types a pokemon had up to and including generation_id, e.g. clefairy was normal until Gen 5
+CREATE TABLE pokemon_past_types (
+    pokemon_id INTEGER NOT NULL REFERENCES pokemon(id),
+    generation_id INTEGER NOT NULL,      -- Last generation with these types
+    type_name TEXT NOT NULL,
+    slot INTEGER NOT NULL,
+    PRIMARY KEY (pokemon_id, generation_id, slot)
+);
+
 -- Populated from: pokemon.abilities array
 CREATE TABLE pokemon_abilities (
     pokemon_id INTEGER NOT NULL REFERENCES pokemon(id),
@@ -175,10 +305,89 @@ CREATE TABLE moves (
     accuracy INTEGER,                    -- NULL for moves that can't miss
     pp INTEGER NOT NULL,
     damage_class TEXT NOT NULL,          -- "physical", "special", "status"
-    effect_short TEXT,                   -- Brief effect description
-    priority INTEGER DEFAULT 0           -- Move priority (-7 to +5)
+    effect_short TEXT,                   -- Brief effect description (English, $effect_chance substituted)
+    priority INTEGER DEFAULT 0,          -- Move priority (-7 to +5)
+    effect_chance INTEGER,               -- % chance of the secondary effect, NULL if none
+    effect_full TEXT,                    -- Full effect description (English, $effect_chance substituted)
+    target TEXT,                         -- e.g., "selected-pokemon", "all-opponents"
+    -- Populated from: move.meta (NULL when PokeAPI has no meta for the move)
+    meta_category TEXT,                  -- e.g., "damage+ailment", "net-good-stats"
+    ailment TEXT,                        -- e.g., "paralysis", "none"
+    ailment_chance INTEGER,
+    crit_rate INTEGER,                   -- Crit stage bonus
+    drain INTEGER,                       -- % of damage drained (negative = recoil)
+    healing INTEGER,                     -- % of max HP healed
+    flinch_chance INTEGER,
+    stat_chance INTEGER,
+    min_hits INTEGER,                    -- Multi-hit moves, NULL otherwise
+    max_hits INTEGER,
+    min_turns INTEGER,                   -- Multi-turn moves, NULL otherwise
+    max_turns INTEGER,
+    -- Contest data
+    contest_type TEXT,                   -- e.g., "cool", "beauty"
+    contest_effect_id INTEGER,
+    super_contest_effect_id INTEGER
+);
+
+-- Populated from: move.stat_changes array
+CREATE TABLE move_stat_changes (
+    move_id INTEGER NOT NULL REFERENCES moves(id),
+    stat_name TEXT NOT NULL,             -- e.g., "attack", "special-defense"
+    change INTEGER NOT NULL,             -- Stages, e.g., -1 for Growl, +2 for Swords Dance
+    PRIMARY KEY (move_id, stat_name)
 );
 
+-- Populated from: move.past_values array
+-- Each row holds the values the move had in every version group BEFORE version_group_id.
+-- NULL columns mean that stat did not change at this boundary.
+-- version_group_id has no FK: moves are synced before later version groups exist.
+CREATE TABLE move_past_values (
+    move_id INTEGER NOT NULL REFERENCES moves(id),
+    version_group_id INTEGER NOT NULL,   -- First version group that uses the NEWER values
+    version_group_order INTEGER NOT NULL, -- version-group.order of version_group_id, that version group may not be synced
+    type_name TEXT,                      -- e.g., Bite was "normal" before gold-silver
+    power INTEGER,
+    accuracy INTEGER,
+    pp INTEGER,
+    effect_chance INTEGER,
+    PRIMARY KEY (move_id, version_group_id)
+);
+
+-- Move stats as they were in each version group.
+-- For every stat, the earliest past_values boundary AFTER the version group wins,
+-- otherwise the current value from moves applies.
+CREATE VIEW move_stats_by_version_group AS
+SELECT
+    m.id AS move_id,
+    vg.id AS version_group_id,
+    COALESCE((
+        SELECT pv.type_name FROM move_past_values pv
+        WHERE pv.move_id = m.id AND pv.type_name IS NOT NULL AND pv.version_group_order > vg.sort_order
+        ORDER BY pv.version_group_order LIMIT 1
+    ), m.type_name) AS type_name,
+    COALESCE((
+        SELECT pv.power FROM move_past_values pv
+        WHERE pv.move_id = m.id AND pv.power IS NOT NULL AND pv.version_group_order > vg.sort_order
+        ORDER BY pv.version_group_order LIMIT 1
+    ), m.power) AS power,
+    COALESCE((
+        SELECT pv.accuracy FROM move_past_values pv
+        WHERE pv.move_id = m.id AND pv.accuracy IS NOT NULL AND pv.version_group_order > vg.sort_order
+        ORDER BY pv.version_group_order LIMIT 1
+    ), m.accuracy) AS accuracy,
+    COALESCE((
+        SELECT pv.pp FROM move_past_values pv
+        WHERE pv.move_id = m.id AND pv.pp IS NOT NULL AND pv.version_group_order > vg.sort_order
+        ORDER BY pv.version_group_order LIMIT 1
+    ), m.pp) AS pp,
+    COALESCE((
+        SELECT pv.effect_chance FROM move_past_values pv
+        WHERE pv.move_id = m.id AND pv.effect_chance IS NOT NULL AND pv.version_group_order > vg.sort_order
+        ORDER BY pv.version_group_order LIMIT 1
+    ), m.effect_chance) AS effect_chance
+FROM moves m
+CROSS JOIN version_groups vg;
+
 -- Populated from: pokemon.moves array (filtered by version_group_details)
 -- THIS IS VERSION-SPECIFIC - same Pokemon learns different moves in different games
 CREATE TABLE pokemon_moves (
@@ -190,6 +399,71 @@ CREATE TABLE pokemon_moves (
     PRIMARY KEY (pokemon_id, move_id, version_group_id, learn_method)
 );
 
+-- ============================================================================
+-- ITEM TABLES
+-- Items and the TMs/HMs/TRs that teach moves (version-specific!)
+-- ============================================================================
+
+-- Populated from: GET /item/{id}
+CREATE TABLE items (
+    id INTEGER PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL,           -- e.g., "thunder-stone", "tm24"
+    display_name TEXT,                   -- e.g., "Thunder Stone" (from names[].name where language=en)
+    category TEXT,                       -- e.g., "evolution", "all-machines", "held-items"
+    cost INTEGER,                        -- Buy price in Poke Dollars
+    fling_power INTEGER,
+    effect_short TEXT,
+    effect_full TEXT,
+    sprite TEXT                          -- Local path of sprites.default
+);
+
+-- Populated from: GET /machine/{id} (for moves learned by machine)
+-- Which TM teaches which move differs per version group - TM24 is Thunderbolt in Gen 1-3 only
+CREATE TABLE machines (
+    id INTEGER PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES items(id),
+    move_id INTEGER NOT NULL REFERENCES moves(id),
+    version_group_id INTEGER NOT NULL REFERENCES version_groups(id),
+    UNIQUE(version_group_id, item_id)
+);
+
+-- ============================================================================
+-- ENCOUNTER TABLES
+-- Where to catch each Pokemon (version-specific!)
+-- ============================================================================
+
+-- Populated from: GET /location/{id}
+CREATE TABLE locations (
+    id INTEGER PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL,           -- e.g., "kanto-route-1"
+    region_name TEXT,                    -- e.g., "kanto", NULL for some event locations
+    display_name TEXT                    -- e.g., "Route 1" (from names[].name where language=en)
+);
+
+-- Populated from: GET /location-area/{id}
+-- A location is split into areas, e.g. floors of a cave
+CREATE TABLE location_areas (
+    id INTEGER PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL,           -- e.g., "mt-moon-1f"
+    location_id INTEGER NOT NULL REFERENCES locations(id),
+    display_name TEXT
+);
+
+-- Populated from: GET /pokemon/{id}/encounters (filtered by version)
+-- Each row = one encounter slot
+CREATE TABLE encounters (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    pokemon_id INTEGER NOT NULL REFERENCES pokemon(id),
+    location_area_id INTEGER NOT NULL REFERENCES location_areas(id),
+    version_id INTEGER NOT NULL REFERENCES versions(id),
+    method TEXT NOT NULL,                -- "walk", "surf", "old-rod", "gift", etc.
+    min_level INTEGER NOT NULL,
+    max_level INTEGER NOT NULL,
+    chance INTEGER NOT NULL,             -- % chance of this slot, summed over PokeAPI's identical slots
+    conditions TEXT NOT NULL DEFAULT '', -- Sorted comma-separated condition values, e.g. "season-spring,time-day"
+    UNIQUE(pokemon_id, location_area_id, version_id, method, min_level, max_level, conditions)
+);
+
 -- ============================================================================
 -- SUPPLEMENTARY TABLES
 -- Additional data for display purposes
@@ -202,6 +476,7 @@ CREATE TABLE types (
 );
 
 -- Populated from: type.damage_relations
+-- Only the attacking side (*_damage_to) is stored, missing pairs are neutral
 CREATE TABLE type_effectiveness (
     attacking_type TEXT NOT NULL REFERENCES types(name),
     defending_type TEXT NOT NULL REFERENCES types(name),
@@ -209,6 +484,17 @@ CREATE TABLE type_effectiveness (
     PRIMARY KEY (attacking_type, defending_type)
 );
 
+-- Populated from: type.past_damage_relations
+-- The full set of relations an attacking type had up to and including generation_id,
+-- e.g. ghost and dark were resisted by steel until Gen 5
+CREATE TABLE type_effectiveness_past (
+    attacking_type TEXT NOT NULL REFERENCES types(name),
+    defending_type TEXT NOT NULL,        -- No FK, may not exist anymore
+    generation_id INTEGER NOT NULL,      -- Last generation with this multiplier
+    multiplier REAL NOT NULL,
+    PRIMARY KEY (attacking_type, defending_type, generation_id)
+);
+
 -- Populated from: GET /ability/{name}
 CREATE TABLE abilities (
     name TEXT PRIMARY KEY,
@@ -216,6 +502,27 @@ CREATE TABLE abilities (
     effect_full TEXT                     -- Full description
 );
 
+-- Populated from: GET /nature/{id}
+-- Neutral natures (hardy, docile, ...) have NULL stats
+CREATE TABLE natures (
+    id INTEGER PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL,           -- e.g., "adamant"
+    increased_stat TEXT,                 -- e.g., "attack" (x1.1)
+    decreased_stat TEXT,                 -- e.g., "special-attack" (x0.9)
+    likes_flavor TEXT,
+    hates_flavor TEXT
+);
+
+-- Populated from: GET /characteristic/{id}
+-- Hint about the highest IV: highest_stat's IV mod 5 equals gene_modulo
+CREATE TABLE characteristics (
+    id INTEGER PRIMARY KEY,
+    highest_stat TEXT NOT NULL,          -- e.g., "hp"
+    gene_modulo INTEGER NOT NULL,        -- 0-4
+    possible_values TEXT NOT NULL,       -- Comma-separated IVs, e.g. "0,5,10,15,20,25,30"
+    description TEXT                     -- e.g., "Loves to eat" (English)
+);
+
 -- Populated from: pokemon-species.flavor_text_entries (filtered by version and language)
 CREATE TABLE flavor_texts (
     species_id INTEGER NOT NULL REFERENCES species(id),
@@ -224,10 +531,105 @@ CREATE TABLE flavor_texts (
     PRIMARY KEY (species_id, version_id)
 );
 
+-- ============================================================================
+-- TRAINER TABLES
+-- Scraped from Bulbapedia, PokeAPI has no trainer data
+-- ============================================================================
+
+-- Populated from: Bulbapedia game page (Gyms/Elite Four) and trainer pages
+-- One row per battle in a version, rematches have battle > 1
+CREATE TABLE trainers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,                  -- e.g., "Brock"
+    role TEXT NOT NULL,                  -- "gym-leader", "elite-four"
+    version_id INTEGER NOT NULL REFERENCES versions(id),
+    battle INTEGER NOT NULL DEFAULT 1,   -- 1 = first battle, 2+ = rematches
+    battle_order INTEGER NOT NULL,       -- Order on the game page, e.g. 1 for the first gym
+    location TEXT NOT NULL DEFAULT '',   -- e.g., "Pewter Gym"
+    badge TEXT NOT NULL DEFAULT '',      -- e.g., "Boulder Badge", empty for the Elite Four
+    type_specialty TEXT NOT NULL DEFAULT '', -- e.g., "rock"
+    source_url TEXT NOT NULL,
+    UNIQUE(name, version_id, battle)
+);
+
+-- The team of a trainer battle, slot 1 is sent out first
+CREATE TABLE trainer_pokemon (
+    trainer_id INTEGER NOT NULL REFERENCES trainers(id),
+    slot INTEGER NOT NULL,
+    species_id INTEGER NOT NULL REFERENCES species(id),
+    level INTEGER NOT NULL,
+    held_item TEXT NOT NULL DEFAULT '',  -- Item name, e.g. "sitrus-berry"
+    moves TEXT NOT NULL DEFAULT '',      -- Comma-separated move names, e.g. "tackle,rock-throw"
+    PRIMARY KEY (trainer_id, slot)
+);
+
+-- ============================================================================
+-- ASSETS
+-- Downloaded images, one row per file: the original and its thumbnails
+-- ============================================================================
+
+CREATE TABLE assets (
+    hash TEXT NOT NULL,                  -- SHA-256 of the original file
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    is_original BOOLEAN NOT NULL,        -- 0 for thumbnails
+    format TEXT NOT NULL,                -- "png", "jpeg", "gif", thumbnails "webp" or "jpeg"
+    path TEXT NOT NULL,                  -- e.g. "images/assets/3f/3f9a...e1_256.webp"
+    source_url TEXT NOT NULL,            -- Where the original was downloaded from
+    PRIMARY KEY (hash, width)
+);
+
+-- ============================================================================
+-- SYNC FAILURES
+-- Resources a sync with -continue-on-error carried on without, "sync
+-- retry-failed" tries them again and drops the ones that sync
+-- ============================================================================
+
+CREATE TABLE sync_failures (
+    resource TEXT NOT NULL,                -- e.g. "species", "move" or "trainers"
+    resource_id INTEGER NOT NULL,          -- PokeAPI ID, the version group for trainers
+    version_id INTEGER NOT NULL DEFAULT 0, -- Game a species was synced for, 0 otherwise
+    error TEXT NOT NULL,                   -- Of the last attempt
+    attempts INTEGER NOT NULL DEFAULT 1,
+    failed_at INTEGER NOT NULL,            -- Unix seconds of the last attempt
+    PRIMARY KEY (resource, resource_id, version_id)
+);
+
+-- ============================================================================
+-- SYNC RUNS
+-- One row per run of the sync command, kept across resets like
+-- igdb_game_mappings so there's no DROP for it above
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS sync_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    command TEXT NOT NULL,               -- e.g. "all" or "game red"
+    status TEXT NOT NULL,                -- "running", "succeeded" or "failed"
+    error TEXT NOT NULL DEFAULT '',      -- Why a failed run stopped
+    started_at INTEGER NOT NULL,         -- Unix seconds
+    finished_at INTEGER,                 -- NULL while running or when the process died
+    duration_ms INTEGER NOT NULL DEFAULT 0,
+    fetched INTEGER NOT NULL DEFAULT 0,  -- PokeAPI requests
+    inserted INTEGER NOT NULL DEFAULT 0, -- Resources written
+    skipped INTEGER NOT NULL DEFAULT 0,  -- Resources left out on purpose
+    failed INTEGER NOT NULL DEFAULT 0,   -- Non-fatal failures, e.g. images
+    cache_hits INTEGER NOT NULL DEFAULT 0
+);
+
 CREATE INDEX idx_pokemon_species ON pokemon(species_id);
 CREATE INDEX idx_pokemon_default ON pokemon(is_default);
+CREATE INDEX idx_pokemon_forms_pokemon ON pokemon_forms(pokemon_id);
+CREATE INDEX idx_pokemon_form_version_groups_version ON pokemon_form_version_groups(version_group_id);
+CREATE INDEX idx_pokemon_sprites_version_group ON pokemon_sprites(pokemon_id, version_group);
 CREATE INDEX idx_pokedex_entries_pokedex ON pokedex_entries(pokedex_id);
 CREATE INDEX idx_pokemon_moves_pokemon ON pokemon_moves(pokemon_id);
 CREATE INDEX idx_pokemon_moves_version ON pokemon_moves(version_group_id);
+CREATE INDEX idx_pokemon_moves_move_version ON pokemon_moves(move_id, version_group_id);
+CREATE INDEX idx_species_egg_groups_group ON species_egg_groups(egg_group);
+CREATE INDEX idx_encounters_pokemon_version ON encounters(pokemon_id, version_id);
+CREATE INDEX idx_encounters_area_version ON encounters(location_area_id, version_id);
+CREATE INDEX idx_machines_move_version ON machines(move_id, version_group_id);
 CREATE INDEX idx_evolutions_from ON evolutions(from_species_id);
 CREATE INDEX idx_evolutions_to ON evolutions(to_species_id);
+CREATE INDEX idx_trainers_version ON trainers(version_id);
+CREATE INDEX idx_assets_source_url ON assets(source_url);
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/queries"
+)
+
+type SyncFailureRepository struct {
+	db *Database
+}
+
+func NewSyncFailureRepository(db *Database) *SyncFailureRepository {
+	return &SyncFailureRepository{db: db}
+}
+
+// InsertSyncFailure stores a failure. A resource that failed before keeps one
+// row with the latest error and its attempts counted up.
+func (r *SyncFailureRepository) InsertSyncFailure(f *dto.SyncFailure) error {
+	_, err := r.db.Exec(queries.InsertSyncFailure, f.Resource, f.ResourceID, f.VersionID, f.Error, f.FailedAt.Unix())
+	if err != nil {
+		return fmt.Errorf("sync failure insert failed: %w", err)
+	}
+	return nil
+}
+
+// GetSyncFailures returns every failure, the oldest first
+func (r *SyncFailureRepository) GetSyncFailures() ([]*dto.SyncFailure, error) {
+	rows, err := r.db.Query(queries.GetSyncFailures)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sync failures: %w", err)
+	}
+	defer rows.Close()
+
+	failures := []*dto.SyncFailure{}
+	for rows.Next() {
+		var f dto.SyncFailure
+		var failedAt int64
+		if err := rows.Scan(&f.Resource, &f.ResourceID, &f.VersionID, &f.Error, &f.Attempts, &failedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		f.FailedAt = time.Unix(failedAt, 0)
+		failures = append(failures, &f)
+	}
+
+	return failures, rows.Err()
+}
+
+// DeleteSyncFailure drops the failure of a resource once it synced
+func (r *SyncFailureRepository) DeleteSyncFailure(f *dto.SyncFailure) error {
+	if _, err := r.db.Exec(queries.DeleteSyncFailure, f.Resource, f.ResourceID, f.VersionID); err != nil {
+		return fmt.Errorf("sync failure delete failed: %w", err)
+	}
+	return nil
+}
+
+// DeleteSyncFailures drops every failure, for syncs that start over
+func (r *SyncFailureRepository) DeleteSyncFailures() error {
+	if _, err := r.db.Exec(queries.DeleteSyncFailures); err != nil {
+		return fmt.Errorf("sync failures delete failed: %w", err)
+	}
+	return nil
+}
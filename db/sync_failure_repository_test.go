@@ -0,0 +1,48 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncFailures(t *testing.T) {
+	db := setupTest(t)
+	repo := NewSyncFailureRepository(db)
+
+	failures, err := repo.GetSyncFailures()
+	require.NoError(t, err)
+	assert.Empty(t, failures)
+
+	start := time.Unix(1700000000, 0)
+	species := &dto.SyncFailure{Resource: dto.ResourceSpecies, ResourceID: 25, VersionID: 1, Error: "404 Not Found", FailedAt: start}
+	move := &dto.SyncFailure{Resource: dto.ResourceMove, ResourceID: 33, Error: "timeout", FailedAt: start.Add(time.Minute)}
+	require.NoError(t, repo.InsertSyncFailure(species))
+	require.NoError(t, repo.InsertSyncFailure(move))
+	// The same species in another game is another failure
+	require.NoError(t, repo.InsertSyncFailure(&dto.SyncFailure{Resource: dto.ResourceSpecies, ResourceID: 25, VersionID: 2, Error: "404 Not Found", FailedAt: start}))
+
+	// Failing again counts the attempt and keeps the latest error
+	require.NoError(t, repo.InsertSyncFailure(&dto.SyncFailure{Resource: dto.ResourceSpecies, ResourceID: 25, VersionID: 1, Error: "connection reset", FailedAt: start.Add(time.Hour)}))
+
+	failures, err = repo.GetSyncFailures()
+	require.NoError(t, err)
+	assert.Equal(t, []*dto.SyncFailure{
+		{Resource: dto.ResourceSpecies, ResourceID: 25, VersionID: 2, Error: "404 Not Found", Attempts: 1, FailedAt: start},
+		{Resource: dto.ResourceMove, ResourceID: 33, Error: "timeout", Attempts: 1, FailedAt: start.Add(time.Minute)},
+		{Resource: dto.ResourceSpecies, ResourceID: 25, VersionID: 1, Error: "connection reset", Attempts: 2, FailedAt: start.Add(time.Hour)},
+	}, failures)
+
+	require.NoError(t, repo.DeleteSyncFailure(move))
+	failures, err = repo.GetSyncFailures()
+	require.NoError(t, err)
+	assert.Len(t, failures, 2)
+
+	require.NoError(t, repo.DeleteSyncFailures())
+	failures, err = repo.GetSyncFailures()
+	require.NoError(t, err)
+	assert.Empty(t, failures)
+}
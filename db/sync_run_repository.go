@@ -0,0 +1,95 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/queries"
+)
+
+type SyncRunRepository struct {
+	db *Database
+}
+
+func NewSyncRunRepository(db *Database) *SyncRunRepository {
+	return &SyncRunRepository{db: db}
+}
+
+// StartSyncRun stores a running sync and sets its ID, status and start time.
+// The row is there from the start so a run that crashes still shows up.
+func (r *SyncRunRepository) StartSyncRun(run *dto.SyncRun, now time.Time) error {
+	result, err := r.db.Exec(queries.InsertSyncRun, run.Command, dto.SyncRunning, now.Unix())
+	if err != nil {
+		return fmt.Errorf("sync run insert failed: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get sync run id: %w", err)
+	}
+	run.ID = int(id)
+	run.Status = dto.SyncRunning
+	run.StartedAt = time.Unix(now.Unix(), 0)
+
+	return nil
+}
+
+// FinishSyncRun stores the outcome and counts of a started run
+func (r *SyncRunRepository) FinishSyncRun(run *dto.SyncRun) error {
+	_, err := r.db.Exec(
+		queries.FinishSyncRun,
+		run.Status,
+		run.Error,
+		unixOrNil(run.FinishedAt),
+		run.Duration.Milliseconds(),
+		run.Fetched,
+		run.Inserted,
+		run.Skipped,
+		run.Failed,
+		run.CacheHits,
+		run.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("sync run update failed: %w", err)
+	}
+	return nil
+}
+
+// GetLatestSyncRun returns the most recently started run
+func (r *SyncRunRepository) GetLatestSyncRun() (*dto.SyncRun, error) {
+	var run dto.SyncRun
+	var startedAt, durationMs int64
+	var finishedAt sql.NullInt64
+
+	err := r.db.QueryRow(queries.GetLatestSyncRun).Scan(
+		&run.ID,
+		&run.Command,
+		&run.Status,
+		&run.Error,
+		&startedAt,
+		&finishedAt,
+		&durationMs,
+		&run.Fetched,
+		&run.Inserted,
+		&run.Skipped,
+		&run.Failed,
+		&run.CacheHits,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("sync run %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+	run.StartedAt = time.Unix(startedAt, 0)
+	if finishedAt.Valid {
+		t := time.Unix(finishedAt.Int64, 0)
+		run.FinishedAt = &t
+	}
+	run.Duration = time.Duration(durationMs) * time.Millisecond
+
+	return &run, nil
+}
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncRuns(t *testing.T) {
+	db := setupTest(t)
+	repo := NewSyncRunRepository(db)
+
+	_, err := repo.GetLatestSyncRun()
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	start := time.Unix(1700000000, 0)
+	first := &dto.SyncRun{Command: "all"}
+	require.NoError(t, repo.StartSyncRun(first, start))
+
+	got, err := repo.GetLatestSyncRun()
+	require.NoError(t, err)
+	assert.Equal(t, &dto.SyncRun{ID: first.ID, Command: "all", Status: dto.SyncRunning, StartedAt: start}, got)
+
+	run := &dto.SyncRun{Command: "game red"}
+	require.NoError(t, repo.StartSyncRun(run, start.Add(time.Hour)))
+	finished := start.Add(time.Hour + time.Minute)
+	run.Status = dto.SyncFailed
+	run.Error = "failed to fetch versions: timeout"
+	run.FinishedAt = &finished
+	run.Duration = 61500 * time.Millisecond
+	run.Fetched = 120
+	run.Inserted = 80
+	run.Skipped = 3
+	run.Failed = 2
+	run.CacheHits = 40
+	require.NoError(t, repo.FinishSyncRun(run))
+
+	got, err = repo.GetLatestSyncRun()
+	require.NoError(t, err)
+	assert.Equal(t, run, got)
+}
@@ -0,0 +1,180 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/queries"
+)
+
+type TrainerRepository struct {
+	db *Database
+}
+
+func NewTrainerRepository(db *Database) *TrainerRepository {
+	return &TrainerRepository{db: db}
+}
+
+// InsertTrainer replaces a trainer battle with t and its team. Team members
+// are matched to species by SpeciesName, an unknown species fails the whole
+// insert so a team is never stored half.
+func (r *TrainerRepository) InsertTrainer(t *dto.Trainer) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(queries.DeleteTrainerPokemon, t.Name, t.VersionID, t.Battle); err != nil {
+		return fmt.Errorf("trainer pokemon delete failed: %w", err)
+	}
+	if _, err := tx.Exec(queries.DeleteTrainer, t.Name, t.VersionID, t.Battle); err != nil {
+		return fmt.Errorf("trainer delete failed: %w", err)
+	}
+
+	result, err := tx.Exec(
+		queries.InsertTrainer,
+		t.Name,
+		t.Role,
+		t.VersionID,
+		t.Battle,
+		t.Order,
+		t.Location,
+		t.Badge,
+		t.TypeSpecialty,
+		t.SourceURL,
+	)
+	if err != nil {
+		return fmt.Errorf("trainer insert failed: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get trainer id: %w", err)
+	}
+
+	for _, p := range t.Team {
+		result, err := tx.Exec(
+			queries.InsertTrainerPokemon,
+			id,
+			p.Slot,
+			p.Level,
+			p.HeldItem,
+			strings.Join(p.Moves, ","),
+			p.SpeciesName,
+		)
+		if err != nil {
+			return fmt.Errorf("trainer pokemon insert failed: %w", err)
+		}
+		if n, err := result.RowsAffected(); err != nil || n == 0 {
+			return fmt.Errorf("species %s of %s %w", p.SpeciesName, t.Name, ErrNotFound)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	t.ID = int(id)
+
+	return nil
+}
+
+// GetTrainers returns the trainer battles of a version with their teams,
+// gym leaders in gym order first, then the Elite Four
+func (r *TrainerRepository) GetTrainers(versionID int) ([]*dto.Trainer, error) {
+	rows, err := r.db.Query(queries.GetTrainers, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	trainers := []*dto.Trainer{}
+
+	for rows.Next() {
+		t, err := scanTrainer(rows)
+		if err != nil {
+			return nil, err
+		}
+		trainers = append(trainers, t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	rows.Close()
+
+	for _, t := range trainers {
+		if t.Team, err = r.getTeam(t.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return trainers, nil
+}
+
+// GetTrainerByID returns a trainer battle with its team
+func (r *TrainerRepository) GetTrainerByID(id int) (*dto.Trainer, error) {
+	t, err := scanTrainer(r.db.QueryRow(queries.GetTrainerByID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("trainer %d %w", id, ErrNotFound)
+		}
+		return nil, err
+	}
+
+	if t.Team, err = r.getTeam(t.ID); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *TrainerRepository) getTeam(trainerID int) ([]dto.TrainerPokemon, error) {
+	rows, err := r.db.Query(queries.GetTrainerPokemon, trainerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team: %w", err)
+	}
+	defer rows.Close()
+
+	team := []dto.TrainerPokemon{}
+	for rows.Next() {
+		var p dto.TrainerPokemon
+		var moves string
+		if err := rows.Scan(&p.Slot, &p.SpeciesID, &p.SpeciesName, &p.Level, &p.HeldItem, &moves); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		p.Moves = []string{}
+		if moves != "" {
+			p.Moves = strings.Split(moves, ",")
+		}
+		team = append(team, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return team, nil
+}
+
+func scanTrainer(row rowScanner) (*dto.Trainer, error) {
+	var t dto.Trainer
+	err := row.Scan(
+		&t.ID,
+		&t.Name,
+		&t.Role,
+		&t.VersionID,
+		&t.Battle,
+		&t.Order,
+		&t.Location,
+		&t.Badge,
+		&t.TypeSpecialty,
+		&t.SourceURL,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+	return &t, nil
+}
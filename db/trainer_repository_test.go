@@ -0,0 +1,109 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrainers(t *testing.T) {
+	db := setupTest(t)
+	repo := NewTrainerRepository(db)
+
+	_, err := db.Exec(`INSERT INTO version_groups (id, name, generation_name) VALUES (1, 'red-blue', 'generation-i')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO versions (id, name, version_group_id) VALUES (1, 'red', 1), (2, 'blue', 1)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO species (id, name) VALUES (74, 'geodude'), (95, 'onix'), (120, 'staryu'), (121, 'starmie')`)
+	require.NoError(t, err)
+
+	brock := &dto.Trainer{
+		Name: "Brock", Role: dto.TrainerGymLeader, VersionID: 1, Battle: 1, Order: 1,
+		Location: "Pewter Gym", Badge: "Boulder Badge", TypeSpecialty: "rock",
+		SourceURL: "https://bulbapedia.bulbagarden.net/wiki/Brock",
+		Team: []dto.TrainerPokemon{
+			{Slot: 1, SpeciesName: "geodude", Level: 12, Moves: []string{"tackle", "defense-curl"}},
+			{Slot: 2, SpeciesName: "onix", Level: 14, Moves: []string{"tackle", "screech", "bide"}},
+		},
+	}
+	misty := &dto.Trainer{
+		Name: "Misty", Role: dto.TrainerGymLeader, VersionID: 1, Battle: 1, Order: 2,
+		Location: "Cerulean Gym", Badge: "Cascade Badge", TypeSpecialty: "water",
+		Team: []dto.TrainerPokemon{
+			{Slot: 1, SpeciesName: "staryu", Level: 18, Moves: []string{}},
+			{Slot: 2, SpeciesName: "starmie", Level: 21, HeldItem: "sitrus-berry", Moves: []string{"bubblebeam"}},
+		},
+	}
+	require.NoError(t, repo.InsertTrainer(misty))
+	require.NoError(t, repo.InsertTrainer(brock))
+	assert.NotZero(t, brock.ID)
+
+	trainers, err := repo.GetTrainers(1)
+	require.NoError(t, err)
+	require.Len(t, trainers, 2)
+	assert.Equal(t, "Brock", trainers[0].Name)
+	assert.Equal(t, "Pewter Gym", trainers[0].Location)
+	require.Len(t, trainers[0].Team, 2)
+	assert.Equal(t, dto.TrainerPokemon{Slot: 2, SpeciesID: 95, SpeciesName: "onix", Level: 14, Moves: []string{"tackle", "screech", "bide"}}, trainers[0].Team[1])
+	assert.Equal(t, "sitrus-berry", trainers[1].Team[1].HeldItem)
+	assert.Empty(t, trainers[1].Team[0].Moves)
+
+	// Syncing again replaces the battle instead of adding a second one
+	brock.Team = brock.Team[1:]
+	brock.Team[0].Slot = 1
+	require.NoError(t, repo.InsertTrainer(brock))
+	trainers, err = repo.GetTrainers(1)
+	require.NoError(t, err)
+	require.Len(t, trainers, 2)
+	assert.Len(t, trainers[0].Team, 1)
+
+	loaded, err := repo.GetTrainerByID(brock.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Boulder Badge", loaded.Badge)
+	assert.Len(t, loaded.Team, 1)
+	_, err = repo.GetTrainerByID(999)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	blue, err := repo.GetTrainers(2)
+	require.NoError(t, err)
+	assert.Empty(t, blue)
+}
+
+func TestInsertTrainerUnknownSpecies(t *testing.T) {
+	db := setupTest(t)
+	repo := NewTrainerRepository(db)
+
+	_, err := db.Exec(`INSERT INTO version_groups (id, name, generation_name) VALUES (1, 'red-blue', 'generation-i')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO versions (id, name, version_group_id) VALUES (1, 'red', 1)`)
+	require.NoError(t, err)
+
+	err = repo.InsertTrainer(&dto.Trainer{
+		Name: "Brock", Role: dto.TrainerGymLeader, VersionID: 1, Battle: 1,
+		Team: []dto.TrainerPokemon{{Slot: 1, SpeciesName: "missingno", Level: 12}},
+	})
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// The trainer row is rolled back with its team
+	trainers, err := repo.GetTrainers(1)
+	require.NoError(t, err)
+	assert.Empty(t, trainers)
+}
+
+func TestGetVersionGroupVersions(t *testing.T) {
+	db := setupTest(t)
+	repo := NewVersionRepository(db)
+
+	_, err := db.Exec(`INSERT INTO version_groups (id, name, generation_name) VALUES (1, 'red-blue', 'generation-i'), (2, 'yellow', 'generation-i')`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO versions (id, name, version_group_id) VALUES (1, 'red', 1), (2, 'blue', 1), (3, 'yellow', 2)`)
+	require.NoError(t, err)
+
+	versions, err := repo.GetVersionGroupVersions("red-blue")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "red", versions[0].Name)
+	assert.Equal(t, "blue", versions[1].Name)
+}
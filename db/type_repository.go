@@ -0,0 +1,133 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/queries"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
+)
+
+type TypeRepository struct {
+	db *Database
+}
+
+func NewTypeRepository(db *Database) *TypeRepository {
+	return &TypeRepository{db: db}
+}
+
+// InsertType stores a type with its current and past offensive matchups.
+// Defending types are inserted by name so the matchups can reference them
+// before they are synced themselves.
+func (r *TypeRepository) InsertType(t *external.Type) error {
+	if _, err := r.db.Exec(queries.InsertTypeDamageClass, t.Name, optionalName(t.MoveDamageClass)); err != nil {
+		return fmt.Errorf("type insert failed: %w", err)
+	}
+
+	for defendingType, multiplier := range offensiveMultipliers(&t.DamageRelations) {
+		if _, err := r.db.Exec(queries.InsertTypeName, defendingType); err != nil {
+			return fmt.Errorf("type insert failed: %w", err)
+		}
+		if _, err := r.db.Exec(queries.InsertTypeEffectiveness, t.Name, defendingType, multiplier); err != nil {
+			return fmt.Errorf("type effectiveness insert failed: %w", err)
+		}
+	}
+
+	for _, past := range t.PastDamageRelations {
+		generationID, err := utils.ExtractIDFromURL(past.Generation.Url)
+		if err != nil {
+			return err
+		}
+		for defendingType, multiplier := range offensiveMultipliers(&past.DamageRelations) {
+			if _, err := r.db.Exec(queries.InsertTypeEffectivenessPast, t.Name, defendingType, generationID, multiplier); err != nil {
+				return fmt.Errorf("past type effectiveness insert failed: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetTypeChart returns the type chart as it was in the given generation
+func (r *TypeRepository) GetTypeChart(generation int) (*dto.TypeChart, error) {
+	chart := &dto.TypeChart{
+		Multipliers:   make(map[string]map[string]float64),
+		DamageClasses: make(map[string]string),
+	}
+
+	rows, err := r.db.Query(queries.GetTypeDamageClasses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query types: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, damageClass string
+		if err := rows.Scan(&name, &damageClass); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		chart.DamageClasses[name] = damageClass
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	past, err := r.getMultipliers(queries.GetTypeEffectivenessPast, generation)
+	if err != nil {
+		return nil, err
+	}
+	current, err := r.getMultipliers(queries.GetTypeEffectiveness)
+	if err != nil {
+		return nil, err
+	}
+
+	// A past chart replaces all current matchups of its attacking type
+	for attackingType, multipliers := range current {
+		chart.Multipliers[attackingType] = multipliers
+	}
+	for attackingType, multipliers := range past {
+		chart.Multipliers[attackingType] = multipliers
+	}
+
+	return chart, nil
+}
+
+func (r *TypeRepository) getMultipliers(query string, args ...any) (map[string]map[string]float64, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query type effectiveness: %w", err)
+	}
+	defer rows.Close()
+
+	multipliers := make(map[string]map[string]float64)
+	for rows.Next() {
+		var attackingType, defendingType string
+		var multiplier float64
+		if err := rows.Scan(&attackingType, &defendingType, &multiplier); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if multipliers[attackingType] == nil {
+			multipliers[attackingType] = make(map[string]float64)
+		}
+		multipliers[attackingType][defendingType] = multiplier
+	}
+
+	return multipliers, rows.Err()
+}
+
+// offensiveMultipliers flattens the *_damage_to relations, the *_damage_from
+// side is stored by the other types
+func offensiveMultipliers(relations *external.TypeDamageRelations) map[string]float64 {
+	multipliers := make(map[string]float64)
+	for _, t := range relations.NoDamageTo {
+		multipliers[t.Name] = 0
+	}
+	for _, t := range relations.HalfDamageTo {
+		multipliers[t.Name] = 0.5
+	}
+	for _, t := range relations.DoubleDamageTo {
+		multipliers[t.Name] = 2
+	}
+	return multipliers
+}
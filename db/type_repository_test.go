@@ -0,0 +1,74 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTypeChart(t *testing.T) {
+	db := setupTest(t)
+	repo := NewTypeRepository(db)
+
+	ghost := &external.Type{
+		ID:              8,
+		Name:            "ghost",
+		MoveDamageClass: &external.Response{Name: "physical"},
+		DamageRelations: external.TypeDamageRelations{
+			NoDamageTo:     []external.Response{{Name: "normal"}},
+			HalfDamageTo:   []external.Response{{Name: "dark"}},
+			DoubleDamageTo: []external.Response{{Name: "ghost"}, {Name: "psychic"}},
+			// The defending side is stored by the attacking types
+			NoDamageFrom: []external.Response{{Name: "normal"}, {Name: "fighting"}},
+		},
+		PastDamageRelations: []external.PastDamageRelation{
+			{
+				Generation: external.Response{Name: "generation-v", Url: "https://pokeapi.co/api/v2/generation/5/"},
+				DamageRelations: external.TypeDamageRelations{
+					NoDamageTo:     []external.Response{{Name: "normal"}},
+					HalfDamageTo:   []external.Response{{Name: "dark"}, {Name: "steel"}},
+					DoubleDamageTo: []external.Response{{Name: "ghost"}, {Name: "psychic"}},
+				},
+			},
+		},
+	}
+	fairy := &external.Type{
+		ID:   18,
+		Name: "fairy",
+		DamageRelations: external.TypeDamageRelations{
+			DoubleDamageTo: []external.Response{{Name: "dragon"}},
+		},
+	}
+	require.NoError(t, repo.InsertType(ghost))
+	require.NoError(t, repo.InsertType(fairy))
+
+	t.Run("Current chart", func(t *testing.T) {
+		chart, err := repo.GetTypeChart(9)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]float64{"normal": 0, "dark": 0.5, "ghost": 2, "psychic": 2}, chart.Multipliers["ghost"])
+		assert.Equal(t, map[string]float64{"dragon": 2}, chart.Multipliers["fairy"])
+		assert.Equal(t, "physical", chart.DamageClasses["ghost"])
+		assert.Equal(t, "", chart.DamageClasses["fairy"])
+		// Defending types are inserted by name only
+		assert.Contains(t, chart.DamageClasses, "psychic")
+	})
+
+	t.Run("Past chart", func(t *testing.T) {
+		chart, err := repo.GetTypeChart(4)
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, chart.Multipliers["ghost"]["steel"])
+		assert.Equal(t, map[string]float64{"dragon": 2}, chart.Multipliers["fairy"])
+	})
+
+	t.Run("Syncing a defending type keeps its damage class", func(t *testing.T) {
+		psychic := &external.Type{ID: 14, Name: "psychic", MoveDamageClass: &external.Response{Name: "special"}}
+		require.NoError(t, repo.InsertType(psychic))
+		require.NoError(t, repo.InsertType(ghost))
+
+		chart, err := repo.GetTypeChart(9)
+		require.NoError(t, err)
+		assert.Equal(t, "special", chart.DamageClasses["psychic"])
+	})
+}
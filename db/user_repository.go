@@ -0,0 +1,102 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/queries"
+)
+
+// UserRepository works on the user database, see NewUserDatabase
+type UserRepository struct {
+	db *Database
+}
+
+func NewUserRepository(db *Database) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (r *UserRepository) InsertUser(username, passwordHash string, createdAt time.Time) (*dto.User, error) {
+	result, err := r.db.Exec(queries.InsertUser, username, passwordHash, createdAt.Unix())
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("user %s %w", username, ErrConflict)
+		}
+		return nil, fmt.Errorf("user insert failed: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user id: %w", err)
+	}
+
+	return &dto.User{
+		ID:           int(id),
+		Username:     username,
+		PasswordHash: passwordHash,
+		CreatedAt:    time.Unix(createdAt.Unix(), 0),
+	}, nil
+}
+
+func (r *UserRepository) GetUserByUsername(username string) (*dto.User, error) {
+	user, err := scanUser(r.db.QueryRow(queries.GetUserByUsername, username))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("user %s %w", username, ErrNotFound)
+	}
+	return user, err
+}
+
+func (r *UserRepository) InsertSession(tokenHash string, userID int, createdAt, expiresAt time.Time) error {
+	_, err := r.db.Exec(queries.InsertSession, tokenHash, userID, createdAt.Unix(), expiresAt.Unix())
+	if err != nil {
+		return fmt.Errorf("session insert failed: %w", err)
+	}
+	return nil
+}
+
+// GetSessionUser returns the owner of a session that has not expired at now
+func (r *UserRepository) GetSessionUser(tokenHash string, now time.Time) (*dto.User, error) {
+	user, err := scanUser(r.db.QueryRow(queries.GetSessionUser, tokenHash, now.Unix()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("session %w", ErrNotFound)
+	}
+	return user, err
+}
+
+func (r *UserRepository) DeleteSession(tokenHash string) error {
+	if _, err := r.db.Exec(queries.DeleteSession, tokenHash); err != nil {
+		return fmt.Errorf("session delete failed: %w", err)
+	}
+	return nil
+}
+
+func (r *UserRepository) DeleteExpiredSessions(now time.Time) error {
+	if _, err := r.db.Exec(queries.DeleteExpiredSessions, now.Unix()); err != nil {
+		return fmt.Errorf("session delete failed: %w", err)
+	}
+	return nil
+}
+
+func scanUser(row *sql.Row) (*dto.User, error) {
+	var user dto.User
+	var createdAt int64
+
+	err := row.Scan(&user.ID, &user.Username, &user.PasswordHash, &createdAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+	user.CreatedAt = time.Unix(createdAt, 0)
+
+	return &user, nil
+}
+
+func isUniqueViolation(err error) bool {
+	return strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
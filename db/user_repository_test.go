@@ -0,0 +1,67 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertUser(t *testing.T) {
+	db := setupUserTest(t)
+	repo := NewUserRepository(db)
+	now := time.Unix(1700000000, 0)
+
+	user, err := repo.InsertUser("ash", "hash", now)
+	require.NoError(t, err)
+	assert.Equal(t, "ash", user.Username)
+
+	actual, err := repo.GetUserByUsername("ash")
+	require.NoError(t, err)
+	assert.Equal(t, user, actual)
+
+	_, err = repo.InsertUser("ash", "other", now)
+	assert.ErrorIs(t, err, ErrConflict)
+
+	_, err = repo.GetUserByUsername("gary")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSessions(t *testing.T) {
+	db := setupUserTest(t)
+	repo := NewUserRepository(db)
+	now := time.Unix(1700000000, 0)
+
+	user, err := repo.InsertUser("misty", "hash", now)
+	require.NoError(t, err)
+	require.NoError(t, repo.InsertSession("token-hash", user.ID, now, now.Add(time.Hour)))
+
+	actual, err := repo.GetSessionUser("token-hash", now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, actual.ID)
+
+	t.Run("Expired", func(t *testing.T) {
+		_, err := repo.GetSessionUser("token-hash", now.Add(2*time.Hour))
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Deleted", func(t *testing.T) {
+		require.NoError(t, repo.DeleteSession("token-hash"))
+		_, err := repo.GetSessionUser("token-hash", now)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestUserSchemaIsIdempotent(t *testing.T) {
+	db := setupUserTest(t)
+	_, err := NewUserRepository(db).InsertUser("brock", "hash", time.Now())
+	require.NoError(t, err)
+
+	// Re-applying the schema must keep existing users
+	_, err = db.Exec(userSchemaSQL)
+	require.NoError(t, err)
+
+	_, err = NewUserRepository(db).GetUserByUsername("brock")
+	assert.NoError(t, err)
+}
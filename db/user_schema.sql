@@ -0,0 +1,104 @@
+-- ============================================================================
+-- USER DATA TABLES
+-- Lives in its own database file next to the reference data, so deleting or
+-- re-syncing pokemon.db never touches it. Every statement must be idempotent:
+-- this file runs on every start.
+-- IDs of reference data (versions, species, pokemon) have no FK for that reason.
+-- Timestamps are unix seconds.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username TEXT UNIQUE NOT NULL,
+    password_hash TEXT NOT NULL,         -- "pbkdf2-sha256$<iterations>$<salt>$<key>"
+    created_at INTEGER NOT NULL
+);
+
+-- Bearer tokens, only the SHA-256 of a token is stored
+CREATE TABLE IF NOT EXISTS sessions (
+    token_hash TEXT PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    created_at INTEGER NOT NULL,
+    expires_at INTEGER NOT NULL
+);
+
+-- A single run through one game
+CREATE TABLE IF NOT EXISTS playthroughs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    version_id INTEGER NOT NULL,         -- versions.id in the reference database
+    name TEXT NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+-- Seen/caught state per species, species without a row are unseen
+CREATE TABLE IF NOT EXISTS playthrough_species (
+    playthrough_id INTEGER NOT NULL REFERENCES playthroughs(id),
+    species_id INTEGER NOT NULL,         -- species.id in the reference database
+    status TEXT NOT NULL CHECK (status IN ('seen', 'caught')),
+    PRIMARY KEY (playthrough_id, species_id)
+);
+
+-- The current party, up to 6 slots
+CREATE TABLE IF NOT EXISTS playthrough_party (
+    playthrough_id INTEGER NOT NULL REFERENCES playthroughs(id),
+    slot INTEGER NOT NULL CHECK (slot BETWEEN 1 AND 6),
+    pokemon_id INTEGER NOT NULL,         -- pokemon.id in the reference database
+    nickname TEXT NOT NULL DEFAULT '',
+    level INTEGER NOT NULL CHECK (level BETWEEN 1 AND 100),
+    PRIMARY KEY (playthrough_id, slot)
+);
+
+-- Living dex: species a user has caught for an entry of a pokedex,
+-- including the virtual Colosseum/XD pokedexes
+CREATE TABLE IF NOT EXISTS living_dex_entries (
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    pokedex_id INTEGER NOT NULL,         -- pokedexes.id in the reference database
+    species_id INTEGER NOT NULL,         -- species.id in the reference database
+    caught_at INTEGER NOT NULL,
+    PRIMARY KEY (user_id, pokedex_id, species_id)
+);
+
+-- A Nuzlocke challenge run through one game with its rule set.
+-- dupes_clause: species whose evolution family was already caught don't count
+-- shiny_clause: shinies can always be caught, on top of the first encounter
+-- gift_clause: gifts are free, otherwise a gift is the location's encounter
+CREATE TABLE IF NOT EXISTS nuzlocke_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    version_id INTEGER NOT NULL,         -- versions.id in the reference database
+    name TEXT NOT NULL,
+    dupes_clause BOOLEAN NOT NULL,
+    shiny_clause BOOLEAN NOT NULL,
+    gift_clause BOOLEAN NOT NULL,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+-- Encounters of a run. Location and species names are copied from the
+-- reference data so a run can be shown without it.
+-- status: alive (in the party), boxed, dead or missed (fled or not caught)
+CREATE TABLE IF NOT EXISTS nuzlocke_encounters (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    run_id INTEGER NOT NULL REFERENCES nuzlocke_runs(id),
+    location_id INTEGER NOT NULL,        -- locations.id in the reference database
+    location_name TEXT NOT NULL,
+    species_id INTEGER NOT NULL,         -- species.id in the reference database
+    species_name TEXT NOT NULL,
+    evolution_chain_id INTEGER NOT NULL, -- 0 when unknown, used by the dupes clause
+    nickname TEXT NOT NULL DEFAULT '',
+    level INTEGER NOT NULL CHECK (level BETWEEN 1 AND 100),
+    status TEXT NOT NULL CHECK (status IN ('alive', 'boxed', 'dead', 'missed')),
+    cause_of_death TEXT NOT NULL DEFAULT '',
+    is_shiny BOOLEAN NOT NULL DEFAULT FALSE,
+    is_gift BOOLEAN NOT NULL DEFAULT FALSE,
+    encountered_at INTEGER NOT NULL,
+    died_at INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user ON sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_playthroughs_user ON playthroughs(user_id);
+CREATE INDEX IF NOT EXISTS idx_nuzlocke_runs_user ON nuzlocke_runs(user_id);
+CREATE INDEX IF NOT EXISTS idx_nuzlocke_encounters_run ON nuzlocke_encounters(run_id);
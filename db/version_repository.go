@@ -1,7 +1,9 @@
 package db
 
 import (
+	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
@@ -48,6 +50,7 @@ func (r *VersionRepository) InsertVersionGroup(v *external.VersionGroup) error {
 		v.ID,
 		v.Name,
 		v.Generation.Name,
+		v.Order,
 	)
 	if err != nil {
 		return fmt.Errorf("version group insert failed: %w", err)
@@ -83,8 +86,235 @@ func (r *VersionRepository) GetVersionByID(id int) (*dto.Version, error) {
 	}
 
 	if version == nil {
-		return nil, fmt.Errorf("version %d not found", id)
+		return nil, fmt.Errorf("version %d %w", id, ErrNotFound)
 	}
 
 	return version, nil
 }
+
+func (r *VersionRepository) GetVersionGroupByID(id int) (*dto.VersionGroup, error) {
+	var versionGroup dto.VersionGroup
+
+	err := r.db.QueryRow(queries.GetVersionGroupByID, id).Scan(
+		&versionGroup.ID,
+		&versionGroup.Name,
+		&versionGroup.GenerationName,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("version group %d %w", id, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	return &versionGroup, nil
+}
+
+// GetVersionGroupVersions returns the versions of a version group by its name, e.g. "red-blue"
+func (r *VersionRepository) GetVersionGroupVersions(versionGroupName string) ([]*dto.Version, error) {
+	rows, err := r.db.Query(queries.GetVersionGroupVersions, versionGroupName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []*dto.Version{}
+
+	for rows.Next() {
+		v := &dto.Version{}
+		if err := rows.Scan(&v.ID, &v.Name, &v.DisplayName, &v.VersionGroupID); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		versions = append(versions, v)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return versions, nil
+}
+
+// InsertVersionMetadata replaces the IGDB metadata, release dates and media of
+// a version with m
+func (r *VersionRepository) InsertVersionMetadata(m *dto.VersionMetadata) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, query := range []string{queries.DeleteVersionMedia, queries.DeleteVersionReleaseDates, queries.DeleteVersionMetadata} {
+		if _, err := tx.Exec(query, m.VersionID); err != nil {
+			return fmt.Errorf("version metadata delete failed: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(
+		queries.InsertVersionMetadata,
+		m.VersionID,
+		m.IGDBID,
+		m.Summary,
+		m.Storyline,
+		strings.Join(m.Platforms, ","),
+		strings.Join(m.Developers, ","),
+		strings.Join(m.Publishers, ","),
+	)
+	if err != nil {
+		return fmt.Errorf("version metadata insert failed: %w", err)
+	}
+
+	for _, rd := range m.ReleaseDates {
+		if _, err := tx.Exec(queries.InsertVersionReleaseDate, m.VersionID, rd.Region, rd.Platform, rd.Date); err != nil {
+			return fmt.Errorf("version release date insert failed: %w", err)
+		}
+	}
+
+	// Position counts within a kind
+	positions := make(map[string]int)
+	for _, media := range m.Media {
+		positions[media.Kind]++
+		_, err := tx.Exec(
+			queries.InsertVersionMedia,
+			m.VersionID,
+			media.Kind,
+			positions[media.Kind],
+			media.ImageID,
+			media.Width,
+			media.Height,
+			media.Path,
+		)
+		if err != nil {
+			return fmt.Errorf("version media insert failed: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetVersionMetadata returns the IGDB metadata of a version, covers first,
+// then artworks and screenshots
+func (r *VersionRepository) GetVersionMetadata(versionID int) (*dto.VersionMetadata, error) {
+	m := &dto.VersionMetadata{ReleaseDates: []dto.ReleaseDate{}, Media: []dto.VersionMedia{}}
+	var platforms, developers, publishers string
+
+	err := r.db.QueryRow(queries.GetVersionMetadata, versionID).Scan(
+		&m.VersionID,
+		&m.IGDBID,
+		&m.Summary,
+		&m.Storyline,
+		&platforms,
+		&developers,
+		&publishers,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("metadata of version %d %w", versionID, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+	m.Platforms = splitList(platforms)
+	m.Developers = splitList(developers)
+	m.Publishers = splitList(publishers)
+
+	rows, err := r.db.Query(queries.GetVersionReleaseDates, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rd dto.ReleaseDate
+		if err := rows.Scan(&rd.Region, &rd.Platform, &rd.Date); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		m.ReleaseDates = append(m.ReleaseDates, rd)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	mediaRows, err := r.db.Query(queries.GetVersionMedia, versionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer mediaRows.Close()
+
+	for mediaRows.Next() {
+		var media dto.VersionMedia
+		if err := mediaRows.Scan(&media.Kind, &media.ImageID, &media.Width, &media.Height, &media.Path); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		m.Media = append(m.Media, media)
+	}
+	if err = mediaRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return m, nil
+}
+
+// GetVersions returns all versions ordered by ID
+func (r *VersionRepository) GetVersions() ([]*dto.Version, error) {
+	rows, err := r.db.Query(queries.GetVersions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []*dto.Version{}
+
+	for rows.Next() {
+		v := &dto.Version{}
+		if err := rows.Scan(&v.ID, &v.Name, &v.DisplayName, &v.VersionGroupID); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		versions = append(versions, v)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return versions, nil
+}
+
+// InsertGameMapping stores the IGDB game of a version, replacing an earlier one
+func (r *VersionRepository) InsertGameMapping(m *dto.GameMapping) error {
+	_, err := r.db.Exec(queries.InsertGameMapping, m.VersionName, m.IGDBID, m.IGDBName, m.Confidence, m.Status, m.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("game mapping insert failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetGameMappings returns all IGDB game mappings, confirmed ones first
+func (r *VersionRepository) GetGameMappings() ([]*dto.GameMapping, error) {
+	rows, err := r.db.Query(queries.GetGameMappings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	mappings := []*dto.GameMapping{}
+
+	for rows.Next() {
+		m := &dto.GameMapping{}
+		if err := rows.Scan(&m.VersionName, &m.IGDBID, &m.IGDBName, &m.Confidence, &m.Status, &m.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		mappings = append(mappings, m)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return mappings, nil
+}
+
+// splitList splits a comma-separated column, an empty column is an empty list
+func splitList(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, ",")
+}
@@ -50,3 +50,91 @@ func TestInsertVersion(t *testing.T) {
 	}
 	assert.Equal(t, expected, got)
 }
+
+func TestVersionMetadata(t *testing.T) {
+	db := setupTest(t)
+	_, err := db.Exec(`INSERT INTO version_groups (id, name, generation_name) VALUES (1, 'red-blue', 'generation-i');
+		INSERT INTO versions (id, name, version_group_id) VALUES (1, 'red', 1)`)
+	require.NoError(t, err)
+
+	repo := NewVersionRepository(db)
+
+	_, err = repo.GetVersionMetadata(1)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	m := &dto.VersionMetadata{
+		VersionID:  1,
+		IGDBID:     1561,
+		Summary:    "Catch them all.",
+		Platforms:  []string{"Game Boy", "Super Game Boy"},
+		Developers: []string{"Game Freak"},
+		Publishers: []string{},
+		ReleaseDates: []dto.ReleaseDate{
+			{Region: "north-america", Platform: "Game Boy", Date: 906076800},
+			{Region: "japan", Platform: "Game Boy", Date: 825811200},
+		},
+		Media: []dto.VersionMedia{
+			{Kind: dto.MediaScreenshot, ImageID: "sc1", Width: 889, Height: 500, Path: "images/games/red/screenshot_sc1.jpg"},
+			{Kind: dto.MediaScreenshot, ImageID: "sc2", Path: "images/games/red/screenshot_sc2.jpg"},
+			{Kind: dto.MediaCover, ImageID: "co1", Path: "images/covers/red.jpg"},
+		},
+	}
+	require.NoError(t, repo.InsertVersionMetadata(m))
+
+	got, err := repo.GetVersionMetadata(1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Game Boy", "Super Game Boy"}, got.Platforms)
+	assert.Equal(t, []string{}, got.Publishers)
+	// Release dates oldest first, covers before screenshots
+	assert.Equal(t, "japan", got.ReleaseDates[0].Region)
+	assert.Equal(t, []string{"co1", "sc1", "sc2"}, []string{got.Media[0].ImageID, got.Media[1].ImageID, got.Media[2].ImageID})
+
+	// A re-sync replaces everything
+	m.Summary = "Gotta catch 'em all!"
+	m.ReleaseDates = m.ReleaseDates[:1]
+	m.Media = m.Media[2:]
+	require.NoError(t, repo.InsertVersionMetadata(m))
+
+	got, err = repo.GetVersionMetadata(1)
+	require.NoError(t, err)
+	assert.Equal(t, "Gotta catch 'em all!", got.Summary)
+	assert.Len(t, got.ReleaseDates, 1)
+	assert.Equal(t, []dto.VersionMedia{m.Media[0]}, got.Media)
+}
+
+func TestGameMappings(t *testing.T) {
+	db := setupTest(t)
+	repo := NewVersionRepository(db)
+
+	got, err := repo.GetGameMappings()
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	pending := &dto.GameMapping{VersionName: "shining-pearl", IGDBID: 1518, IGDBName: "Pokémon Pearl", Confidence: 0.3, Status: dto.GameMappingPending, UpdatedAt: 100}
+	require.NoError(t, repo.InsertGameMapping(pending))
+	require.NoError(t, repo.InsertGameMapping(&dto.GameMapping{VersionName: "new-game", IGDBID: 500, Status: dto.GameMappingPending, UpdatedAt: 100}))
+
+	// Confirming replaces the pending match
+	confirmed := &dto.GameMapping{VersionName: "shining-pearl", IGDBID: 144050, IGDBName: "Pokémon Shining Pearl", Confidence: 1, Status: dto.GameMappingConfirmed, UpdatedAt: 200}
+	require.NoError(t, repo.InsertGameMapping(confirmed))
+
+	got, err = repo.GetGameMappings()
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, confirmed, got[0])
+	assert.Equal(t, "new-game", got[1].VersionName)
+}
+
+func TestGetVersions(t *testing.T) {
+	db := setupTest(t)
+	_, err := db.Exec(`INSERT INTO version_groups (id, name, generation_name) VALUES (1, 'red-blue', 'generation-i');
+		INSERT INTO versions (id, name, display_name, version_group_id) VALUES (2, 'blue', 'Blue', 1), (1, 'red', 'Red', 1)`)
+	require.NoError(t, err)
+
+	got, err := NewVersionRepository(db).GetVersions()
+	require.NoError(t, err)
+	assert.Equal(t, []*dto.Version{
+		{ID: 1, Name: "red", DisplayName: "Red", VersionGroupID: 1},
+		{ID: 2, Name: "blue", DisplayName: "Blue", VersionGroupID: 1},
+	}, got)
+}
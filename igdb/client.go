@@ -1,13 +1,19 @@
 package igdb
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/metrics"
 )
 
 const (
@@ -20,7 +26,8 @@ const (
 	Size1080p          = "1080p"           // 1920×1080
 )
 
-// Map of Pokemon version names to their IGDB game IDs
+// Map of Pokemon version names to their IGDB game IDs. Versions missing here
+// are matched by search, see KnownGameIDs.
 var pokemonGameIDs = map[string]int{
 	"red":               1561,
 	"blue":              1511,
@@ -64,7 +71,6 @@ var pokemonGameIDs = map[string]int{
 	"green-japan":       275105,
 	"the-indigo-disk":   239933,
 	"the-teal-mask":     239930,
-	"shining-pearl":     1518,
 	"legends-arceus":    144054,
 	"scarlet":           191931,
 	"violet":            191930,
@@ -72,43 +78,115 @@ var pokemonGameIDs = map[string]int{
 	"mega-dimension":    366894,
 }
 
-type Cover struct {
+// Image is a cover, screenshot or artwork, see GetImageURL for its URL
+type Image struct {
 	ID      int    `json:"id"`
 	ImageID string `json:"image_id"`
 	URL     string `json:"url"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+}
+
+type Cover = Image
+
+type Platform struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Abbreviation string `json:"abbreviation"`
+}
+
+// ReleaseDate is the release of a game on one platform in one region, see
+// RegionName for the regions
+type ReleaseDate struct {
+	Date     int64    `json:"date"` // Unix timestamp
+	Region   int      `json:"region"`
+	Platform Platform `json:"platform"`
+}
+
+type Company struct {
+	Name string `json:"name"`
+}
+
+type InvolvedCompany struct {
+	Company   Company `json:"company"`
+	Developer bool    `json:"developer"`
+	Publisher bool    `json:"publisher"`
 }
 
 type Game struct {
-	ID               int    `json:"id"`
-	Name             string `json:"name"`
-	Cover            Cover  `json:"cover"`
-	FirstReleaseDate int64  `json:"first_release_date"` // Unix timestamp
+	ID                int               `json:"id"`
+	Name              string            `json:"name"`
+	Cover             Cover             `json:"cover"`
+	FirstReleaseDate  int64             `json:"first_release_date"` // Unix timestamp
+	Summary           string            `json:"summary"`
+	Storyline         string            `json:"storyline"`
+	Platforms         []Platform        `json:"platforms"`
+	ReleaseDates      []ReleaseDate     `json:"release_dates"`
+	InvolvedCompanies []InvolvedCompany `json:"involved_companies"`
+	Screenshots       []Image           `json:"screenshots"`
+	Artworks          []Image           `json:"artworks"`
 }
 
+const (
+	DefaultTokenURL = "https://id.twitch.tv/oauth2/token"
+	DefaultAPIURL   = "https://api.igdb.com/v4"
+
+	// IGDB allows 4 requests per second
+	requestInterval = 250 * time.Millisecond
+	// Tokens are refreshed a bit before they expire so requests in flight stay valid
+	tokenExpiryMargin = time.Minute
+)
+
+// IGDBClient talks to the IGDB API with a Twitch app access token. URLs can be
+// pointed at a test server. When TokenCachePath is set the token is saved
+// there and reused by later processes until it expires.
 type IGDBClient struct {
-	ClientID     string
-	ClientSecret string
-	AccessToken  string
-	TokenExpiry  time.Time
-	HTTPClient   *http.Client
+	ClientID       string
+	ClientSecret   string
+	TokenURL       string
+	APIURL         string
+	TokenCachePath string
+	AccessToken    string
+	TokenExpiry    time.Time
+	HTTPClient     *http.Client
+
+	mu      sync.Mutex // Guards AccessToken and TokenExpiry
+	limiter *limiter
 }
 
 func NewIGDBClient(clientID, clientSecret string) *IGDBClient {
 	return &IGDBClient{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
-		HTTPClient:   &http.Client{Timeout: 10 * time.Second},
+		TokenURL:     DefaultTokenURL,
+		APIURL:       DefaultAPIURL,
+		HTTPClient:   &http.Client{Timeout: 10 * time.Second, Transport: metrics.Transport("igdb", nil)},
+		limiter:      newLimiter(requestInterval),
 	}
 }
 
-// Ensure we have a valid access token
-func (c *IGDBClient) ensureToken() error {
+// cachedToken is the on-disk format of TokenCachePath
+type cachedToken struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// token returns a valid access token, from memory, the token cache or a new
+// one from the token endpoint in that order
+func (c *IGDBClient) token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.AccessToken != "" && time.Now().Before(c.TokenExpiry) {
-		return nil
+		return c.AccessToken, nil
+	}
+	if cached, ok := c.loadToken(); ok {
+		c.AccessToken, c.TokenExpiry = cached.AccessToken, cached.ExpiresAt
+		return c.AccessToken, nil
 	}
 
 	if c.ClientID == "" || c.ClientSecret == "" {
-		return fmt.Errorf("IGDB_CLIENT_ID and IGDB_CLIENT_SECRET must be set")
+		return "", fmt.Errorf("IGDB_CLIENT_ID and IGDB_CLIENT_SECRET must be set")
 	}
 
 	data := url.Values{}
@@ -116,16 +194,25 @@ func (c *IGDBClient) ensureToken() error {
 	data.Set("client_secret", c.ClientSecret)
 	data.Set("grant_type", "client_credentials")
 
-	resp, err := http.PostForm("https://id.twitch.tv/oauth2/token", data)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.TokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to request token: %w", err)
+		return "", fmt.Errorf("failed to request token: %w", err)
 	}
 	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token response: %w", err)
+	}
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("OAuth failed (status %d): %s", resp.StatusCode, string(body))
+		return "", fmt.Errorf("OAuth failed (status %d): %s", resp.StatusCode, string(body))
 	}
 
 	var tokenResp struct {
@@ -134,64 +221,85 @@ func (c *IGDBClient) ensureToken() error {
 	}
 
 	if err := json.Unmarshal(body, &tokenResp); err != nil {
-		return fmt.Errorf("failed to parse token response: %w", err)
+		return "", fmt.Errorf("failed to parse token response: %w", err)
 	}
 
 	if tokenResp.AccessToken == "" {
-		return fmt.Errorf("received empty access token")
+		return "", fmt.Errorf("received empty access token")
 	}
 
 	c.AccessToken = tokenResp.AccessToken
-	c.TokenExpiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	c.TokenExpiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn)*time.Second - tokenExpiryMargin)
+	if err := c.saveToken(); err != nil {
+		// The token still works for this process
+		logger().Warn("failed to cache IGDB token", "err", err)
+	}
 
-	return nil
+	return c.AccessToken, nil
 }
 
-// Get Pokemon game cover by version name using known game IDs
-func (c *IGDBClient) GetPokemonGameCover(versionName string) (*Game, error) {
-	gameID, exists := pokemonGameIDs[versionName]
-	if !exists {
-		// Version not in our map, return nil (no cover)
-		return nil, nil
-	}
+// invalidateToken drops a token the API rejected, in memory and on disk
+func (c *IGDBClient) invalidateToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	return c.GetGameByID(gameID)
+	if c.AccessToken != token {
+		return
+	}
+	c.AccessToken, c.TokenExpiry = "", time.Time{}
+	if c.TokenCachePath != "" {
+		os.Remove(c.TokenCachePath)
+	}
 }
 
-// Get a specific game by ID
-func (c *IGDBClient) GetGameByID(gameID int) (*Game, error) {
-	if err := c.ensureToken(); err != nil {
-		return nil, err
+func (c *IGDBClient) loadToken() (*cachedToken, bool) {
+	if c.TokenCachePath == "" {
+		return nil, false
 	}
-
-	query := fmt.Sprintf(`fields name, first_release_date, cover.image_id; where id = %d;`, gameID)
-
-	req, err := http.NewRequest("POST", "https://api.igdb.com/v4/games", bytes.NewBufferString(query))
+	data, err := os.ReadFile(c.TokenCachePath)
 	if err != nil {
-		return nil, err
+		return nil, false
 	}
-
-	req.Header.Set("Client-ID", c.ClientID)
-	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
-
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
+	var cached cachedToken
+	if err := json.Unmarshal(data, &cached); err != nil || cached.AccessToken == "" || !time.Now().Before(cached.ExpiresAt) {
+		return nil, false
 	}
-	defer resp.Body.Close()
+	return &cached, true
+}
 
-	body, err := io.ReadAll(resp.Body)
+func (c *IGDBClient) saveToken() error {
+	if c.TokenCachePath == "" {
+		return nil
+	}
+	data, err := json.Marshal(cachedToken{AccessToken: c.AccessToken, ExpiresAt: c.TokenExpiry})
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.TokenCachePath), 0o700); err != nil {
+		return err
 	}
+	// The token is a credential, keep it private to the user
+	return os.WriteFile(c.TokenCachePath, data, 0o600)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("IGDB API error (status %d): %s", resp.StatusCode, string(body))
+// Get Pokemon game cover by version name using known game IDs
+func (c *IGDBClient) GetPokemonGameCover(ctx context.Context, versionName string) (*Game, error) {
+	gameID, exists := pokemonGameIDs[versionName]
+	if !exists {
+		// Version not in our map, return nil (no cover)
+		return nil, nil
 	}
 
+	return c.GetGameByID(ctx, gameID)
+}
+
+// Get a specific game by ID
+func (c *IGDBClient) GetGameByID(ctx context.Context, gameID int) (*Game, error) {
+	query := fmt.Sprintf(`fields name, first_release_date, cover.image_id; where id = %d;`, gameID)
+
 	var games []Game
-	if err := json.Unmarshal(body, &games); err != nil {
-		return nil, fmt.Errorf("unmarshal error: %w", err)
+	if err := c.query(ctx, "games", query, &games); err != nil {
+		return nil, err
 	}
 
 	if len(games) == 0 {
@@ -201,49 +309,74 @@ func (c *IGDBClient) GetGameByID(gameID int) (*Game, error) {
 	return &games[0], nil
 }
 
-// Search for a game (generic search - use GetPokemonGameCover for Pokemon games)
-func (c *IGDBClient) SearchGame(gameName string) ([]Game, error) {
-	if err := c.ensureToken(); err != nil {
-		return nil, err
-	}
-
-	query := fmt.Sprintf(`search "%s"; fields name, cover.image_id; limit 5;`, gameName)
-
-	req, err := http.NewRequest("POST", "https://api.igdb.com/v4/games", bytes.NewBufferString(query))
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Client-ID", c.ClientID)
-	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
-
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+// Search for a game (generic search - use GetPokemonGameCover for Pokemon games).
+// Results carry the platforms and release date to tell similar names apart.
+func (c *IGDBClient) SearchGame(ctx context.Context, gameName string) ([]Game, error) {
+	query := fmt.Sprintf(`search "%s"; fields name, first_release_date, platforms.name, cover.image_id; limit 10;`, strings.ReplaceAll(gameName, `"`, `\"`))
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
+	var games []Game
+	if err := c.query(ctx, "games", query, &games); err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("IGDB API error (status %d): %s", resp.StatusCode, string(body))
-	}
+	return games, nil
+}
 
-	var games []Game
-	if err := json.Unmarshal(body, &games); err != nil {
-		return nil, fmt.Errorf("unmarshal error: %w", err)
+// query posts an Apicalypse query to an endpoint like "games" and decodes the
+// JSON response into out. A rejected token is replaced once.
+func (c *IGDBClient) query(ctx context.Context, endpoint, query string, out any) error {
+	for attempt := 1; ; attempt++ {
+		token, err := c.token(ctx)
+		if err != nil {
+			return err
+		}
+		if err := c.limiter.wait(ctx); err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.APIURL+"/"+endpoint, strings.NewReader(query))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Client-ID", c.ClientID)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 1 {
+			logger().Info("IGDB rejected the access token, retrying with a new one", "endpoint", endpoint)
+			metrics.Retried("igdb")
+			c.invalidateToken(token)
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("IGDB API error (status %d): %s", resp.StatusCode, string(body))
+		}
+
+		if err := json.Unmarshal(body, out); err != nil {
+			return fmt.Errorf("unmarshal error: %w", err)
+		}
+		return nil
 	}
-
-	return games, nil
 }
 
 // Get cover URL with specified size
 func GetCoverURL(imageID, size string) string {
 	if size == "" {
-		size = "cover_big"
+		size = SizeCoverBig
 	}
+	return GetImageURL(imageID, size)
+}
+
+// GetImageURL returns the URL of any IGDB image in one of the Size* sizes
+func GetImageURL(imageID, size string) string {
 	return fmt.Sprintf("https://images.igdb.com/igdb/image/upload/t_%s/%s.jpg", size, imageID)
 }
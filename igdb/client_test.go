@@ -0,0 +1,239 @@
+package igdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIGDB serves a token endpoint and a games endpoint. Every token it hands
+// out is "token-<n>", rejected lists tokens the games endpoint answers 401 to.
+type fakeIGDB struct {
+	tokenRequests atomic.Int32
+	gameRequests  atomic.Int32
+	rejected      map[string]bool
+	lastQuery     atomic.Value
+}
+
+func (f *fakeIGDB) start(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		n := f.tokenRequests.Add(1)
+		assert.Equal(t, "client_credentials", r.FormValue("grant_type"))
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": fmt.Sprintf("token-%d", n),
+			"expires_in":   3600,
+		})
+	})
+	mux.HandleFunc("POST /v4/games", func(w http.ResponseWriter, r *http.Request) {
+		f.gameRequests.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		f.lastQuery.Store(string(body))
+
+		token := r.Header.Get("Authorization")
+		if f.rejected[token] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "id", r.Header.Get("Client-ID"))
+		w.Write([]byte(`[{"id":1561,"name":"Pokémon Red","first_release_date":825811200,"cover":{"image_id":"co1abc"}}]`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestClient(server *httptest.Server) *IGDBClient {
+	c := NewIGDBClient("id", "secret")
+	c.TokenURL = server.URL + "/oauth2/token"
+	c.APIURL = server.URL + "/v4"
+	c.limiter = newLimiter(0)
+	return c
+}
+
+func TestGetGameByID(t *testing.T) {
+	fake := &fakeIGDB{}
+	c := newTestClient(fake.start(t))
+
+	game, err := c.GetGameByID(context.Background(), 1561)
+	require.NoError(t, err)
+	assert.Equal(t, "Pokémon Red", game.Name)
+	assert.Equal(t, "co1abc", game.Cover.ImageID)
+	assert.Contains(t, fake.lastQuery.Load(), "where id = 1561;")
+
+	// The token is reused for later requests
+	_, err = c.GetGameByID(context.Background(), 1561)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, fake.tokenRequests.Load())
+	assert.EqualValues(t, 2, fake.gameRequests.Load())
+}
+
+func TestGetPokemonGameCoverUnknownVersion(t *testing.T) {
+	fake := &fakeIGDB{}
+	c := newTestClient(fake.start(t))
+
+	game, err := c.GetPokemonGameCover(context.Background(), "not-a-version")
+	require.NoError(t, err)
+	assert.Nil(t, game)
+	assert.EqualValues(t, 0, fake.tokenRequests.Load())
+}
+
+func TestSearchGameEscapesQuotes(t *testing.T) {
+	fake := &fakeIGDB{}
+	c := newTestClient(fake.start(t))
+
+	_, err := c.SearchGame(context.Background(), `Pokémon "Red"`)
+	require.NoError(t, err)
+	assert.Contains(t, fake.lastQuery.Load(), `search "Pokémon \"Red\"";`)
+}
+
+func TestTokenCache(t *testing.T) {
+	fake := &fakeIGDB{}
+	server := fake.start(t)
+	path := filepath.Join(t.TempDir(), "igdb", "token.json")
+
+	first := newTestClient(server)
+	first.TokenCachePath = path
+	_, err := first.GetGameByID(context.Background(), 1561)
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	var cached cachedToken
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &cached))
+	assert.Equal(t, "token-1", cached.AccessToken)
+	assert.WithinDuration(t, time.Now().Add(time.Hour-tokenExpiryMargin), cached.ExpiresAt, 5*time.Second)
+
+	// A new process picks the token up without asking for another one
+	second := newTestClient(server)
+	second.TokenCachePath = path
+	_, err = second.GetGameByID(context.Background(), 1561)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, fake.tokenRequests.Load())
+}
+
+func TestExpiredTokenCacheIsIgnored(t *testing.T) {
+	fake := &fakeIGDB{}
+	path := filepath.Join(t.TempDir(), "token.json")
+	data, _ := json.Marshal(cachedToken{AccessToken: "old", ExpiresAt: time.Now().Add(-time.Minute)})
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	c := newTestClient(fake.start(t))
+	c.TokenCachePath = path
+	_, err := c.GetGameByID(context.Background(), 1561)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, fake.tokenRequests.Load())
+	assert.Equal(t, "token-1", c.AccessToken)
+}
+
+func TestRejectedTokenIsReplacedOnce(t *testing.T) {
+	fake := &fakeIGDB{rejected: map[string]bool{"Bearer revoked": true}}
+	path := filepath.Join(t.TempDir(), "token.json")
+	data, _ := json.Marshal(cachedToken{AccessToken: "revoked", ExpiresAt: time.Now().Add(time.Hour)})
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	c := newTestClient(fake.start(t))
+	c.TokenCachePath = path
+	_, err := c.GetGameByID(context.Background(), 1561)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, fake.tokenRequests.Load())
+	assert.EqualValues(t, 2, fake.gameRequests.Load())
+
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "token-1")
+
+	// A token that keeps failing is an error, not a loop
+	fake.rejected["Bearer token-1"] = true
+	fake.rejected["Bearer token-2"] = true
+	_, err = c.GetGameByID(context.Background(), 1561)
+	assert.ErrorContains(t, err, "status 401")
+	assert.EqualValues(t, 4, fake.gameRequests.Load())
+}
+
+func TestMissingCredentials(t *testing.T) {
+	fake := &fakeIGDB{}
+	c := newTestClient(fake.start(t))
+	c.ClientSecret = ""
+
+	_, err := c.GetGameByID(context.Background(), 1561)
+	assert.ErrorContains(t, err, "IGDB_CLIENT_SECRET")
+	assert.EqualValues(t, 0, fake.tokenRequests.Load())
+}
+
+func TestLimiterSpacesRequests(t *testing.T) {
+	l := newLimiter(20 * time.Millisecond)
+	start := time.Now()
+	for range 4 {
+		require.NoError(t, l.wait(context.Background()))
+	}
+	// The first request goes out right away, the other three wait a slot each
+	assert.GreaterOrEqual(t, time.Since(start), 60*time.Millisecond)
+}
+
+func TestLimiterStopsOnCancel(t *testing.T) {
+	l := newLimiter(time.Hour)
+	require.NoError(t, l.wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.wait(ctx), context.DeadlineExceeded)
+}
+
+func TestRequestStopsOnCancel(t *testing.T) {
+	fake := &fakeIGDB{}
+	c := newTestClient(fake.start(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.GetGameByID(ctx, 1561)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.EqualValues(t, 0, fake.gameRequests.Load())
+}
+
+func TestGetPokemonGames(t *testing.T) {
+	fake := &fakeIGDB{}
+	c := newTestClient(fake.start(t))
+
+	games, err := c.GetPokemonGames(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, fake.gameRequests.Load())
+
+	query := fake.lastQuery.Load().(string)
+	assert.Contains(t, query, "screenshots.image_id")
+	assert.Contains(t, query, "involved_companies.developer")
+	assert.Contains(t, query, "where id = (")
+	assert.Contains(t, query, "1561,")
+
+	// The fake only knows Red
+	require.Len(t, games, 1)
+	assert.Equal(t, "co1abc", games["red"].Cover.ImageID)
+}
+
+func TestGameCompanies(t *testing.T) {
+	game := &Game{InvolvedCompanies: []InvolvedCompany{
+		{Company: Company{Name: "Game Freak"}, Developer: true},
+		{Company: Company{Name: "Nintendo"}, Publisher: true},
+		{Company: Company{Name: "Creatures"}, Developer: true, Publisher: true},
+	}}
+	assert.Equal(t, []string{"Game Freak", "Creatures"}, game.Developers())
+	assert.Equal(t, []string{"Nintendo", "Creatures"}, game.Publishers())
+	assert.Equal(t, "japan", RegionName(5))
+	assert.Equal(t, "unknown", RegionName(99))
+}
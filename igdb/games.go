@@ -0,0 +1,126 @@
+package igdb
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// gameFields are the fields GetPokemonGames asks for, expanded in one query
+var gameFields = []string{
+	"name",
+	"summary",
+	"storyline",
+	"first_release_date",
+	"cover.image_id", "cover.width", "cover.height",
+	"platforms.name", "platforms.abbreviation",
+	"release_dates.date", "release_dates.region", "release_dates.platform.name",
+	"involved_companies.company.name", "involved_companies.developer", "involved_companies.publisher",
+	"screenshots.image_id", "screenshots.width", "screenshots.height",
+	"artworks.image_id", "artworks.width", "artworks.height",
+}
+
+// IGDB returns at most 500 results per query
+const maxQueryLimit = 500
+
+// KnownGameIDs returns a copy of the built-in IGDB game IDs by version name
+func KnownGameIDs() map[string]int {
+	return maps.Clone(pokemonGameIDs)
+}
+
+// GetPokemonGames returns the games of all built-in Pokemon versions, see GetGames
+func (c *IGDBClient) GetPokemonGames(ctx context.Context) (map[string]*Game, error) {
+	return c.GetGames(ctx, pokemonGameIDs)
+}
+
+// GetGames returns games with their metadata and media keyed by version name,
+// gameIDs maps version names to IGDB game IDs. It's a single query, so call it
+// once per sync rather than once per version.
+func (c *IGDBClient) GetGames(ctx context.Context, gameIDs map[string]int) (map[string]*Game, error) {
+	var ids []int
+	for _, id := range gameIDs {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return map[string]*Game{}, nil
+	}
+	slices.Sort(ids)
+	if len(ids) > maxQueryLimit {
+		return nil, fmt.Errorf("too many games for one query: %d", len(ids))
+	}
+
+	idList := make([]string, len(ids))
+	for i, id := range ids {
+		idList[i] = fmt.Sprint(id)
+	}
+	query := fmt.Sprintf(`fields %s; where id = (%s); limit %d;`,
+		strings.Join(gameFields, ", "), strings.Join(idList, ","), maxQueryLimit)
+
+	var games []Game
+	if err := c.query(ctx, "games", query, &games); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]*Game, len(games))
+	for i := range games {
+		byID[games[i].ID] = &games[i]
+	}
+
+	// Several versions can map to the same game ID
+	byVersion := make(map[string]*Game, len(gameIDs))
+	for version, id := range gameIDs {
+		if game, ok := byID[id]; ok {
+			byVersion[version] = game
+		}
+	}
+
+	return byVersion, nil
+}
+
+// Developers returns the names of the companies that developed the game
+func (g *Game) Developers() []string {
+	var names []string
+	for _, ic := range g.InvolvedCompanies {
+		if ic.Developer {
+			names = append(names, ic.Company.Name)
+		}
+	}
+	return names
+}
+
+// Publishers returns the names of the companies that published the game
+func (g *Game) Publishers() []string {
+	var names []string
+	for _, ic := range g.InvolvedCompanies {
+		if ic.Publisher {
+			names = append(names, ic.Company.Name)
+		}
+	}
+	return names
+}
+
+// regionNames are IGDB's release date regions
+var regionNames = map[int]string{
+	1:  "europe",
+	2:  "north-america",
+	3:  "australia",
+	4:  "new-zealand",
+	5:  "japan",
+	6:  "china",
+	7:  "asia",
+	8:  "worldwide",
+	9:  "korea",
+	10: "brazil",
+}
+
+// RegionName returns the name of an IGDB release region, e.g. "japan" for 5
+func RegionName(region int) string {
+	if name, ok := regionNames[region]; ok {
+		return name
+	}
+	return "unknown"
+}
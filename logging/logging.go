@@ -0,0 +1,33 @@
+// Package logging sets up the slog default logger of the commands. Packages
+// log through slog.Default with a component attribute, e.g. component=db.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// Setup makes slog and the log package write records at or above level to w
+// as text or JSON lines. The log package logs at info.
+func Setup(w io.Writer, level slog.Level, format string) error {
+	opts := &slog.HandlerOptions{Level: level}
+	switch format {
+	case "text":
+		slog.SetDefault(slog.New(slog.NewTextHandler(w, opts)))
+	case "json":
+		slog.SetDefault(slog.New(slog.NewJSONHandler(w, opts)))
+	default:
+		return fmt.Errorf("invalid log format %q, want text or json", format)
+	}
+	return nil
+}
+
+// ParseLevel parses a level name like "debug", "info", "warn" or "error"
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("invalid log level %q, want debug, info, warn or error", s)
+	}
+	return level, nil
+}
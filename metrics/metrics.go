@@ -0,0 +1,169 @@
+// Package metrics holds the Prometheus metrics of the sync and the API server.
+// Everything is registered on Registry, which the server exposes on /metrics
+// and a sync can push to a Pushgateway when it finishes.
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+const namespace = "pokemon"
+
+// Registry holds every metric of this package plus the Go runtime and
+// process collectors
+var Registry = prometheus.NewRegistry()
+
+var (
+	upstreamRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upstream_requests_total",
+		Help:      "Requests to upstream APIs by upstream and status code, status is \"error\" when no response arrived.",
+	}, []string{"upstream", "status"})
+
+	upstreamDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upstream_request_duration_seconds",
+		Help:      "Latency of requests to upstream APIs.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"upstream"})
+
+	upstreamRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "upstream_retries_total",
+		Help:      "Requests to upstream APIs that were retried.",
+	}, []string{"upstream"})
+
+	dbWriteDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_write_duration_seconds",
+		Help:      "Latency of database writes by table.",
+		Buckets:   []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1},
+	}, []string{"table"})
+
+	dbRowsWritten = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_rows_written_total",
+		Help:      "Rows inserted, updated or deleted by table.",
+	}, []string{"table"})
+
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "API requests by route pattern and status code.",
+	}, []string{"route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of API requests by route pattern.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		upstreamRequests,
+		upstreamDuration,
+		upstreamRetries,
+		dbWriteDuration,
+		dbRowsWritten,
+		httpRequests,
+		httpDuration,
+	)
+}
+
+// Handler serves the metrics of Registry in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// Push sends the metrics of Registry to the Pushgateway at url, replacing
+// the metrics previously pushed under job
+func Push(url, job string) error {
+	if err := push.New(url, job).Gatherer(Registry).Push(); err != nil {
+		return fmt.Errorf("failed to push metrics to %s: %w", url, err)
+	}
+	return nil
+}
+
+// Transport counts the requests sent through base and observes their latency
+// under upstream. A nil base is http.DefaultTransport.
+func Transport(upstream string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{upstream: upstream, base: base}
+}
+
+type transport struct {
+	upstream string
+	base     http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	upstreamDuration.WithLabelValues(t.upstream).Observe(time.Since(start).Seconds())
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	upstreamRequests.WithLabelValues(t.upstream, status).Inc()
+
+	return resp, err
+}
+
+// Retried counts a request to upstream that is sent again
+func Retried(upstream string) {
+	upstreamRetries.WithLabelValues(upstream).Inc()
+}
+
+// ObserveWrite records a write to table that took d and changed rows rows
+func ObserveWrite(table string, d time.Duration, rows int64) {
+	dbWriteDuration.WithLabelValues(table).Observe(d.Seconds())
+	if rows > 0 {
+		dbRowsWritten.WithLabelValues(table).Add(float64(rows))
+	}
+}
+
+// ObserveRequest records an API request matched by route that was answered
+// with status after d
+func ObserveRequest(route string, status int, d time.Duration) {
+	httpRequests.WithLabelValues(route, strconv.Itoa(status)).Inc()
+	httpDuration.WithLabelValues(route).Observe(d.Seconds())
+}
+
+// writeStatement matches the table of an INSERT, REPLACE, UPDATE or DELETE,
+// after leading comments
+var writeStatement = regexp.MustCompile(`(?is)^\s*(?:--[^\n]*\n\s*)*(?:INSERT(?:\s+OR\s+\w+)?\s+INTO|REPLACE\s+INTO|UPDATE(?:\s+OR\s+\w+)?|DELETE\s+FROM)\s+["` + "`" + `\[]?(\w+)`)
+
+// tables caches WriteTable by query, the same few queries run for every row
+var tables sync.Map
+
+// WriteTable returns the table a write statement changes, "" for queries that
+// aren't a single write like SELECTs or the schema
+func WriteTable(query string) string {
+	if table, ok := tables.Load(query); ok {
+		return table.(string)
+	}
+	table := ""
+	if m := writeStatement.FindStringSubmatch(query); m != nil {
+		table = strings.ToLower(m[1])
+	}
+	tables.Store(query, table)
+	return table
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Transport("test-transport", nil)}
+	for _, path := range []string{"/", "/", "/missing"} {
+		resp, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	server.Close()
+	_, err := client.Get(server.URL)
+	require.Error(t, err)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(upstreamRequests.WithLabelValues("test-transport", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(upstreamRequests.WithLabelValues("test-transport", "404")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(upstreamRequests.WithLabelValues("test-transport", "error")))
+	assert.Equal(t, uint64(4), sampleCount(t, upstreamDuration.WithLabelValues("test-transport")))
+}
+
+func TestObserveWrite(t *testing.T) {
+	ObserveWrite("test_table", time.Millisecond, 3)
+	ObserveWrite("test_table", time.Millisecond, 0)
+
+	assert.Equal(t, 3.0, testutil.ToFloat64(dbRowsWritten.WithLabelValues("test_table")))
+	assert.Equal(t, uint64(2), sampleCount(t, dbWriteDuration.WithLabelValues("test_table")))
+}
+
+func TestWriteTable(t *testing.T) {
+	tests := map[string]string{
+		"INSERT INTO pokemon (id) VALUES (?)":                         "pokemon",
+		"INSERT OR IGNORE INTO species_egg_groups VALUES (?, ?)":      "species_egg_groups",
+		"-- Replaces a row\nINSERT OR REPLACE INTO assets VALUES (?)": "assets",
+		"update sync_runs SET status = ?":                             "sync_runs",
+		"DELETE FROM `sessions` WHERE token = ?":                      "sessions",
+		"SELECT * FROM pokemon":                                       "",
+		"DROP TABLE IF EXISTS pokemon;":                               "",
+	}
+	for query, table := range tests {
+		assert.Equal(t, table, WriteTable(query), query)
+	}
+}
+
+func sampleCount(t *testing.T, o prometheus.Observer) uint64 {
+	var m dto.Metric
+	require.NoError(t, o.(prometheus.Metric).Write(&m))
+	return m.GetHistogram().GetSampleCount()
+}
@@ -0,0 +1,48 @@
+package models
+
+import "strings"
+
+// Battle-only forms have no learnset in PokeAPI and their pokemon-form only
+// records the version group that introduced them, so the games they appear in
+// are listed here. Megas were dropped in Sword/Shield, Gigantamax only exists
+// in Sword/Shield and its DLCs.
+var battleOnlyFormVersionGroups = map[string][]string{
+	"mega": {
+		"x-y",
+		"omega-ruby-alpha-sapphire",
+		"sun-moon",
+		"ultra-sun-ultra-moon",
+		"lets-go-pikachu-lets-go-eevee",
+	},
+	"primal": {
+		"omega-ruby-alpha-sapphire",
+		"sun-moon",
+		"ultra-sun-ultra-moon",
+	},
+	"totem": {
+		"sun-moon",
+		"ultra-sun-ultra-moon",
+	},
+	"gmax": {
+		"sword-shield",
+		"the-isle-of-armor",
+		"the-crown-tundra",
+	},
+	"eternamax": {
+		"sword-shield",
+		"the-isle-of-armor",
+		"the-crown-tundra",
+	},
+}
+
+// GetBattleOnlyFormVersionGroups returns the version groups a battle-only
+// variety appears in, by the kind in its pokemon name (charizard-mega-x, pikachu-gmax)
+// Returns false if the pokemon isn't a battle-only variety
+func GetBattleOnlyFormVersionGroups(pokemonName string) ([]string, bool) {
+	for _, part := range strings.Split(pokemonName, "-")[1:] {
+		if versionGroups, ok := battleOnlyFormVersionGroups[part]; ok {
+			return versionGroups, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,20 @@
+package dto
+
+// Asset is a downloaded image stored under the SHA-256 of its content, with
+// smaller copies so clients can pick a size
+type Asset struct {
+	Hash      string         `json:"hash"`
+	SourceURL string         `json:"sourceUrl"`
+	Format    string         `json:"format"` // "png", "jpeg" or "gif"
+	Width     int            `json:"width"`
+	Height    int            `json:"height"`
+	Path      string         `json:"path"`
+	Variants  []AssetVariant `json:"variants"` // Thumbnails, smallest first
+}
+
+type AssetVariant struct {
+	Format string `json:"format"` // "webp", or "jpeg" for JPEG originals
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Path   string `json:"path"`
+}
@@ -0,0 +1,43 @@
+package dto
+
+const (
+	PlanSourceParty = "party"
+	PlanSourcePool  = "pool"
+)
+
+// BattlePlan ranks the Pokemon a player can bring against a trainer battle.
+// LevelCap is the highest level on the trainer's team, pool Pokemon are
+// planned at it and only know the moves they learn by then.
+type BattlePlan struct {
+	Trainer  Trainer   `json:"trainer"`
+	LevelCap int       `json:"levelCap"`
+	Source   string    `json:"source"` // "party" or "pool"
+	Counters []Counter `json:"counters"`
+}
+
+// Counter is one of the player's Pokemon with its matchup against every
+// member of the trainer's team, best counters have the highest Score
+type Counter struct {
+	PokemonID int       `json:"pokemonId"`
+	Name      string    `json:"name"`
+	Level     int       `json:"level"`
+	Types     []string  `json:"types"`
+	Score     float64   `json:"score"`
+	Matchups  []Matchup `json:"matchups"`
+}
+
+// Matchup is a counter's best move against one opponent. Percentages are of
+// the opponent's HP, Threat is how effective the opponent's types are
+// against the counter.
+type Matchup struct {
+	Slot          int     `json:"slot"`
+	Opponent      string  `json:"opponent"`
+	OpponentLevel int     `json:"opponentLevel"`
+	Move          string  `json:"move,omitempty"` // Empty when the counter has no damaging move
+	MoveType      string  `json:"moveType,omitempty"`
+	Effectiveness float64 `json:"effectiveness"`
+	MinPercent    float64 `json:"minPercent"`
+	MaxPercent    float64 `json:"maxPercent"`
+	Threat        float64 `json:"threat"`
+	Faster        bool    `json:"faster"`
+}
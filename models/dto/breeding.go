@@ -0,0 +1,26 @@
+package dto
+
+// BreedingCandidate is a species that knows a move by level-up or as an egg
+// move, LevelLearnedAt is nil when it only learns it as an egg move
+type BreedingCandidate struct {
+	SpeciesID      int
+	Name           string
+	GenderRate     int // -1 = genderless, 0-8 = female ratio
+	EggGroups      []string
+	LevelLearnedAt *int
+	EggMove        bool
+}
+
+// BreedingChain passes a move from a species that learns it by level-up down
+// to the target species. Every step is the father of the next one.
+type BreedingChain struct {
+	Steps []BreedingStep `json:"steps"`
+}
+
+type BreedingStep struct {
+	SpeciesID   int    `json:"speciesId"`
+	Name        string `json:"name"`
+	LearnMethod string `json:"learnMethod"`        // "level-up" for the first step, "egg" for the rest
+	Level       int    `json:"level,omitempty"`    // Level the first step learns the move at
+	EggGroup    string `json:"eggGroup,omitempty"` // Shared with the next step
+}
@@ -0,0 +1,47 @@
+package dto
+
+type Encounter struct {
+	PokemonID               int      `json:"pokemonId"`
+	PokemonName             string   `json:"pokemonName"`
+	LocationAreaID          int      `json:"locationAreaId"`
+	LocationAreaName        string   `json:"locationAreaName"`
+	LocationAreaDisplayName string   `json:"locationAreaDisplayName"`
+	LocationID              int      `json:"locationId"`
+	LocationName            string   `json:"locationName"`
+	LocationDisplayName     string   `json:"locationDisplayName"`
+	VersionID               int      `json:"versionId"`
+	Method                  string   `json:"method"`
+	MinLevel                int      `json:"minLevel"`
+	MaxLevel                int      `json:"maxLevel"`
+	Chance                  int      `json:"chance"`
+	Conditions              []string `json:"conditions"`
+}
+
+// SpeciesLocation is a location a species can be encountered at in a version
+type SpeciesLocation struct {
+	SpeciesID        int
+	SpeciesName      string
+	EvolutionChainID int
+	VersionID        int
+	VersionName      string
+	LocationName     string // Display name, or name when there is none
+}
+
+// VersionExclusives lists the species that can only be obtained in one
+// version of a version group and have to be traded for in the others
+type VersionExclusives struct {
+	Version Version            `json:"version"`
+	Species []ExclusiveSpecies `json:"species"`
+}
+
+type ExclusiveSpecies struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Location struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	RegionName  string `json:"regionName"`
+	DisplayName string `json:"displayName"` // Name when there is no English name
+}
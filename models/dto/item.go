@@ -0,0 +1,20 @@
+package dto
+
+type Item struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Category    string `json:"category"`
+	Cost        int    `json:"cost"`
+	FlingPower  int    `json:"flingPower"`
+	EffectShort string `json:"effectShort"`
+	EffectFull  string `json:"effectFull"`
+	Sprite      string `json:"sprite"`
+}
+
+// Machine is a TM/HM/TR of a version group, e.g. "TM24" teaching thunderbolt
+type Machine struct {
+	Name     string `json:"name"`
+	MoveID   int    `json:"moveId"`
+	MoveName string `json:"moveName"`
+}
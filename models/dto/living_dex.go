@@ -0,0 +1,50 @@
+package dto
+
+type DexProgress struct {
+	Pokedex Pokedex `json:"pokedex"`
+	Caught  int     `json:"caught"`
+	Total   int     `json:"total"`
+	Percent float64 `json:"percent"`
+}
+
+type DexEntry struct {
+	EntryNumber int    `json:"entryNumber"`
+	SpeciesID   int    `json:"speciesId"`
+	Name        string `json:"name"`
+	Caught      bool   `json:"caught"`
+}
+
+// LivingDex is the caught state of every entry of a pokedex
+type LivingDex struct {
+	DexProgress
+	Entries []DexEntry `json:"entries"`
+}
+
+// GameProgress combines the pokedexes of a game's version group. Caught and
+// Total count distinct species, so a species in two pokedexes counts once.
+type GameProgress struct {
+	Version   Version       `json:"version"`
+	Pokedexes []DexProgress `json:"pokedexes"`
+	Caught    int           `json:"caught"`
+	Total     int           `json:"total"`
+	Percent   float64       `json:"percent"`
+}
+
+// MissingEntry is an uncaught pokedex entry with where to get it in a game.
+// ExclusiveTo lists the versions of the version group it can be found in when
+// the paired versions cannot, EvolutionFamily the catchable relatives of a
+// species that cannot be found in the wild.
+type MissingEntry struct {
+	EntryNumber     int                   `json:"entryNumber"`
+	SpeciesID       int                   `json:"speciesId"`
+	Name            string                `json:"name"`
+	Availability    []VersionAvailability `json:"availability"`
+	ExclusiveTo     []string              `json:"exclusiveTo,omitempty"`
+	EvolutionFamily []string              `json:"evolutionFamily,omitempty"`
+}
+
+type VersionAvailability struct {
+	VersionID int      `json:"versionId"`
+	Version   string   `json:"version"`
+	Locations []string `json:"locations"`
+}
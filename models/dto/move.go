@@ -1,13 +1,50 @@
 package dto
 
 type Move struct {
-	ID          int
-	Name        string
-	Type        string
-	Power       int
-	Accuracy    int
-	PP          int
-	DamageClass string
-	EffectShort string
-	Priority    int
+	ID           int              `json:"id"`
+	Name         string           `json:"name"`
+	Type         string           `json:"type"`
+	Power        int              `json:"power"`
+	Accuracy     int              `json:"accuracy"`
+	PP           int              `json:"pp"`
+	DamageClass  string           `json:"damageClass"`
+	EffectShort  string           `json:"effectShort"`
+	Priority     int              `json:"priority"`
+	EffectChance int              `json:"effectChance,omitempty"`
+	EffectFull   string           `json:"effectFull,omitempty"`
+	Target       string           `json:"target,omitempty"`
+	ContestType  string           `json:"contestType,omitempty"`
+	Meta         *MoveMeta        `json:"meta,omitempty"`
+	StatChanges  []MoveStatChange `json:"statChanges,omitempty"`
+}
+
+// MoveMeta is nil on a Move when PokeAPI has no battle metadata for it.
+// Hit and turn counts are 0 for moves that are not multi-hit or multi-turn.
+type MoveMeta struct {
+	Category      string `json:"category"`
+	Ailment       string `json:"ailment"`
+	AilmentChance int    `json:"ailmentChance"`
+	CritRate      int    `json:"critRate"`
+	Drain         int    `json:"drain"`
+	Healing       int    `json:"healing"`
+	FlinchChance  int    `json:"flinchChance"`
+	StatChance    int    `json:"statChance"`
+	MinHits       int    `json:"minHits"`
+	MaxHits       int    `json:"maxHits"`
+	MinTurns      int    `json:"minTurns"`
+	MaxTurns      int    `json:"maxTurns"`
+}
+
+type MoveStatChange struct {
+	Stat   string `json:"stat"`
+	Change int    `json:"change"`
+}
+
+// PokemonMove is one learnset row: a move with the stats it had in the
+// version group the Pokemon learns it in.
+type PokemonMove struct {
+	Move           Move   `json:"move"`
+	LearnMethod    string `json:"learnMethod"`
+	LevelLearnedAt int    `json:"levelLearnedAt"`
+	Machine        string `json:"machine,omitempty"` // e.g., "TM24" for learn_method = machine
 }
@@ -0,0 +1,17 @@
+package dto
+
+// Nature has empty stats for neutral natures
+type Nature struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	IncreasedStat string `json:"increasedStat"`
+	DecreasedStat string `json:"decreasedStat"`
+}
+
+type Characteristic struct {
+	ID             int    `json:"id"`
+	HighestStat    string `json:"highestStat"`
+	GeneModulo     int    `json:"geneModulo"`
+	PossibleValues []int  `json:"possibleValues"`
+	Description    string `json:"description"`
+}
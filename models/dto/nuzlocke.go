@@ -0,0 +1,62 @@
+package dto
+
+import "time"
+
+const (
+	NuzlockeAlive  = "alive" // In the party
+	NuzlockeBoxed  = "boxed"
+	NuzlockeDead   = "dead"
+	NuzlockeMissed = "missed" // Fled or not caught, the encounter is used up
+)
+
+type NuzlockeRules struct {
+	DupesClause bool `json:"dupesClause"` // Already caught evolution families don't count as an encounter
+	ShinyClause bool `json:"shinyClause"` // Shinies can always be caught
+	GiftClause  bool `json:"giftClause"`  // Gifts are free instead of being the location's encounter
+}
+
+// NuzlockeRun is a Nuzlocke challenge through one game. Encounters are only
+// filled in when a single run is loaded.
+type NuzlockeRun struct {
+	ID         int                 `json:"id"`
+	UserID     int                 `json:"-"`
+	VersionID  int                 `json:"versionId"`
+	Name       string              `json:"name"`
+	Rules      NuzlockeRules       `json:"rules"`
+	Encounters []NuzlockeEncounter `json:"encounters,omitempty"`
+	CreatedAt  time.Time           `json:"createdAt"`
+	UpdatedAt  time.Time           `json:"updatedAt"`
+}
+
+type NuzlockeEncounter struct {
+	ID               int        `json:"id"`
+	RunID            int        `json:"-"`
+	LocationID       int        `json:"locationId"`
+	LocationName     string     `json:"locationName"`
+	SpeciesID        int        `json:"speciesId"`
+	SpeciesName      string     `json:"speciesName"`
+	EvolutionChainID int        `json:"-"`
+	Nickname         string     `json:"nickname"`
+	Level            int        `json:"level"`
+	Status           string     `json:"status"`
+	CauseOfDeath     string     `json:"causeOfDeath,omitempty"`
+	Shiny            bool       `json:"shiny"`
+	Gift             bool       `json:"gift"`
+	EncounteredAt    time.Time  `json:"encounteredAt"`
+	DiedAt           *time.Time `json:"diedAt,omitempty"`
+}
+
+// NuzlockeStats summarises a run. SurvivalRate is the percentage of caught
+// pokemon that are still alive or boxed.
+type NuzlockeStats struct {
+	Encounters    int                 `json:"encounters"`
+	Alive         int                 `json:"alive"`
+	Boxed         int                 `json:"boxed"`
+	Dead          int                 `json:"dead"`
+	Missed        int                 `json:"missed"`
+	SurvivalRate  float64             `json:"survivalRate"`
+	PartyLevel    float64             `json:"partyLevel"` // Average level of the party
+	Party         []NuzlockeEncounter `json:"party"`
+	Graveyard     []NuzlockeEncounter `json:"graveyard"` // Most recent death first
+	DeathsByCause map[string]int      `json:"deathsByCause"`
+}
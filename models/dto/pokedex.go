@@ -17,9 +17,10 @@ type PokedexDescription struct {
 }
 
 type PokemonEntry struct {
-	EntryNumber int    `json:"entryNumber"`
-	Name        string `json:"name"`
-	SpeciesID   int    `json:"speciesId"`
+	EntryNumber      int    `json:"entryNumber"`
+	Name             string `json:"name"`
+	SpeciesID        int    `json:"speciesId"`
+	EvolutionChainID int    `json:"-"`
 }
 
 type VersionGroupPokedex struct {
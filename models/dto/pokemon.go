@@ -17,6 +17,33 @@ type Pokemon struct {
 	SpriteFrontDefault string
 	SpriteFrontShiny   string
 	SpriteArtwork      string
+	SpriteArtworkShiny string
+}
+
+const (
+	SpriteFront = "front"
+	SpriteBack  = "back"
+)
+
+// PokemonSprite is an in-game sprite of a pokemon. Sprite sets are PokeAPI's
+// names, one per version group except gold and silver.
+type PokemonSprite struct {
+	PokemonID    int    `json:"pokemonId"`
+	Generation   int    `json:"generation"`
+	VersionGroup string `json:"versionGroup"` // e.g. "gold-silver"
+	SpriteSet    string `json:"spriteSet"`    // e.g. "gold"
+	Side         string `json:"side"`
+	Shiny        bool   `json:"shiny"`
+	Female       bool   `json:"female"`
+	Animated     bool   `json:"animated"`
+	Path         string `json:"-"`   // Local file
+	URL          string `json:"url"` // Set by the API
+}
+
+type Species struct {
+	ID               int    `json:"id"`
+	Name             string `json:"name"`
+	EvolutionChainID int    `json:"evolutionChainId"` // 0 when unknown
 }
 
 type Ability struct {
@@ -25,3 +52,16 @@ type Ability struct {
 	IsHidden    bool
 	Slot        int
 }
+
+// PokemonForm is a form available in a version group. PokemonIsDefault marks
+// forms of the species' default variety, e.g. raichu vs raichu-alola.
+type PokemonForm struct {
+	ID               int    `json:"id"`
+	PokemonID        int    `json:"pokemonId"`
+	Name             string `json:"name"`
+	FormName         string `json:"formName"`
+	IsDefault        bool   `json:"isDefault"`
+	PokemonIsDefault bool   `json:"pokemonIsDefault"`
+	IsBattleOnly     bool   `json:"isBattleOnly"`
+	IsMega           bool   `json:"isMega"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// Resources a sync can carry on without
+const (
+	ResourceGame           = "game"    // A version with its pokedexes
+	ResourceSpecies        = "species" // A species of a game with its pokemon and pokedex entries
+	ResourceMove           = "move"
+	ResourceItem           = "item"
+	ResourceNature         = "nature"
+	ResourceCharacteristic = "characteristic"
+	ResourceType           = "type"
+	ResourceTrainers       = "trainers" // The trainers of a version group
+)
+
+// SyncFailure is a resource a sync failed to sync. VersionID is the game a
+// species was synced for and 0 for other resources.
+type SyncFailure struct {
+	Resource   string    `json:"resource"`
+	ResourceID int       `json:"resourceId"`
+	VersionID  int       `json:"versionId,omitempty"`
+	Error      string    `json:"error"`
+	Attempts   int       `json:"attempts"`
+	FailedAt   time.Time `json:"failedAt"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+const (
+	SyncRunning   = "running"
+	SyncSucceeded = "succeeded"
+	SyncFailed    = "failed"
+)
+
+// SyncRun is one run of the sync command with a summary of what it did.
+// FinishedAt is nil while it runs, or when the process died before the end.
+type SyncRun struct {
+	ID         int           `json:"id"`
+	Command    string        `json:"command"`
+	Status     string        `json:"status"`
+	Error      string        `json:"error,omitempty"`
+	StartedAt  time.Time     `json:"startedAt"`
+	FinishedAt *time.Time    `json:"finishedAt,omitempty"`
+	Duration   time.Duration `json:"duration"`
+	Fetched    int           `json:"fetched"`
+	Inserted   int           `json:"inserted"`
+	Skipped    int           `json:"skipped"`
+	Failed     int           `json:"failed"`
+	CacheHits  int           `json:"cacheHits"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+const (
+	TrainerGymLeader = "gym-leader"
+	TrainerEliteFour = "elite-four"
+)
+
+// Trainer is one battle against an important trainer in a version
+type Trainer struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	Role          string           `json:"role"`
+	VersionID     int              `json:"versionId"`
+	Battle        int              `json:"battle"` // 1 = first battle, 2+ = rematches
+	Order         int              `json:"order"`
+	Location      string           `json:"location"`
+	Badge         string           `json:"badge,omitempty"`
+	TypeSpecialty string           `json:"typeSpecialty,omitempty"`
+	SourceURL     string           `json:"sourceUrl"`
+	Team          []TrainerPokemon `json:"team"`
+}
+
+type TrainerPokemon struct {
+	Slot        int      `json:"slot"`
+	SpeciesID   int      `json:"speciesId"`
+	SpeciesName string   `json:"speciesName"`
+	Level       int      `json:"level"`
+	HeldItem    string   `json:"heldItem,omitempty"`
+	Moves       []string `json:"moves"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+// TypeChart is the type matchup chart of a single generation.
+// Pairs missing from Multipliers are neutral (x1).
+type TypeChart struct {
+	Multipliers   map[string]map[string]float64 `json:"multipliers"`   // attacking type -> defending type -> multiplier
+	DamageClasses map[string]string             `json:"damageClasses"` // Pre-Gen 4 damage class of every type
+}
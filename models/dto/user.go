@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+type User struct {
+	ID           int       `json:"id"`
+	Username     string    `json:"username"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Playthrough is a single run through one game. Caught, Seen and Party are
+// only filled in when a single playthrough is loaded.
+type Playthrough struct {
+	ID          int           `json:"id"`
+	UserID      int           `json:"-"`
+	VersionID   int           `json:"versionId"`
+	Name        string        `json:"name"`
+	Notes       string        `json:"notes"`
+	CaughtCount int           `json:"caughtCount"`
+	SeenCount   int           `json:"seenCount"`
+	Caught      []int         `json:"caught,omitempty"` // Species IDs
+	Seen        []int         `json:"seen,omitempty"`   // Species IDs seen but not caught
+	Party       []PartyMember `json:"party,omitempty"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+}
+
+type PartyMember struct {
+	Slot      int    `json:"slot"` // 1-6
+	PokemonID int    `json:"pokemonId"`
+	Nickname  string `json:"nickname"`
+	Level     int    `json:"level"`
+}
@@ -0,0 +1,52 @@
+package dto
+
+const (
+	MediaCover      = "cover"
+	MediaScreenshot = "screenshot"
+	MediaArtwork    = "artwork"
+)
+
+// VersionMetadata is what IGDB knows about the game of a version
+type VersionMetadata struct {
+	VersionID    int            `json:"versionId"`
+	IGDBID       int            `json:"igdbId"`
+	Summary      string         `json:"summary"`
+	Storyline    string         `json:"storyline,omitempty"`
+	Platforms    []string       `json:"platforms"`
+	Developers   []string       `json:"developers"`
+	Publishers   []string       `json:"publishers"`
+	ReleaseDates []ReleaseDate  `json:"releaseDates"`
+	Media        []VersionMedia `json:"media"`
+}
+
+type ReleaseDate struct {
+	Region   string `json:"region"`   // e.g. "japan"
+	Platform string `json:"platform"` // e.g. "Game Boy"
+	Date     int64  `json:"date"`     // Unix timestamp
+}
+
+// VersionMedia is a downloaded cover, screenshot or artwork
+type VersionMedia struct {
+	Kind    string `json:"kind"`
+	ImageID string `json:"imageId"` // IGDB image_id
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+	Path    string `json:"-"`   // Local file
+	URL     string `json:"url"` // Set by the API
+}
+
+const (
+	GameMappingConfirmed = "confirmed"
+	GameMappingPending   = "pending"
+)
+
+// GameMapping links a version to its IGDB game. Pending mappings are search
+// matches that weren't good enough to use without a review.
+type GameMapping struct {
+	VersionName string  `json:"versionName"`
+	IGDBID      int     `json:"igdbId"`
+	IGDBName    string  `json:"igdbName"`
+	Confidence  float64 `json:"confidence"`
+	Status      string  `json:"status"`
+	UpdatedAt   int64   `json:"updatedAt"` // Unix seconds
+}
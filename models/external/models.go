@@ -1,17 +1,27 @@
 package external
 
 type Species struct {
-	ID             int      `json:"id"`
-	Name           string   `json:"name"`
-	EvolutionChain URL      `json:"evolution_chain"`
-	GenderRate     int      `json:"gender_rate"`
-	CaptureRate    int      `json:"capture_rate"`
-	BaseHappiness  int      `json:"base_happiness"`
-	IsBaby         bool     `json:"is_baby"`
-	IsLegendary    bool     `json:"is_legendary"`
-	IsMythical     bool     `json:"is_mythical"`
-	GrowthRate     Response `json:"growth_rate"`
-	Generation     Response `json:"generation"`
+	ID                   int        `json:"id"`
+	Name                 string     `json:"name"`
+	EvolutionChain       URL        `json:"evolution_chain"`
+	GenderRate           int        `json:"gender_rate"`
+	CaptureRate          int        `json:"capture_rate"`
+	BaseHappiness        int        `json:"base_happiness"`
+	IsBaby               bool       `json:"is_baby"`
+	IsLegendary          bool       `json:"is_legendary"`
+	IsMythical           bool       `json:"is_mythical"`
+	GrowthRate           Response   `json:"growth_rate"`
+	Generation           Response   `json:"generation"`
+	Varieties            []Variety  `json:"varieties"`
+	EggGroups            []Response `json:"egg_groups"`
+	HatchCounter         int        `json:"hatch_counter"`
+	HasGenderDifferences bool       `json:"has_gender_differences"`
+}
+
+// Variety is a Pokemon belonging to a species, e.g. raichu and raichu-alola
+type Variety struct {
+	IsDefault bool     `json:"is_default"`
+	Pokemon   Response `json:"pokemon"`
 }
 
 type URL struct {
@@ -19,15 +29,86 @@ type URL struct {
 }
 
 type Move struct {
-	ID            int           `json:"id"`
-	Name          string        `json:"name"`
-	Type          Response      `json:"type"`
-	Power         int           `json:"power"`
-	Accuracy      int           `json:"accuracy"`
-	PP            int           `json:"pp"`
-	DamageClass   Response      `json:"damage_class"`
-	EffectEntries []EffectEntry `json:"effect_entries"`
-	Priority      int           `json:"priority"`
+	ID                 int              `json:"id"`
+	Name               string           `json:"name"`
+	Type               Response         `json:"type"`
+	Power              int              `json:"power"`
+	Accuracy           int              `json:"accuracy"`
+	PP                 int              `json:"pp"`
+	DamageClass        Response         `json:"damage_class"`
+	EffectChance       *int             `json:"effect_chance"`
+	EffectEntries      []EffectEntry    `json:"effect_entries"`
+	Priority           int              `json:"priority"`
+	Target             Response         `json:"target"`
+	Meta               *MoveMeta        `json:"meta"`
+	StatChanges        []MoveStatChange `json:"stat_changes"`
+	ContestType        *Response        `json:"contest_type"`
+	ContestEffect      *URL             `json:"contest_effect"`
+	SuperContestEffect *URL             `json:"super_contest_effect"`
+	PastValues         []MovePastValue  `json:"past_values"`
+	Machines           []MoveMachine    `json:"machines"`
+}
+
+// MoveMachine references the TM/HM/TR that teaches a move in a version group
+type MoveMachine struct {
+	Machine      URL      `json:"machine"`
+	VersionGroup Response `json:"version_group"`
+}
+
+// Machine maps a TM/HM/TR item to the move it teaches in a version group
+type Machine struct {
+	ID           int      `json:"id"`
+	Item         Response `json:"item"`
+	Move         Response `json:"move"`
+	VersionGroup Response `json:"version_group"`
+}
+
+type Item struct {
+	ID            int             `json:"id"`
+	Name          string          `json:"name"`
+	Cost          int             `json:"cost"`
+	FlingPower    *int            `json:"fling_power"`
+	Category      Response        `json:"category"`
+	EffectEntries []EffectEntry   `json:"effect_entries"`
+	Names         []LocalizedName `json:"names"`
+	Sprites       ItemSprites     `json:"sprites"`
+}
+
+type ItemSprites struct {
+	Default string `json:"default"`
+}
+
+// MoveMeta is the battle metadata of a move. Chances and rates are percentages,
+// except CritRate which is a crit stage bonus.
+type MoveMeta struct {
+	Ailment       Response `json:"ailment"`
+	Category      Response `json:"category"`
+	MinHits       *int     `json:"min_hits"`
+	MaxHits       *int     `json:"max_hits"`
+	MinTurns      *int     `json:"min_turns"`
+	MaxTurns      *int     `json:"max_turns"`
+	Drain         int      `json:"drain"`
+	Healing       int      `json:"healing"`
+	CritRate      int      `json:"crit_rate"`
+	AilmentChance int      `json:"ailment_chance"`
+	FlinchChance  int      `json:"flinch_chance"`
+	StatChance    int      `json:"stat_chance"`
+}
+
+type MoveStatChange struct {
+	Change int      `json:"change"`
+	Stat   Response `json:"stat"`
+}
+
+// MovePastValue holds the values a move had before VersionGroup changed them.
+// Nil fields were not changed in that version group.
+type MovePastValue struct {
+	Accuracy     *int      `json:"accuracy"`
+	EffectChance *int      `json:"effect_chance"`
+	Power        *int      `json:"power"`
+	PP           *int      `json:"pp"`
+	Type         *Response `json:"type"`
+	VersionGroup Response  `json:"version_group"`
 }
 
 type EffectEntry struct {
@@ -61,9 +142,32 @@ type Pokemon struct {
 	Stats          []Stat         `json:"stats"`
 	Sprites        Sprite         `json:"sprites"`
 	Species        Response       `json:"species"`
+	Forms          []Response     `json:"forms"`
+	PastTypes      []PastType     `json:"past_types"`
 	SpeciesID      int
 }
 
+// PastType lists the types a Pokemon had up to and including Generation,
+// e.g. clefairy was normal until generation-v
+type PastType struct {
+	Generation Response      `json:"generation"`
+	Types      []PokemonType `json:"types"`
+}
+
+// PokemonForm is a form of a Pokemon variety, e.g. unown-b or charizard-mega-x.
+// VersionGroup is the version group the form was introduced in.
+type PokemonForm struct {
+	ID           int      `json:"id"`
+	Name         string   `json:"name"`
+	FormName     string   `json:"form_name"`
+	FormOrder    int      `json:"form_order"`
+	IsDefault    bool     `json:"is_default"`
+	IsBattleOnly bool     `json:"is_battle_only"`
+	IsMega       bool     `json:"is_mega"`
+	Pokemon      Response `json:"pokemon"`
+	VersionGroup Response `json:"version_group"`
+}
+
 type Ability struct {
 	IsHidden bool     `json:"is_hidden"`
 	Ability  Response `json:"ability"`
@@ -71,7 +175,26 @@ type Ability struct {
 }
 
 type Sprite struct {
-	Other Other `json:"other"`
+	SpriteSet                                      // The latest in-game sprites
+	Other     Other                                `json:"other"`
+	Versions  map[string]map[string]VersionSprites `json:"versions"` // By generation, then sprite set, e.g. "generation-v" and "black-white"
+}
+
+// SpriteSet is a set of in-game sprites, URLs are empty where a game has none
+type SpriteSet struct {
+	FrontDefault     string `json:"front_default"`
+	FrontShiny       string `json:"front_shiny"`
+	FrontFemale      string `json:"front_female"`
+	FrontShinyFemale string `json:"front_shiny_female"`
+	BackDefault      string `json:"back_default"`
+	BackShiny        string `json:"back_shiny"`
+	BackFemale       string `json:"back_female"`
+	BackShinyFemale  string `json:"back_shiny_female"`
+}
+
+type VersionSprites struct {
+	SpriteSet
+	Animated *SpriteSet `json:"animated"` // Generation V only
 }
 
 type Other struct {
@@ -100,6 +223,7 @@ type Version struct {
 type VersionGroup struct {
 	ID         int        `json:"id"`
 	Name       string     `json:"name"`
+	Order      int        `json:"order"`
 	Generation Response   `json:"generation"`
 	Pokedexes  []Response `json:"pokedexes"`
 }
@@ -132,11 +256,104 @@ type PokedexDescriptions struct {
 	Language    Response `json:"language"`
 }
 
+// Nature has nil stats for neutral natures like hardy
+type Nature struct {
+	ID            int       `json:"id"`
+	Name          string    `json:"name"`
+	IncreasedStat *Response `json:"increased_stat"`
+	DecreasedStat *Response `json:"decreased_stat"`
+	LikesFlavor   *Response `json:"likes_flavor"`
+	HatesFlavor   *Response `json:"hates_flavor"`
+}
+
+// Characteristic is the summary text shown for a Pokemon's highest IV,
+// e.g. "Loves to eat" when HP is highest and HP IV mod 5 is 0
+type Characteristic struct {
+	ID             int                         `json:"id"`
+	GeneModulo     int                         `json:"gene_modulo"`
+	PossibleValues []int                       `json:"possible_values"`
+	HighestStat    Response                    `json:"highest_stat"`
+	Descriptions   []CharacteristicDescription `json:"descriptions"`
+}
+
+type CharacteristicDescription struct {
+	Description string   `json:"description"`
+	Language    Response `json:"language"`
+}
+
+type LocalizedName struct {
+	Name     string   `json:"name"`
+	Language Response `json:"language"`
+}
+
+type Location struct {
+	ID     int             `json:"id"`
+	Name   string          `json:"name"`
+	Region *Response       `json:"region"`
+	Names  []LocalizedName `json:"names"`
+	Areas  []Response      `json:"areas"`
+}
+
+type LocationArea struct {
+	ID       int             `json:"id"`
+	Name     string          `json:"name"`
+	Location Response        `json:"location"`
+	Names    []LocalizedName `json:"names"`
+}
+
+// LocationAreaEncounter is one entry of GET /pokemon/{id}/encounters
+type LocationAreaEncounter struct {
+	LocationArea   Response                 `json:"location_area"`
+	VersionDetails []EncounterVersionDetail `json:"version_details"`
+}
+
+type EncounterVersionDetail struct {
+	Version          Response          `json:"version"`
+	MaxChance        int               `json:"max_chance"`
+	EncounterDetails []EncounterDetail `json:"encounter_details"`
+}
+
+// EncounterDetail is a single encounter slot. ConditionValues are e.g.
+// "time-night", "season-winter" or "swarm-yes".
+type EncounterDetail struct {
+	MinLevel        int        `json:"min_level"`
+	MaxLevel        int        `json:"max_level"`
+	Chance          int        `json:"chance"`
+	Method          Response   `json:"method"`
+	ConditionValues []Response `json:"condition_values"`
+}
+
 type PokemonType struct {
 	Type Response `json:"type"`
 	Slot int      `json:"slot"`
 }
 
+// Type is an elemental type. MoveDamageClass is the damage class every move of
+// the type had before the Gen 4 physical/special split, nil for fairy and the
+// status-only types.
+type Type struct {
+	ID                  int                  `json:"id"`
+	Name                string               `json:"name"`
+	MoveDamageClass     *Response            `json:"move_damage_class"`
+	DamageRelations     TypeDamageRelations  `json:"damage_relations"`
+	PastDamageRelations []PastDamageRelation `json:"past_damage_relations"`
+}
+
+type TypeDamageRelations struct {
+	NoDamageTo       []Response `json:"no_damage_to"`
+	HalfDamageTo     []Response `json:"half_damage_to"`
+	DoubleDamageTo   []Response `json:"double_damage_to"`
+	NoDamageFrom     []Response `json:"no_damage_from"`
+	HalfDamageFrom   []Response `json:"half_damage_from"`
+	DoubleDamageFrom []Response `json:"double_damage_from"`
+}
+
+// PastDamageRelation holds the damage relations a type had up to and including Generation
+type PastDamageRelation struct {
+	Generation      Response            `json:"generation"`
+	DamageRelations TypeDamageRelations `json:"damage_relations"`
+}
+
 type Response struct {
 	Name string `json:"name"`
 	Url  string `json:"url"`
@@ -4,22 +4,34 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/metrics"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/progress"
 )
 
 type Client struct {
-	BaseURL string
+	BaseURL    string
+	HTTPClient *http.Client
+	Progress   *progress.Tracker // Counts every response, optional
 }
 
 func NewClient(baseURL string) *Client {
-	return &Client{BaseURL: baseURL}
+	return &Client{
+		BaseURL:    baseURL,
+		HTTPClient: &http.Client{Transport: metrics.Transport("pokeapi", nil)},
+	}
 }
 
 func (c *Client) FetchPokemon(id int) (*external.Pokemon, error) {
 	return fetchByID[external.Pokemon](c, "pokemon", id)
 }
 
+func (c *Client) FetchPokemonForm(id int) (*external.PokemonForm, error) {
+	return fetchByID[external.PokemonForm](c, "pokemon-form", id)
+}
+
 func (c *Client) FetchMove(id int) (*external.Move, error) {
 	return fetchByID[external.Move](c, "move", id)
 }
@@ -40,9 +52,44 @@ func (c *Client) FetchPokedex(id int) (*external.Pokedex, error) {
 	return fetchByID[external.Pokedex](c, "pokedex", id)
 }
 
+func (c *Client) FetchLocation(id int) (*external.Location, error) {
+	return fetchByID[external.Location](c, "location", id)
+}
+
+func (c *Client) FetchLocationArea(id int) (*external.LocationArea, error) {
+	return fetchByID[external.LocationArea](c, "location-area", id)
+}
+
+func (c *Client) FetchPokemonEncounters(pokemonID int) ([]external.LocationAreaEncounter, error) {
+	encounters, err := fetchPath[[]external.LocationAreaEncounter](c, fmt.Sprintf("pokemon/%d/encounters", pokemonID), "encounters")
+	if err != nil {
+		return nil, err
+	}
+	return *encounters, nil
+}
+
+func (c *Client) FetchItem(id int) (*external.Item, error) {
+	return fetchByID[external.Item](c, "item", id)
+}
+
+func (c *Client) FetchMachine(id int) (*external.Machine, error) {
+	return fetchByID[external.Machine](c, "machine", id)
+}
+
+func (c *Client) FetchNature(id int) (*external.Nature, error) {
+	return fetchByID[external.Nature](c, "nature", id)
+}
+
+func (c *Client) FetchCharacteristic(id int) (*external.Characteristic, error) {
+	return fetchByID[external.Characteristic](c, "characteristic", id)
+}
+
+func (c *Client) FetchType(id int) (*external.Type, error) {
+	return fetchByID[external.Type](c, "type", id)
+}
+
 func (c *Client) FetchAll(path string) ([]external.Response, error) {
-	url := fmt.Sprintf("%s/api/v2/%s", c.BaseURL, path)
-	resp, err := http.Get(url)
+	resp, err := c.get(path)
 	if err != nil {
 		return nil, err
 	}
@@ -56,13 +103,29 @@ func (c *Client) FetchAll(path string) ([]external.Response, error) {
 	if err := json.NewDecoder(resp.Body).Decode(&paginatedResponse); err != nil {
 		return nil, fmt.Errorf("failed to decode all pokemon :%w", err)
 	}
+	c.Progress.Fetched()
 
 	return paginatedResponse.Results, nil
 }
 
+// get requests a path below /api/v2/
+func (c *Client) get(path string) (*http.Response, error) {
+	start := time.Now()
+	resp, err := c.HTTPClient.Get(fmt.Sprintf("%s/api/v2/%s", c.BaseURL, path))
+	if err != nil {
+		logger().Warn("request failed", "path", path, "err", err)
+		return nil, err
+	}
+	logger().Debug("fetched", "path", path, "status", resp.StatusCode, "duration", time.Since(start))
+	return resp, nil
+}
+
 func fetchByID[T any](c *Client, resource string, id int) (*T, error) {
-	url := fmt.Sprintf("%s/api/v2/%s/%d", c.BaseURL, resource, id)
-	resp, err := http.Get(url)
+	return fetchPath[T](c, fmt.Sprintf("%s/%d", resource, id), resource)
+}
+
+func fetchPath[T any](c *Client, path string, resource string) (*T, error) {
+	resp, err := c.get(path)
 	if err != nil {
 		return nil, err
 	}
@@ -76,6 +139,7 @@ func fetchByID[T any](c *Client, resource string, id int) (*T, error) {
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return nil, fmt.Errorf("failed to decode %s: %w", resource, err)
 	}
+	c.Progress.Fetched()
 
 	return &result, nil
 }
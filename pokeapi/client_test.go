@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/progress"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -271,3 +272,21 @@ func TestFetchSpecies(t *testing.T) {
 	}
 
 }
+
+func TestFetchCountsResponses(t *testing.T) {
+	server := mockPokeAPIServer(t, "/api/v2/pokemon/25", 200, `{"id": 25, "name": "pikachu"}`)
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.Progress = progress.NewTracker()
+	_, err := client.FetchPokemon(25)
+	require.NoError(t, err)
+	assert.Equal(t, 1, client.Progress.Snapshot().Fetched)
+
+	failing := mockPokeAPIServer(t, "/api/v2/pokemon/25", 500, ``)
+	defer failing.Close()
+	client.BaseURL = failing.URL
+	_, err = client.FetchPokemon(25)
+	assert.Error(t, err)
+	assert.Equal(t, 1, client.Progress.Snapshot().Fetched)
+}
@@ -0,0 +1,8 @@
+package pokeapi
+
+import "log/slog"
+
+// logger returns the default logger tagged with this package as component
+func logger() *slog.Logger {
+	return slog.Default().With("component", "pokeapi")
+}
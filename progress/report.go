@@ -0,0 +1,163 @@
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const barWidth = 24
+
+// Reporter writes a tracker's progress every interval until it's stopped,
+// either as a bar redrawn in place or as one JSON event per line
+type Reporter struct {
+	tracker *Tracker
+	out     io.Writer
+	bar     bool
+
+	mu    sync.Mutex // Serializes writes to out
+	drawn bool       // The bar is on the current line of out
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// NewBar reports on a terminal. Logs written to the same terminal should go
+// through the reporter, see Write.
+func NewBar(t *Tracker, out io.Writer) *Reporter {
+	return &Reporter{tracker: t, out: out, bar: true}
+}
+
+// NewJSON reports as JSON lines, for logs and scripts. An event looks like
+//
+//	{"event":"progress","elapsedMs":61000,"percent":12.5,"etaMs":427000,
+//	 "stages":[{"name":"versions","done":1,"total":8}],"fetched":310,...}
+//
+// and the last one has "event":"done".
+func NewJSON(t *Tracker, out io.Writer) *Reporter {
+	return &Reporter{tracker: t, out: out}
+}
+
+// IsTerminal reports whether f is a terminal rather than a file or pipe
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Start reports every interval in the background
+func (r *Reporter) Start(interval time.Duration) {
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				r.report(false)
+			case <-r.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the background reports and writes the final one
+func (r *Reporter) Stop() {
+	if r.stop != nil {
+		close(r.stop)
+		<-r.done
+	}
+	r.report(true)
+}
+
+// Write writes p above the bar, so log lines don't end up in the middle of
+// it. A JSON reporter writes p as is.
+func (r *Reporter) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.bar && r.drawn {
+		fmt.Fprint(r.out, "\r\x1b[K")
+		r.drawn = false
+	}
+	n, err := r.out.Write(p)
+	if err != nil {
+		return n, err
+	}
+	if r.bar {
+		r.draw(r.tracker.Snapshot())
+	}
+	return n, nil
+}
+
+func (r *Reporter) report(final bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.tracker.Snapshot()
+
+	if r.bar {
+		r.draw(s)
+		if final {
+			fmt.Fprintln(r.out)
+			r.drawn = false
+		}
+		return
+	}
+
+	e := event{
+		Event:     "progress",
+		ElapsedMs: s.Elapsed.Milliseconds(),
+		Percent:   math.Round(s.Fraction()*1000) / 10,
+		Stages:    s.Stages,
+		Counts:    s.Counts,
+	}
+	if final {
+		e.Event = "done"
+	}
+	if eta, ok := s.ETA(); ok && !final {
+		ms := eta.Milliseconds()
+		e.EtaMs = &ms
+	}
+	// Encoding a struct of numbers and strings can't fail
+	line, _ := json.Marshal(e)
+	fmt.Fprintf(r.out, "%s\n", line)
+}
+
+type event struct {
+	Event     string  `json:"event"`
+	ElapsedMs int64   `json:"elapsedMs"`
+	Percent   float64 `json:"percent"`
+	EtaMs     *int64  `json:"etaMs,omitempty"` // Missing until there's a pace to go by
+	Stages    []Stage `json:"stages"`
+	Counts
+}
+
+// draw redraws the bar on the current line. r.mu must be held.
+func (r *Reporter) draw(s Snapshot) {
+	fmt.Fprintf(r.out, "\r\x1b[K%s", barLine(s))
+	r.drawn = true
+}
+
+// barLine renders a snapshot like
+// "[######------------------]  25%  versions 2/8  pokemon 40/151  ETA 3m10s"
+func barLine(s Snapshot) string {
+	f := s.Fraction()
+	filled := int(f * barWidth)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s%s] %3d%%", strings.Repeat("#", filled), strings.Repeat("-", barWidth-filled), int(f*100))
+	for _, st := range s.Stages {
+		fmt.Fprintf(&b, "  %s %d/%d", st.Name, st.Done, st.Total)
+	}
+	if eta, ok := s.ETA(); ok {
+		fmt.Fprintf(&b, "  ETA %s", eta.Round(time.Second))
+	} else {
+		fmt.Fprintf(&b, "  elapsed %s", s.Elapsed.Round(time.Second))
+	}
+	return b.String()
+}
@@ -0,0 +1,75 @@
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBarLine(t *testing.T) {
+	s := Snapshot{
+		Elapsed: time.Minute,
+		Stages: []Stage{
+			{Name: Versions, Done: 1, Total: 2},
+			{Name: Pokemon, Done: 0, Total: 2},
+		},
+	}
+	assert.Equal(t, "[######------------------]  25%  versions 1/2  pokemon 0/2  ETA 3m0s", barLine(s))
+
+	s.Stages = nil
+	assert.Equal(t, "[------------------------]   0%  elapsed 1m0s", barLine(s))
+}
+
+func TestBarKeepsLogsAboveIt(t *testing.T) {
+	var out bytes.Buffer
+	tracker := fixedTracker(time.Minute)
+	tracker.AddTotal(Moves, 4)
+	r := NewBar(tracker, &out)
+
+	r.report(false)
+	tracker.Done(Moves)
+	_, err := r.Write([]byte("warning\n"))
+	require.NoError(t, err)
+	r.Stop()
+
+	assert.Equal(t,
+		"\r\x1b[K[------------------------]   0%  moves 0/4  elapsed 1m0s"+
+			"\r\x1b[Kwarning\n"+
+			"\r\x1b[K[######------------------]  25%  moves 1/4  ETA 3m0s"+
+			"\r\x1b[K[######------------------]  25%  moves 1/4  ETA 3m0s\n",
+		out.String())
+}
+
+func TestJSONEvents(t *testing.T) {
+	var out bytes.Buffer
+	tracker := fixedTracker(time.Minute)
+	tracker.AddTotal(Versions, 4)
+	tracker.Done(Versions)
+	tracker.Fetched()
+	r := NewJSON(tracker, &out)
+
+	r.Start(time.Hour)
+	r.report(false)
+	r.Stop()
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var e map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &e))
+	assert.Equal(t, "progress", e["event"])
+	assert.Equal(t, 25.0, e["percent"])
+	assert.Equal(t, 180000.0, e["etaMs"])
+	assert.Equal(t, 1.0, e["fetched"])
+	assert.Equal(t, []any{map[string]any{"name": "versions", "done": 1.0, "total": 4.0}}, e["stages"])
+
+	e = nil
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &e))
+	assert.Equal(t, "done", e["event"])
+	assert.NotContains(t, e, "etaMs")
+}
@@ -0,0 +1,158 @@
+// Package progress keeps count of a sync's work and reports it while the sync
+// runs, as a progress bar on a terminal or as JSON events otherwise.
+package progress
+
+import (
+	"sync"
+	"time"
+)
+
+// Stages of a sync. Their totals grow as the sync discovers work, e.g. the
+// pokemon of a pokedex are only known once it has been fetched.
+const (
+	Versions        = "versions"
+	Entries         = "pokedex entries"
+	Pokemon         = "pokemon" // Unique species
+	Moves           = "moves"   // Unique moves
+	Items           = "items"
+	Natures         = "natures"
+	Characteristics = "characteristics"
+	Types           = "types"
+	Trainers        = "trainers"
+	Retries         = "retries" // Failures of earlier syncs tried again
+)
+
+// Stage is how many of a kind of work are done out of the known total
+type Stage struct {
+	Name  string `json:"name"`
+	Done  int    `json:"done"`
+	Total int    `json:"total"`
+}
+
+// Counts are what a sync did. Inserted counts resources written, e.g. a
+// pokemon with its types and abilities is one.
+type Counts struct {
+	Fetched   int `json:"fetched"`   // PokeAPI requests
+	Inserted  int `json:"inserted"`  // Resources written to the database
+	Skipped   int `json:"skipped"`   // Resources left out on purpose, e.g. forms not in a game
+	Failed    int `json:"failed"`    // Non-fatal failures, e.g. images that didn't download
+	CacheHits int `json:"cacheHits"` // Resources already synced in this run
+}
+
+// Snapshot is a tracker's state at one point in time
+type Snapshot struct {
+	Elapsed time.Duration
+	Stages  []Stage
+	Counts
+}
+
+// Fraction is the share of the known work that is done, from 0 to 1
+func (s Snapshot) Fraction() float64 {
+	done, total := 0, 0
+	for _, st := range s.Stages {
+		done += min(st.Done, st.Total)
+		total += st.Total
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(done) / float64(total)
+}
+
+// ETA estimates the time left from the pace so far. It's false until some
+// work is done, and only as good as the totals known at the time.
+func (s Snapshot) ETA() (time.Duration, bool) {
+	f := s.Fraction()
+	if f == 0 {
+		return 0, false
+	}
+	return time.Duration(float64(s.Elapsed) * (1 - f) / f), true
+}
+
+// Tracker counts the work of a sync. It's safe for concurrent use, and a nil
+// Tracker ignores everything so syncers can be used without one.
+type Tracker struct {
+	mu     sync.Mutex
+	start  time.Time
+	now    func() time.Time
+	stages []Stage // In the order they were first added to
+	counts Counts
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{start: time.Now(), now: time.Now}
+}
+
+// AddTotal adds n to the work known for a stage
+func (t *Tracker) AddTotal(stage string, n int) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stage(stage).Total += n
+}
+
+// Done marks one item of a stage as done
+func (t *Tracker) Done(stage string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stage(stage).Done++
+}
+
+func (t *Tracker) Fetched() {
+	t.count(func(c *Counts) { c.Fetched++ })
+}
+
+func (t *Tracker) Inserted(n int) {
+	t.count(func(c *Counts) { c.Inserted += n })
+}
+
+func (t *Tracker) Skipped() {
+	t.count(func(c *Counts) { c.Skipped++ })
+}
+
+func (t *Tracker) Failed() {
+	t.count(func(c *Counts) { c.Failed++ })
+}
+
+func (t *Tracker) CacheHit() {
+	t.count(func(c *Counts) { c.CacheHits++ })
+}
+
+// Snapshot returns a copy of the tracker's state
+func (t *Tracker) Snapshot() Snapshot {
+	if t == nil {
+		return Snapshot{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return Snapshot{
+		Elapsed: t.now().Sub(t.start),
+		Stages:  append([]Stage(nil), t.stages...),
+		Counts:  t.counts,
+	}
+}
+
+func (t *Tracker) count(f func(*Counts)) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	f(&t.counts)
+}
+
+// stage returns a stage by name, adding it when it's new. t.mu must be held.
+func (t *Tracker) stage(name string) *Stage {
+	for i := range t.stages {
+		if t.stages[i].Name == name {
+			return &t.stages[i]
+		}
+	}
+	t.stages = append(t.stages, Stage{Name: name})
+	return &t.stages[len(t.stages)-1]
+}
@@ -0,0 +1,61 @@
+package progress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fixedTracker is a tracker that is always elapsed into its sync
+func fixedTracker(elapsed time.Duration) *Tracker {
+	t := NewTracker()
+	t.now = func() time.Time { return t.start.Add(elapsed) }
+	return t
+}
+
+func TestTracker(t *testing.T) {
+	tracker := fixedTracker(time.Minute)
+	tracker.AddTotal(Versions, 2)
+	tracker.AddTotal(Pokemon, 3)
+	tracker.AddTotal(Pokemon, 3)
+	tracker.Done(Versions)
+	tracker.Done(Pokemon)
+	tracker.Done(Pokemon)
+	tracker.Fetched()
+	tracker.Fetched()
+	tracker.Inserted(5)
+	tracker.Skipped()
+	tracker.Failed()
+	tracker.CacheHit()
+
+	s := tracker.Snapshot()
+	assert.Equal(t, []Stage{
+		{Name: Versions, Done: 1, Total: 2},
+		{Name: Pokemon, Done: 2, Total: 6},
+	}, s.Stages)
+	assert.Equal(t, Counts{Fetched: 2, Inserted: 5, Skipped: 1, Failed: 1, CacheHits: 1}, s.Counts)
+	assert.InDelta(t, 3.0/8, s.Fraction(), 1e-9)
+
+	// A minute for 3 of 8, so 5 more take 1m40s
+	eta, ok := s.ETA()
+	assert.True(t, ok)
+	assert.Equal(t, 100*time.Second, eta.Round(time.Second))
+}
+
+func TestTrackerWithoutWork(t *testing.T) {
+	s := fixedTracker(time.Second).Snapshot()
+	assert.Zero(t, s.Fraction())
+	_, ok := s.ETA()
+	assert.False(t, ok)
+}
+
+func TestNilTracker(t *testing.T) {
+	var tracker *Tracker
+	tracker.AddTotal(Moves, 1)
+	tracker.Done(Moves)
+	tracker.Fetched()
+	tracker.Inserted(1)
+	tracker.CacheHit()
+	assert.Equal(t, Snapshot{}, tracker.Snapshot())
+}
@@ -65,3 +65,321 @@ var GetMoveByID string
 
 //go:embed sql/version/get_version.sql
 var GetVersionByID string
+
+//go:embed sql/move/move_past_values.sql
+var InsertMovePastValue string
+
+//go:embed sql/move/get_move_for_version_group.sql
+var GetMoveForVersionGroup string
+
+//go:embed sql/move/get_pokemon_moves.sql
+var GetPokemonMoves string
+
+//go:embed sql/move/move_stat_change.sql
+var InsertMoveStatChange string
+
+//go:embed sql/move/get_move_stat_changes.sql
+var GetMoveStatChanges string
+
+//go:embed sql/pokemon/pokemon_form.sql
+var InsertPokemonForm string
+
+//go:embed sql/pokemon/pokemon_form_version_group.sql
+var InsertPokemonFormVersionGroup string
+
+//go:embed sql/pokemon/get_species_forms.sql
+var GetSpeciesForms string
+
+//go:embed sql/encounter/location.sql
+var InsertLocation string
+
+//go:embed sql/encounter/location_area.sql
+var InsertLocationArea string
+
+//go:embed sql/encounter/encounter.sql
+var InsertEncounter string
+
+//go:embed sql/encounter/get_encounters.sql
+var GetEncounters string
+
+//go:embed sql/encounter/get_location_encounters.sql
+var GetLocationEncounters string
+
+//go:embed sql/item/item.sql
+var InsertItem string
+
+//go:embed sql/item/machine.sql
+var InsertMachine string
+
+//go:embed sql/item/get_item.sql
+var GetItemByName string
+
+//go:embed sql/item/get_machines.sql
+var GetMachinesByVersionGroup string
+
+//go:embed sql/nature/nature.sql
+var InsertNature string
+
+//go:embed sql/nature/characteristic.sql
+var InsertCharacteristic string
+
+//go:embed sql/nature/get_nature.sql
+var GetNatureByName string
+
+//go:embed sql/nature/get_characteristic.sql
+var GetCharacteristicByID string
+
+//go:embed sql/version/get_version_group.sql
+var GetVersionGroupByID string
+
+//go:embed sql/type/type.sql
+var InsertTypeDamageClass string
+
+//go:embed sql/type/type_name.sql
+var InsertTypeName string
+
+//go:embed sql/type/type_effectiveness.sql
+var InsertTypeEffectiveness string
+
+//go:embed sql/type/type_effectiveness_past.sql
+var InsertTypeEffectivenessPast string
+
+//go:embed sql/type/get_type_damage_classes.sql
+var GetTypeDamageClasses string
+
+//go:embed sql/type/get_type_effectiveness.sql
+var GetTypeEffectiveness string
+
+//go:embed sql/type/get_type_effectiveness_past.sql
+var GetTypeEffectivenessPast string
+
+//go:embed sql/pokemon/pokemon_past_type.sql
+var InsertPokemonPastType string
+
+//go:embed sql/pokemon/get_pokemon_types.sql
+var GetPokemonTypes string
+
+//go:embed sql/user/user.sql
+var InsertUser string
+
+//go:embed sql/user/get_user_by_username.sql
+var GetUserByUsername string
+
+//go:embed sql/user/session.sql
+var InsertSession string
+
+//go:embed sql/user/get_session_user.sql
+var GetSessionUser string
+
+//go:embed sql/user/delete_session.sql
+var DeleteSession string
+
+//go:embed sql/user/delete_expired_sessions.sql
+var DeleteExpiredSessions string
+
+//go:embed sql/playthrough/playthrough.sql
+var InsertPlaythrough string
+
+//go:embed sql/playthrough/get_playthroughs.sql
+var GetPlaythroughs string
+
+//go:embed sql/playthrough/get_playthrough.sql
+var GetPlaythrough string
+
+//go:embed sql/playthrough/update_playthrough.sql
+var UpdatePlaythrough string
+
+//go:embed sql/playthrough/touch_playthrough.sql
+var TouchPlaythrough string
+
+//go:embed sql/playthrough/delete_playthrough.sql
+var DeletePlaythrough string
+
+//go:embed sql/playthrough/playthrough_species.sql
+var InsertPlaythroughSpecies string
+
+//go:embed sql/playthrough/get_playthrough_species.sql
+var GetPlaythroughSpecies string
+
+//go:embed sql/playthrough/delete_playthrough_species.sql
+var DeletePlaythroughSpecies string
+
+//go:embed sql/playthrough/delete_all_playthrough_species.sql
+var DeleteAllPlaythroughSpecies string
+
+//go:embed sql/playthrough/party_member.sql
+var InsertPartyMember string
+
+//go:embed sql/playthrough/get_party.sql
+var GetParty string
+
+//go:embed sql/playthrough/delete_party.sql
+var DeleteParty string
+
+//go:embed sql/pokedex/get_pokedex_entries.sql
+var GetPokedexEntries string
+
+//go:embed sql/pokedex/get_version_group_pokedexes.sql
+var GetVersionGroupPokedexes string
+
+//go:embed sql/encounter/get_species_locations.sql
+var GetSpeciesLocations string
+
+//go:embed sql/living_dex/living_dex_entry.sql
+var InsertLivingDexEntry string
+
+//go:embed sql/living_dex/delete_living_dex_entry.sql
+var DeleteLivingDexEntry string
+
+//go:embed sql/living_dex/get_living_dex_entries.sql
+var GetLivingDexEntries string
+
+//go:embed sql/encounter/get_version_exclusives.sql
+var GetVersionExclusives string
+
+//go:embed sql/encounter/get_location.sql
+var GetLocationByID string
+
+//go:embed sql/pokemon/get_species.sql
+var GetSpeciesByID string
+
+//go:embed sql/nuzlocke/nuzlocke_run.sql
+var InsertNuzlockeRun string
+
+//go:embed sql/nuzlocke/get_nuzlocke_runs.sql
+var GetNuzlockeRuns string
+
+//go:embed sql/nuzlocke/get_nuzlocke_run.sql
+var GetNuzlockeRun string
+
+//go:embed sql/nuzlocke/delete_nuzlocke_run.sql
+var DeleteNuzlockeRun string
+
+//go:embed sql/nuzlocke/touch_nuzlocke_run.sql
+var TouchNuzlockeRun string
+
+//go:embed sql/nuzlocke/nuzlocke_encounter.sql
+var InsertNuzlockeEncounter string
+
+//go:embed sql/nuzlocke/get_nuzlocke_encounters.sql
+var GetNuzlockeEncounters string
+
+//go:embed sql/nuzlocke/update_nuzlocke_encounter.sql
+var UpdateNuzlockeEncounter string
+
+//go:embed sql/nuzlocke/delete_nuzlocke_encounter.sql
+var DeleteNuzlockeEncounter string
+
+//go:embed sql/nuzlocke/delete_all_nuzlocke_encounters.sql
+var DeleteAllNuzlockeEncounters string
+
+//go:embed sql/pokemon/species_egg_group.sql
+var InsertSpeciesEggGroup string
+
+//go:embed sql/pokemon/get_breeding_candidates.sql
+var GetBreedingCandidates string
+
+//go:embed sql/version/get_version_group_versions.sql
+var GetVersionGroupVersions string
+
+//go:embed sql/trainer/trainer.sql
+var InsertTrainer string
+
+//go:embed sql/trainer/trainer_pokemon.sql
+var InsertTrainerPokemon string
+
+//go:embed sql/trainer/delete_trainer.sql
+var DeleteTrainer string
+
+//go:embed sql/trainer/delete_trainer_pokemon.sql
+var DeleteTrainerPokemon string
+
+//go:embed sql/trainer/get_trainers.sql
+var GetTrainers string
+
+//go:embed sql/trainer/get_trainer_pokemon.sql
+var GetTrainerPokemon string
+
+//go:embed sql/trainer/get_trainer.sql
+var GetTrainerByID string
+
+//go:embed sql/version/version_metadata.sql
+var InsertVersionMetadata string
+
+//go:embed sql/version/version_release_date.sql
+var InsertVersionReleaseDate string
+
+//go:embed sql/version/version_media.sql
+var InsertVersionMedia string
+
+//go:embed sql/version/delete_version_metadata.sql
+var DeleteVersionMetadata string
+
+//go:embed sql/version/delete_version_release_dates.sql
+var DeleteVersionReleaseDates string
+
+//go:embed sql/version/delete_version_media.sql
+var DeleteVersionMedia string
+
+//go:embed sql/version/get_version_metadata.sql
+var GetVersionMetadata string
+
+//go:embed sql/version/get_version_release_dates.sql
+var GetVersionReleaseDates string
+
+//go:embed sql/version/get_version_media.sql
+var GetVersionMedia string
+
+//go:embed sql/version/get_versions.sql
+var GetVersions string
+
+//go:embed sql/version/game_mapping.sql
+var InsertGameMapping string
+
+//go:embed sql/version/get_game_mappings.sql
+var GetGameMappings string
+
+//go:embed sql/asset/asset.sql
+var InsertAsset string
+
+//go:embed sql/asset/delete_asset.sql
+var DeleteAsset string
+
+//go:embed sql/asset/get_asset.sql
+var GetAsset string
+
+//go:embed sql/asset/get_asset_by_source_url.sql
+var GetAssetBySourceURL string
+
+//go:embed sql/pokemon/pokemon_sprite.sql
+var InsertPokemonSprite string
+
+//go:embed sql/pokemon/delete_pokemon_sprites.sql
+var DeletePokemonSprites string
+
+//go:embed sql/pokemon/get_pokemon_sprites.sql
+var GetPokemonSprites string
+
+//go:embed sql/sync_run/sync_run.sql
+var InsertSyncRun string
+
+//go:embed sql/sync_run/finish_sync_run.sql
+var FinishSyncRun string
+
+//go:embed sql/sync_run/get_latest_sync_run.sql
+var GetLatestSyncRun string
+
+//go:embed sql/sync_failure/sync_failure.sql
+var InsertSyncFailure string
+
+//go:embed sql/sync_failure/get_sync_failures.sql
+var GetSyncFailures string
+
+//go:embed sql/sync_failure/delete_sync_failure.sql
+var DeleteSyncFailure string
+
+//go:embed sql/sync_failure/delete_sync_failures.sql
+var DeleteSyncFailures string
+
+//go:embed sql/encounter/get_version_regions.sql
+var GetVersionRegions string
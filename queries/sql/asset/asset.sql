@@ -0,0 +1,2 @@
+INSERT INTO assets (hash, width, height, is_original, format, path, source_url)
+VALUES (?, ?, ?, ?, ?, ?, ?)
@@ -0,0 +1,2 @@
+DELETE FROM assets
+WHERE hash = ?
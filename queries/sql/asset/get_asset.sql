@@ -0,0 +1,4 @@
+SELECT hash, width, height, is_original, format, path, source_url
+FROM assets
+WHERE hash = ?
+ORDER BY is_original DESC, width
@@ -0,0 +1,4 @@
+SELECT hash
+FROM assets
+WHERE source_url = ?
+  AND is_original = 1
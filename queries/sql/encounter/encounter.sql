@@ -0,0 +1,4 @@
+INSERT INTO encounters (pokemon_id, location_area_id, version_id, method, min_level, max_level, chance, conditions)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(pokemon_id, location_area_id, version_id, method, min_level, max_level, conditions) DO UPDATE SET
+    chance = excluded.chance
@@ -0,0 +1,7 @@
+SELECT
+    id,
+    name,
+    COALESCE(region_name, ''),
+    COALESCE(NULLIF(display_name, ''), name)
+FROM locations
+WHERE id = ?
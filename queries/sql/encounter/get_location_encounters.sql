@@ -0,0 +1,21 @@
+SELECT
+    e.pokemon_id,
+    p.name,
+    e.location_area_id,
+    la.name,
+    COALESCE(la.display_name, ''),
+    l.id,
+    l.name,
+    COALESCE(l.display_name, ''),
+    e.version_id,
+    e.method,
+    e.min_level,
+    e.max_level,
+    e.chance,
+    e.conditions
+FROM encounters e
+JOIN pokemon p ON p.id = e.pokemon_id
+JOIN location_areas la ON la.id = e.location_area_id
+JOIN locations l ON l.id = la.location_id
+WHERE e.location_area_id = ? AND e.version_id = ?
+ORDER BY e.method, e.chance DESC, p.name
@@ -0,0 +1,16 @@
+-- Every location a species can be encountered at in the versions of a version group
+SELECT DISTINCT
+    s.id,
+    s.name,
+    COALESCE(s.evolution_chain_id, 0),
+    v.id,
+    v.name,
+    COALESCE(NULLIF(l.display_name, ''), l.name)
+FROM encounters e
+JOIN pokemon p ON p.id = e.pokemon_id
+JOIN species s ON s.id = p.species_id
+JOIN versions v ON v.id = e.version_id
+JOIN location_areas la ON la.id = e.location_area_id
+JOIN locations l ON l.id = la.location_id
+WHERE v.version_group_id = ?
+ORDER BY s.id, v.id, 6
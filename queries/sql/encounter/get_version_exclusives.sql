@@ -0,0 +1,43 @@
+-- Species that can only be encountered in one version of a version group.
+-- A species counts as available wherever a member of its evolution chain is
+-- encountered, since it can be evolved or bred from it. Only species in the
+-- version group's pokedexes are carried along the chain, so chain members of
+-- later generations don't show up. Versions without any encounter data are
+-- ignored, otherwise everything in the synced version would look exclusive.
+-- Versions without exclusives get a single row with a NULL species.
+WITH encountered AS (
+    SELECT DISTINCT p.species_id, e.version_id
+    FROM encounters e
+    JOIN pokemon p ON p.id = e.pokemon_id
+    JOIN versions v ON v.id = e.version_id
+    WHERE v.version_group_id = ?1
+),
+available AS (
+    SELECT species_id, version_id FROM encountered
+    UNION
+    SELECT s.id, en.version_id
+    FROM encountered en
+    JOIN species es ON es.id = en.species_id
+    JOIN species s ON s.evolution_chain_id = es.evolution_chain_id
+    JOIN pokedex_entries pe ON pe.species_id = s.id
+    JOIN version_group_pokedexes vgp ON vgp.pokedex_id = pe.pokedex_id
+    WHERE vgp.version_group_id = ?1
+),
+exclusive AS (
+    SELECT species_id
+    FROM available
+    GROUP BY species_id
+    HAVING COUNT(*) = 1 AND (SELECT COUNT(DISTINCT version_id) FROM available) > 1
+)
+SELECT
+    v.id,
+    v.name,
+    COALESCE(v.display_name, v.name),
+    v.version_group_id,
+    s.id,
+    s.name
+FROM versions v
+LEFT JOIN available a ON a.version_id = v.id AND a.species_id IN (SELECT species_id FROM exclusive)
+LEFT JOIN species s ON s.id = a.species_id
+WHERE v.version_group_id = ?1
+ORDER BY v.id, s.id
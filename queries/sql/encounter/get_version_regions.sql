@@ -0,0 +1,15 @@
+SELECT region_name FROM (
+    SELECT p.region_name
+    FROM versions v
+    JOIN version_group_pokedexes vgp ON vgp.version_group_id = v.version_group_id
+    JOIN pokedexes p ON p.id = vgp.pokedex_id
+    WHERE v.id = ?
+    UNION
+    SELECT l.region_name
+    FROM encounters e
+    JOIN location_areas la ON la.id = e.location_area_id
+    JOIN locations l ON l.id = la.location_id
+    WHERE e.version_id = ?
+)
+WHERE region_name IS NOT NULL AND region_name != ''
+ORDER BY region_name
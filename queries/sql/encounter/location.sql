@@ -0,0 +1,2 @@
+INSERT OR IGNORE INTO locations (id, name, region_name, display_name)
+VALUES (?, ?, ?, ?)
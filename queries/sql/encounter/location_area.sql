@@ -0,0 +1,2 @@
+INSERT OR IGNORE INTO location_areas (id, name, location_id, display_name)
+VALUES (?, ?, ?, ?)
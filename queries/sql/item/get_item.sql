@@ -0,0 +1,12 @@
+SELECT
+    id,
+    name,
+    display_name,
+    category,
+    cost,
+    fling_power,
+    effect_short,
+    effect_full,
+    sprite
+FROM items
+WHERE name = ?
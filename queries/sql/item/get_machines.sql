@@ -0,0 +1,9 @@
+SELECT
+    UPPER(i.name),
+    m.move_id,
+    mv.name
+FROM machines m
+JOIN items i ON i.id = m.item_id
+JOIN moves mv ON mv.id = m.move_id
+WHERE m.version_group_id = ?
+ORDER BY i.name
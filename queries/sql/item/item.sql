@@ -0,0 +1,2 @@
+INSERT OR IGNORE INTO items (id, name, display_name, category, cost, fling_power, effect_short, effect_full, sprite)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
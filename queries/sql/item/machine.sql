@@ -0,0 +1,2 @@
+INSERT OR IGNORE INTO machines (id, item_id, move_id, version_group_id)
+VALUES (?, ?, ?, ?)
@@ -0,0 +1,4 @@
+DELETE FROM living_dex_entries
+WHERE user_id = ?
+  AND pokedex_id = ?
+  AND species_id = ?
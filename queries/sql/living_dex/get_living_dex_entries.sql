@@ -0,0 +1,5 @@
+SELECT species_id
+FROM living_dex_entries
+WHERE user_id = ?
+  AND pokedex_id = ?
+ORDER BY species_id
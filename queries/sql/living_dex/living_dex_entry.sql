@@ -0,0 +1,2 @@
+INSERT OR IGNORE INTO living_dex_entries (user_id, pokedex_id, species_id, caught_at)
+VALUES (?, ?, ?, ?)
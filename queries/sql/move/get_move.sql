@@ -1,2 +1,28 @@
-SELECT * FROM moves
+SELECT
+    id,
+    name,
+    type_name,
+    COALESCE(power, 0),
+    COALESCE(accuracy, 0),
+    pp,
+    damage_class,
+    effect_short,
+    priority,
+    effect_chance,
+    effect_full,
+    target,
+    meta_category,
+    ailment,
+    ailment_chance,
+    crit_rate,
+    drain,
+    healing,
+    flinch_chance,
+    stat_chance,
+    min_hits,
+    max_hits,
+    min_turns,
+    max_turns,
+    contest_type
+FROM moves
 WHERE id = ?
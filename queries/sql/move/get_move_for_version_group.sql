@@ -0,0 +1,29 @@
+SELECT
+    m.id,
+    m.name,
+    s.type_name,
+    COALESCE(s.power, 0),
+    COALESCE(s.accuracy, 0),
+    s.pp,
+    m.damage_class,
+    m.effect_short,
+    m.priority,
+    s.effect_chance,
+    m.effect_full,
+    m.target,
+    m.meta_category,
+    m.ailment,
+    m.ailment_chance,
+    m.crit_rate,
+    m.drain,
+    m.healing,
+    m.flinch_chance,
+    m.stat_chance,
+    m.min_hits,
+    m.max_hits,
+    m.min_turns,
+    m.max_turns,
+    m.contest_type
+FROM moves m
+JOIN move_stats_by_version_group s ON s.move_id = m.id
+WHERE m.id = ? AND s.version_group_id = ?
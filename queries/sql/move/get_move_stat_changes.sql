@@ -0,0 +1,6 @@
+SELECT
+    stat_name,
+    change
+FROM move_stat_changes
+WHERE move_id = ?
+ORDER BY stat_name
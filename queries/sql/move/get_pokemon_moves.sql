@@ -0,0 +1,24 @@
+SELECT
+    m.id,
+    m.name,
+    s.type_name,
+    COALESCE(s.power, 0),
+    COALESCE(s.accuracy, 0),
+    s.pp,
+    m.damage_class,
+    COALESCE(m.effect_short, ''),
+    m.priority,
+    s.effect_chance,
+    pm.learn_method,
+    pm.level_learned_at,
+    COALESCE((
+        SELECT UPPER(i.name) FROM machines mc
+        JOIN items i ON i.id = mc.item_id
+        WHERE pm.learn_method = 'machine' AND mc.move_id = pm.move_id AND mc.version_group_id = pm.version_group_id
+        ORDER BY i.name LIMIT 1
+    ), '')
+FROM pokemon_moves pm
+JOIN moves m ON m.id = pm.move_id
+JOIN move_stats_by_version_group s ON s.move_id = pm.move_id AND s.version_group_id = pm.version_group_id
+WHERE pm.pokemon_id = ? AND pm.version_group_id = ?
+ORDER BY pm.learn_method, pm.level_learned_at, m.name
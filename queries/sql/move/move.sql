@@ -1,2 +1,30 @@
-INSERT OR IGNORE INTO moves (id, name, type_name, power, accuracy, pp, damage_class, effect_short, priority)
-VALUES (?,?,?,?,?,?,?,?,?)
+INSERT OR IGNORE INTO moves (
+    id,
+    name,
+    type_name,
+    power,
+    accuracy,
+    pp,
+    damage_class,
+    effect_short,
+    priority,
+    effect_chance,
+    effect_full,
+    target,
+    meta_category,
+    ailment,
+    ailment_chance,
+    crit_rate,
+    drain,
+    healing,
+    flinch_chance,
+    stat_chance,
+    min_hits,
+    max_hits,
+    min_turns,
+    max_turns,
+    contest_type,
+    contest_effect_id,
+    super_contest_effect_id
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
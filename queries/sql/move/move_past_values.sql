@@ -0,0 +1,2 @@
+INSERT OR IGNORE INTO move_past_values (move_id, version_group_id, version_group_order, type_name, power, accuracy, pp, effect_chance)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
@@ -0,0 +1,2 @@
+INSERT OR IGNORE INTO move_stat_changes (move_id, stat_name, change)
+VALUES (?, ?, ?)
@@ -0,0 +1,2 @@
+INSERT OR IGNORE INTO characteristics (id, highest_stat, gene_modulo, possible_values, description)
+VALUES (?, ?, ?, ?, ?)
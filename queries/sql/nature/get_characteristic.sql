@@ -0,0 +1,8 @@
+SELECT
+    id,
+    highest_stat,
+    gene_modulo,
+    possible_values,
+    COALESCE(description, '')
+FROM characteristics
+WHERE id = ?
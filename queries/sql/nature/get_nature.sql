@@ -0,0 +1,7 @@
+SELECT
+    id,
+    name,
+    COALESCE(increased_stat, ''),
+    COALESCE(decreased_stat, '')
+FROM natures
+WHERE name = ?
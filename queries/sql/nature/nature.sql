@@ -0,0 +1,2 @@
+INSERT OR IGNORE INTO natures (id, name, increased_stat, decreased_stat, likes_flavor, hates_flavor)
+VALUES (?, ?, ?, ?, ?, ?)
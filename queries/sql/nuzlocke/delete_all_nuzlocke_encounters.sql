@@ -0,0 +1,2 @@
+DELETE FROM nuzlocke_encounters
+WHERE run_id = ?
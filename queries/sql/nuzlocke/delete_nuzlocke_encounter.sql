@@ -0,0 +1,3 @@
+DELETE FROM nuzlocke_encounters
+WHERE id = ?
+  AND run_id = ?
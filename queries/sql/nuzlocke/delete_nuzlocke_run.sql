@@ -0,0 +1,3 @@
+DELETE FROM nuzlocke_runs
+WHERE id = ?
+  AND user_id = ?
@@ -0,0 +1,13 @@
+SELECT
+    id,
+    user_id,
+    version_id,
+    name,
+    dupes_clause,
+    shiny_clause,
+    gift_clause,
+    created_at,
+    updated_at
+FROM nuzlocke_runs
+WHERE user_id = ?
+ORDER BY updated_at DESC, id DESC
@@ -0,0 +1,5 @@
+INSERT INTO nuzlocke_encounters (
+    run_id, location_id, location_name, species_id, species_name, evolution_chain_id,
+    nickname, level, status, cause_of_death, is_shiny, is_gift, encountered_at, died_at
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
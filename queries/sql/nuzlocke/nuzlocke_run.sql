@@ -0,0 +1,2 @@
+INSERT INTO nuzlocke_runs (user_id, version_id, name, dupes_clause, shiny_clause, gift_clause, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
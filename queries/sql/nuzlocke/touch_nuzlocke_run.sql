@@ -0,0 +1,3 @@
+UPDATE nuzlocke_runs
+SET updated_at = ?
+WHERE id = ?
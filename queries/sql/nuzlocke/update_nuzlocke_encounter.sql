@@ -0,0 +1,8 @@
+UPDATE nuzlocke_encounters
+SET nickname = ?,
+    level = ?,
+    status = ?,
+    cause_of_death = ?,
+    died_at = ?
+WHERE id = ?
+  AND run_id = ?
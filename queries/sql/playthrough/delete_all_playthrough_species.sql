@@ -0,0 +1,2 @@
+DELETE FROM playthrough_species
+WHERE playthrough_id = ?
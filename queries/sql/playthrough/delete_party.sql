@@ -0,0 +1,2 @@
+DELETE FROM playthrough_party
+WHERE playthrough_id = ?
@@ -0,0 +1,3 @@
+DELETE FROM playthroughs
+WHERE id = ?
+  AND user_id = ?
@@ -0,0 +1,3 @@
+DELETE FROM playthrough_species
+WHERE playthrough_id = ?
+  AND species_id = ?
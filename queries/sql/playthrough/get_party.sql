@@ -0,0 +1,8 @@
+SELECT
+    slot,
+    pokemon_id,
+    nickname,
+    level
+FROM playthrough_party
+WHERE playthrough_id = ?
+ORDER BY slot
@@ -0,0 +1,13 @@
+SELECT
+    p.id,
+    p.user_id,
+    p.version_id,
+    p.name,
+    p.notes,
+    p.created_at,
+    p.updated_at,
+    (SELECT COUNT(*) FROM playthrough_species s WHERE s.playthrough_id = p.id AND s.status = 'caught'),
+    (SELECT COUNT(*) FROM playthrough_species s WHERE s.playthrough_id = p.id AND s.status = 'seen')
+FROM playthroughs p
+WHERE p.id = ?
+  AND p.user_id = ?
@@ -0,0 +1,6 @@
+SELECT
+    species_id,
+    status
+FROM playthrough_species
+WHERE playthrough_id = ?
+ORDER BY species_id
@@ -0,0 +1,2 @@
+INSERT INTO playthrough_party (playthrough_id, slot, pokemon_id, nickname, level)
+VALUES (?, ?, ?, ?, ?)
@@ -0,0 +1,2 @@
+INSERT INTO playthroughs (user_id, version_id, name, notes, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?)
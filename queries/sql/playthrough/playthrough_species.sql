@@ -0,0 +1,3 @@
+INSERT INTO playthrough_species (playthrough_id, species_id, status)
+VALUES (?, ?, ?)
+ON CONFLICT(playthrough_id, species_id) DO UPDATE SET status = excluded.status
@@ -0,0 +1,3 @@
+UPDATE playthroughs
+SET updated_at = ?
+WHERE id = ?
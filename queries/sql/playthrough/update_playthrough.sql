@@ -0,0 +1,6 @@
+UPDATE playthroughs
+SET name = ?,
+    notes = ?,
+    updated_at = ?
+WHERE id = ?
+  AND user_id = ?
@@ -1,5 +1,6 @@
 SELECT
     p.id,
-    p.region_name,
-    p.name
+    p.name,
+    COALESCE(p.region_name, '')
 FROM pokedexes p
+WHERE p.id = ?
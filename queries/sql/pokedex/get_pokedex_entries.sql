@@ -0,0 +1,9 @@
+SELECT
+    pe.entry_number,
+    s.name,
+    s.id,
+    COALESCE(s.evolution_chain_id, 0)
+FROM pokedex_entries pe
+JOIN species s ON s.id = pe.species_id
+WHERE pe.pokedex_id = ?
+ORDER BY pe.entry_number, s.id
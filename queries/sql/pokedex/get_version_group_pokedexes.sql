@@ -0,0 +1,8 @@
+SELECT
+    p.id,
+    p.name,
+    COALESCE(p.region_name, '')
+FROM version_group_pokedexes vgp
+JOIN pokedexes p ON p.id = vgp.pokedex_id
+WHERE vgp.version_group_id = ?
+ORDER BY p.id
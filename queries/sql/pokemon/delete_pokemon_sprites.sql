@@ -0,0 +1 @@
+DELETE FROM pokemon_sprites WHERE pokemon_id = ?
@@ -0,0 +1,17 @@
+-- Species whose default pokemon learn a move by level-up or as an egg move
+-- in a version group, with what breeding needs to know about them
+SELECT
+    s.id,
+    s.name,
+    COALESCE(s.gender_rate, -1),
+    COALESCE((SELECT GROUP_CONCAT(eg.egg_group) FROM species_egg_groups eg WHERE eg.species_id = s.id), ''),
+    MIN(CASE WHEN pm.learn_method = 'level-up' THEN pm.level_learned_at END),
+    MAX(pm.learn_method = 'egg')
+FROM pokemon_moves pm
+JOIN pokemon p ON p.id = pm.pokemon_id AND p.is_default
+JOIN species s ON s.id = p.species_id
+WHERE pm.move_id = ?
+  AND pm.version_group_id = ?
+  AND pm.learn_method IN ('level-up', 'egg')
+GROUP BY s.id
+ORDER BY s.id
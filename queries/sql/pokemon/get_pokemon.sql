@@ -14,6 +14,7 @@ SELECT
     p.speed,
     p.sprite_front_default,
     p.sprite_front_shiny,
-    p.sprite_artwork
+    p.sprite_artwork,
+    p.sprite_artwork_shiny
 FROM pokemon p
 WHERE p.id = ?;
@@ -0,0 +1,13 @@
+SELECT
+    pokemon_id,
+    generation,
+    version_group,
+    sprite_set,
+    side,
+    shiny,
+    female,
+    animated,
+    path
+FROM pokemon_sprites
+WHERE pokemon_id = ? AND version_group = ?
+ORDER BY sprite_set, side DESC, animated, shiny, female
@@ -0,0 +1,22 @@
+-- Types in the given generation: the earliest past types still valid in it,
+-- otherwise the current types
+SELECT type_name, slot
+FROM pokemon_past_types
+WHERE pokemon_id = ?1
+  AND generation_id = (
+      SELECT MIN(generation_id)
+      FROM pokemon_past_types
+      WHERE pokemon_id = ?1
+        AND generation_id >= ?2
+  )
+UNION ALL
+SELECT type_name, slot
+FROM pokemon_types
+WHERE pokemon_id = ?1
+  AND NOT EXISTS (
+      SELECT 1
+      FROM pokemon_past_types
+      WHERE pokemon_id = ?1
+        AND generation_id >= ?2
+  )
+ORDER BY slot
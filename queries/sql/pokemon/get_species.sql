@@ -0,0 +1,6 @@
+SELECT
+    id,
+    name,
+    COALESCE(evolution_chain_id, 0)
+FROM species
+WHERE id = ?
@@ -0,0 +1,14 @@
+SELECT
+    f.id,
+    f.pokemon_id,
+    f.name,
+    f.form_name,
+    f.is_default,
+    p.is_default,
+    f.is_battle_only,
+    f.is_mega
+FROM pokemon_forms f
+JOIN pokemon p ON p.id = f.pokemon_id
+JOIN pokemon_form_version_groups fvg ON fvg.pokemon_form_id = f.id
+WHERE p.species_id = ? AND fvg.version_group_id = ?
+ORDER BY p.is_default DESC, p.id, f.form_order
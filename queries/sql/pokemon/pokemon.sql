@@ -14,6 +14,7 @@ INSERT OR IGNORE INTO pokemon (
     speed,
     sprite_front_default,
     sprite_front_shiny,
-    sprite_artwork
+    sprite_artwork,
+    sprite_artwork_shiny
  )
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
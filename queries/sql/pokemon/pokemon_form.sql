@@ -0,0 +1,12 @@
+INSERT OR IGNORE INTO pokemon_forms (
+    id,
+    pokemon_id,
+    name,
+    form_name,
+    form_order,
+    is_default,
+    is_battle_only,
+    is_mega,
+    introduced_version_group_id
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
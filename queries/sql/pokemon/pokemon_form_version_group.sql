@@ -0,0 +1,2 @@
+INSERT OR IGNORE INTO pokemon_form_version_groups (pokemon_form_id, version_group_id)
+VALUES (?, ?)
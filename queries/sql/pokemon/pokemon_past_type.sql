@@ -0,0 +1,2 @@
+INSERT OR IGNORE INTO pokemon_past_types (pokemon_id, generation_id, type_name, slot)
+VALUES (?, ?, ?, ?)
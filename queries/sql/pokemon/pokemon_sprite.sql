@@ -0,0 +1,12 @@
+INSERT INTO pokemon_sprites (
+    pokemon_id,
+    generation,
+    version_group,
+    sprite_set,
+    side,
+    shiny,
+    female,
+    animated,
+    path
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
@@ -9,6 +9,8 @@ INSERT OR IGNORE INTO species (
     is_legendary,
     is_mythical,
     growth_rate_name,
-    generation_name
+    generation_name,
+    hatch_counter,
+    has_gender_differences
 )
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
\ No newline at end of file
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
\ No newline at end of file
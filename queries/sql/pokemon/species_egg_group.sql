@@ -0,0 +1,2 @@
+INSERT OR IGNORE INTO species_egg_groups (species_id, egg_group)
+VALUES (?, ?)
@@ -0,0 +1,2 @@
+DELETE FROM sync_failures
+WHERE resource = ? AND resource_id = ? AND version_id = ?
@@ -0,0 +1 @@
+DELETE FROM sync_failures
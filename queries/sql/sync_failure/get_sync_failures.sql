@@ -0,0 +1,9 @@
+SELECT
+    resource,
+    resource_id,
+    version_id,
+    error,
+    attempts,
+    failed_at
+FROM sync_failures
+ORDER BY failed_at, resource, resource_id, version_id
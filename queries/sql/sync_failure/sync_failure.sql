@@ -0,0 +1,6 @@
+INSERT INTO sync_failures (resource, resource_id, version_id, error, failed_at)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (resource, resource_id, version_id) DO UPDATE SET
+    error = excluded.error,
+    attempts = attempts + 1,
+    failed_at = excluded.failed_at
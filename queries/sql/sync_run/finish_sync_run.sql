@@ -0,0 +1,11 @@
+UPDATE sync_runs
+SET status = ?,
+    error = ?,
+    finished_at = ?,
+    duration_ms = ?,
+    fetched = ?,
+    inserted = ?,
+    skipped = ?,
+    failed = ?,
+    cache_hits = ?
+WHERE id = ?
@@ -0,0 +1,16 @@
+SELECT
+    id,
+    command,
+    status,
+    error,
+    started_at,
+    finished_at,
+    duration_ms,
+    fetched,
+    inserted,
+    skipped,
+    failed,
+    cache_hits
+FROM sync_runs
+ORDER BY id DESC
+LIMIT 1
@@ -0,0 +1,2 @@
+INSERT INTO sync_runs (command, status, started_at)
+VALUES (?, ?, ?)
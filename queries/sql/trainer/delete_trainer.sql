@@ -0,0 +1,4 @@
+DELETE FROM trainers
+WHERE name = ?
+  AND version_id = ?
+  AND battle = ?
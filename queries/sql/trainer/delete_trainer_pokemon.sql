@@ -0,0 +1,4 @@
+DELETE FROM trainer_pokemon
+WHERE trainer_id IN (
+    SELECT id FROM trainers WHERE name = ? AND version_id = ? AND battle = ?
+)
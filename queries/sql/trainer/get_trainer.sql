@@ -0,0 +1,13 @@
+SELECT
+    id,
+    name,
+    role,
+    version_id,
+    battle,
+    battle_order,
+    location,
+    badge,
+    type_specialty,
+    source_url
+FROM trainers
+WHERE id = ?
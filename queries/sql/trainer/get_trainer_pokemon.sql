@@ -0,0 +1,11 @@
+SELECT
+    tp.slot,
+    tp.species_id,
+    s.name,
+    tp.level,
+    tp.held_item,
+    tp.moves
+FROM trainer_pokemon tp
+JOIN species s ON s.id = tp.species_id
+WHERE tp.trainer_id = ?
+ORDER BY tp.slot
@@ -0,0 +1,14 @@
+SELECT
+    id,
+    name,
+    role,
+    version_id,
+    battle,
+    battle_order,
+    location,
+    badge,
+    type_specialty,
+    source_url
+FROM trainers
+WHERE version_id = ?
+ORDER BY role = 'elite-four', battle_order, battle
@@ -0,0 +1,2 @@
+INSERT INTO trainers (name, role, version_id, battle, battle_order, location, badge, type_specialty, source_url)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
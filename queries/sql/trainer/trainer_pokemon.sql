@@ -0,0 +1,5 @@
+-- Inserts nothing when the species is unknown
+INSERT INTO trainer_pokemon (trainer_id, slot, species_id, level, held_item, moves)
+SELECT ?, ?, id, ?, ?, ?
+FROM species
+WHERE name = ?
@@ -0,0 +1,4 @@
+SELECT
+    name,
+    COALESCE(damage_class, '')
+FROM types
@@ -0,0 +1,5 @@
+SELECT
+    attacking_type,
+    defending_type,
+    multiplier
+FROM type_effectiveness
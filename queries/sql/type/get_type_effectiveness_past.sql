@@ -0,0 +1,13 @@
+-- For every attacking type only the earliest past chart still valid in the
+-- given generation applies, later generations use type_effectiveness
+SELECT
+    p.attacking_type,
+    p.defending_type,
+    p.multiplier
+FROM type_effectiveness_past p
+WHERE p.generation_id = (
+    SELECT MIN(generation_id)
+    FROM type_effectiveness_past
+    WHERE attacking_type = p.attacking_type
+      AND generation_id >= ?
+)
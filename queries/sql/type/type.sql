@@ -0,0 +1,3 @@
+INSERT INTO types (name, damage_class)
+VALUES (?, ?)
+ON CONFLICT(name) DO UPDATE SET damage_class = excluded.damage_class
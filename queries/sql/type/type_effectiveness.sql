@@ -0,0 +1,2 @@
+INSERT OR REPLACE INTO type_effectiveness (attacking_type, defending_type, multiplier)
+VALUES (?, ?, ?)
@@ -0,0 +1,2 @@
+INSERT OR REPLACE INTO type_effectiveness_past (attacking_type, defending_type, generation_id, multiplier)
+VALUES (?, ?, ?, ?)
@@ -0,0 +1,9 @@
+SELECT
+    u.id,
+    u.username,
+    u.password_hash,
+    u.created_at
+FROM sessions s
+JOIN users u ON u.id = s.user_id
+WHERE s.token_hash = ?
+  AND s.expires_at > ?
@@ -0,0 +1,7 @@
+SELECT
+    id,
+    username,
+    password_hash,
+    created_at
+FROM users
+WHERE username = ?
@@ -0,0 +1,2 @@
+INSERT INTO users (username, password_hash, created_at)
+VALUES (?, ?, ?)
@@ -0,0 +1,2 @@
+DELETE FROM version_media
+WHERE version_id = ?
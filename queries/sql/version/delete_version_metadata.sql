@@ -0,0 +1,2 @@
+DELETE FROM version_metadata
+WHERE version_id = ?
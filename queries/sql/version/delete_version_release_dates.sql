@@ -0,0 +1,2 @@
+DELETE FROM version_release_dates
+WHERE version_id = ?
@@ -0,0 +1,8 @@
+INSERT INTO igdb_game_mappings (version_name, igdb_id, igdb_name, confidence, status, updated_at)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT(version_name) DO UPDATE SET
+    igdb_id = excluded.igdb_id,
+    igdb_name = excluded.igdb_name,
+    confidence = excluded.confidence,
+    status = excluded.status,
+    updated_at = excluded.updated_at
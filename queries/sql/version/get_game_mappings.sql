@@ -0,0 +1,3 @@
+SELECT version_name, igdb_id, igdb_name, confidence, status, updated_at
+FROM igdb_game_mappings
+ORDER BY status, version_name
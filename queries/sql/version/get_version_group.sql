@@ -0,0 +1,6 @@
+SELECT
+    id,
+    name,
+    generation_name
+FROM version_groups
+WHERE id = ?
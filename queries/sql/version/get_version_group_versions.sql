@@ -0,0 +1,9 @@
+SELECT
+    v.id,
+    v.name,
+    COALESCE(v.display_name, v.name),
+    v.version_group_id
+FROM versions v
+JOIN version_groups vg ON vg.id = v.version_group_id
+WHERE vg.name = ?
+ORDER BY v.id
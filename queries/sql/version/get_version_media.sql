@@ -0,0 +1,4 @@
+SELECT kind, igdb_image_id, width, height, path
+FROM version_media
+WHERE version_id = ?
+ORDER BY CASE kind WHEN 'cover' THEN 0 WHEN 'artwork' THEN 1 ELSE 2 END, position
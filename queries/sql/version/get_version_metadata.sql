@@ -0,0 +1,3 @@
+SELECT version_id, igdb_id, summary, storyline, platforms, developers, publishers
+FROM version_metadata
+WHERE version_id = ?
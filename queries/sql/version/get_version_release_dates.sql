@@ -0,0 +1,4 @@
+SELECT region, platform, release_date
+FROM version_release_dates
+WHERE version_id = ?
+ORDER BY release_date, region, platform
@@ -0,0 +1,3 @@
+SELECT id, name, display_name, version_group_id
+FROM versions
+ORDER BY id
@@ -1,2 +1,2 @@
-INSERT OR IGNORE INTO version_groups (id, name, generation_name)
-VALUES (?, ?, ?)
\ No newline at end of file
+INSERT OR IGNORE INTO version_groups (id, name, generation_name, sort_order)
+VALUES (?, ?, ?, ?)
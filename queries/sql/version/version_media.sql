@@ -0,0 +1,2 @@
+INSERT INTO version_media (version_id, kind, position, igdb_image_id, width, height, path)
+VALUES (?, ?, ?, ?, ?, ?, ?)
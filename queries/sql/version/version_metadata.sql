@@ -0,0 +1,2 @@
+INSERT INTO version_metadata (version_id, igdb_id, summary, storyline, platforms, developers, publishers)
+VALUES (?, ?, ?, ?, ?, ?, ?)
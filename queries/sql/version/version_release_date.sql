@@ -0,0 +1,2 @@
+INSERT OR IGNORE INTO version_release_dates (version_id, region, platform, release_date)
+VALUES (?, ?, ?, ?)
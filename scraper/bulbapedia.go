@@ -1,79 +1,149 @@
 package scraper
 
 import (
+	"bytes"
 	"fmt"
-	"net/http"
-	"strings"
 
 	"github.com/PuerkitoBio/goquery"
 )
 
-type Scraper struct {
+const bulbapediaURL = "https://bulbapedia.bulbagarden.net"
+
+// GamePages maps version groups to their Bulbapedia page
+var GamePages = map[string]string{
+	"red-blue":                            "Pokémon_Red_and_Blue_Versions",
+	"yellow":                              "Pokémon_Yellow_Version",
+	"gold-silver":                         "Pokémon_Gold_and_Silver_Versions",
+	"crystal":                             "Pokémon_Crystal_Version",
+	"ruby-sapphire":                       "Pokémon_Ruby_and_Sapphire_Versions",
+	"emerald":                             "Pokémon_Emerald_Version",
+	"firered-leafgreen":                   "Pokémon_FireRed_and_LeafGreen_Versions",
+	"diamond-pearl":                       "Pokémon_Diamond_and_Pearl_Versions",
+	"platinum":                            "Pokémon_Platinum_Version",
+	"heartgold-soulsilver":                "Pokémon_HeartGold_and_SoulSilver_Versions",
+	"black-white":                         "Pokémon_Black_and_White_Versions",
+	"black-2-white-2":                     "Pokémon_Black_2_and_White_2_Versions",
+	"x-y":                                 "Pokémon_X_and_Y",
+	"omega-ruby-alpha-sapphire":           "Pokémon_Omega_Ruby_and_Alpha_Sapphire",
+	"sun-moon":                            "Pokémon_Sun_and_Moon",
+	"ultra-sun-ultra-moon":                "Pokémon_Ultra_Sun_and_Ultra_Moon",
+	"lets-go-pikachu-lets-go-eevee":       "Pokémon:_Let's_Go,_Pikachu!_and_Let's_Go,_Eevee!",
+	"sword-shield":                        "Pokémon_Sword_and_Shield",
+	"brilliant-diamond-and-shining-pearl": "Pokémon_Brilliant_Diamond_and_Shining_Pearl",
+	"scarlet-violet":                      "Pokémon_Scarlet_and_Violet",
 }
 
-func NewScraper() *Scraper {
-	return &Scraper{}
+// VersionGroupIDs maps the version groups of GamePages to their PokeAPI ID,
+// for naming a version group that isn't synced yet
+var VersionGroupIDs = map[string]int{
+	"red-blue":                            1,
+	"yellow":                              2,
+	"gold-silver":                         3,
+	"crystal":                             4,
+	"ruby-sapphire":                       5,
+	"emerald":                             6,
+	"firered-leafgreen":                   7,
+	"diamond-pearl":                       8,
+	"platinum":                            9,
+	"heartgold-soulsilver":                10,
+	"black-white":                         11,
+	"black-2-white-2":                     14,
+	"x-y":                                 15,
+	"omega-ruby-alpha-sapphire":           16,
+	"sun-moon":                            17,
+	"ultra-sun-ultra-moon":                18,
+	"lets-go-pikachu-lets-go-eevee":       19,
+	"sword-shield":                        20,
+	"brilliant-diamond-and-shining-pearl": 23,
+	"scarlet-violet":                      25,
 }
 
-type GymLeaders struct {
-	name string
-	url  string
+// Trainer is a gym leader or Elite Four member with their teams in every
+// game their page lists. Names in teams are PokeAPI style, e.g. "mr-mime".
+type Trainer struct {
+	Name          string
+	Role          string // "gym-leader" or "elite-four"
+	Order         int    // Position on the game page, starting at 1
+	URL           string
+	Location      string
+	Badge         string
+	TypeSpecialty string
+	Teams         []Team
 }
 
-func (s *Scraper) ScrapeGamePage(url string) error {
-	gymLeaders := findGymLeaders(url)
-	fmt.Println(gymLeaders)
+// Team is the party of one battle. Games are the version names of the
+// section the team is listed in, e.g. ["red", "green", "blue"].
+type Team struct {
+	Games   []string
+	Battle  int // 1 for the first team listed for the games, 2+ for rematches
+	Pokemon []TeamPokemon
+}
 
-	return nil
+type TeamPokemon struct {
+	Species  string
+	Level    int
+	HeldItem string
+	Moves    []string
 }
 
-func findGymLeaders(url string) []GymLeaders {
-	res, err := http.Get(url)
-	if err != nil {
-		return nil
+// Scraper reads trainers from Bulbapedia pages, see NewCachedFetcher to
+// scrape without hitting the network for pages that were seen before
+type Scraper struct {
+	fetcher Fetcher
+	baseURL string
+}
+
+func NewScraper(fetcher Fetcher) *Scraper {
+	return &Scraper{
+		fetcher: fetcher,
+		baseURL: bulbapediaURL,
 	}
-	defer res.Body.Close()
+}
 
-	doc, err := goquery.NewDocumentFromReader(res.Body)
+// ScrapeTrainers returns the gym leaders and Elite Four of a version group
+// with their teams, read from the game page and each trainer's page
+func (s *Scraper) ScrapeTrainers(versionGroup string) ([]*Trainer, error) {
+	page, ok := GamePages[versionGroup]
+	if !ok {
+		return nil, fmt.Errorf("no Bulbapedia page for version group %s", versionGroup)
+	}
+	doc, err := s.fetch("/wiki/" + page)
 	if err != nil {
-		return nil
+		return nil, err
 	}
 
-	// Find the "Gyms" heading
-	gymsHeading := doc.Find("span#Gyms")
-	if gymsHeading.Length() == 0 {
-		fmt.Println("Could not find Gyms section")
-		return nil
+	links := ParseTrainerLinks(doc)
+	if len(links) == 0 {
+		return nil, fmt.Errorf("no trainers found on %s", page)
 	}
 
-	// Get the parent heading, then find the next <p> tag
-	parent := gymsHeading.Parent()
-	nextParagraph := parent.NextFilteredUntil("p", "h2, h3, h4")
+	trainers := make([]*Trainer, 0, len(links))
+	for i, link := range links {
+		trainerDoc, err := s.fetch(link.Href)
+		if err != nil {
+			return nil, err
+		}
 
-	if nextParagraph.Length() == 0 {
-		fmt.Println("Could not find paragraph with gym leaders")
-		return nil
+		t := ParseTrainerPage(trainerDoc)
+		t.Name = link.Name
+		t.Role = link.Role
+		t.Order = i + 1
+		t.URL = s.baseURL + link.Href
+		trainers = append(trainers, t)
 	}
 
-	// Extract gym leader names
-	var gymLeaders []GymLeaders
-	nextParagraph.Find("a").Each(func(i int, link *goquery.Selection) {
-		href, exists := link.Attr("href")
-		if !exists {
-			return
-		}
+	return trainers, nil
+}
 
-		// Skip type links (they contain "(type)" in href)
-		if len(href) > 0 && !strings.Contains(href, "(type)") {
-			name := link.Text()
-			if len(name) > 0 {
-				gymLeaders = append(gymLeaders, GymLeaders{
-					name: name,
-					url:  href,
-				})
-			}
-		}
-	})
+func (s *Scraper) fetch(path string) (*goquery.Document, error) {
+	body, err := s.fetcher.Fetch(s.baseURL + path)
+	if err != nil {
+		return nil, err
+	}
 
-	return gymLeaders
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return doc, nil
 }
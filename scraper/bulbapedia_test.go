@@ -0,0 +1,65 @@
+package scraper
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testdataFetcher serves the pages in testdata by title
+type testdataFetcher struct{}
+
+func (testdataFetcher) Fetch(pageURL string) ([]byte, error) {
+	title := strings.TrimPrefix(pageURL, bulbapediaURL+"/wiki/")
+	if unescaped, err := url.PathUnescape(title); err == nil {
+		title = unescaped
+	}
+	body, err := os.ReadFile(filepath.Join("testdata", title+".html"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", pageURL, err)
+	}
+	return body, nil
+}
+
+func TestScrapeTrainers(t *testing.T) {
+	s := NewScraper(testdataFetcher{})
+
+	trainers, err := s.ScrapeTrainers("red-blue")
+	require.NoError(t, err)
+	require.Len(t, trainers, 2)
+
+	brock, lorelei := trainers[0], trainers[1]
+	assert.Equal(t, "Brock", brock.Name)
+	assert.Equal(t, "gym-leader", brock.Role)
+	assert.Equal(t, 1, brock.Order)
+	assert.Equal(t, "https://bulbapedia.bulbagarden.net/wiki/Brock", brock.URL)
+	assert.Equal(t, "Pewter Gym", brock.Location)
+	assert.Len(t, brock.Teams, 4)
+
+	assert.Equal(t, "Lorelei", lorelei.Name)
+	assert.Equal(t, "elite-four", lorelei.Role)
+	assert.Equal(t, 2, lorelei.Order)
+}
+
+func TestScrapeTrainersErrors(t *testing.T) {
+	s := NewScraper(testdataFetcher{})
+
+	_, err := s.ScrapeTrainers("colosseum")
+	assert.ErrorContains(t, err, "no Bulbapedia page")
+
+	// The fetch error is returned instead of an empty result
+	_, err = s.ScrapeTrainers("gold-silver")
+	assert.ErrorContains(t, err, "Gold_and_Silver")
+}
+
+func TestVersionGroupIDs(t *testing.T) {
+	for name := range GamePages {
+		assert.NotZero(t, VersionGroupIDs[name], name)
+	}
+}
@@ -0,0 +1,128 @@
+package scraper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/metrics"
+)
+
+const userAgent = "pokemon-companion-go-backend/1.0 (+https://github.com/ArtisGulbis/pokemon-companion-go-backend)"
+
+// Fetcher returns the HTML of a page
+type Fetcher interface {
+	Fetch(pageURL string) ([]byte, error)
+}
+
+// HTTPFetcher fetches pages from the network, at most one request per tick
+// of its rate limiter
+type HTTPFetcher struct {
+	client      *http.Client
+	rateLimiter *time.Ticker
+	fetched     bool
+}
+
+func NewHTTPFetcher(rateLimiter *time.Ticker) *HTTPFetcher {
+	return &HTTPFetcher{
+		client:      &http.Client{Timeout: 30 * time.Second, Transport: metrics.Transport("bulbapedia", nil)},
+		rateLimiter: rateLimiter,
+	}
+}
+
+func (f *HTTPFetcher) Fetch(pageURL string) ([]byte, error) {
+	if f.fetched {
+		<-f.rateLimiter.C
+	}
+	f.fetched = true
+
+	req, err := http.NewRequest(http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", pageURL, err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	res, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", pageURL, err)
+	}
+	defer res.Body.Close()
+	logger().Debug("fetched page", "url", pageURL, "status", res.StatusCode)
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: status %d", pageURL, res.StatusCode)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", pageURL, err)
+	}
+	return body, nil
+}
+
+// CachedFetcher keeps every page it fetches in a directory and only asks the
+// next fetcher for pages it doesn't have. Delete a file to fetch it again.
+type CachedFetcher struct {
+	dir  string
+	next Fetcher
+}
+
+func NewCachedFetcher(dir string, next Fetcher) *CachedFetcher {
+	return &CachedFetcher{dir: dir, next: next}
+}
+
+func (f *CachedFetcher) Fetch(pageURL string) ([]byte, error) {
+	path := filepath.Join(f.dir, cacheFileName(pageURL))
+
+	body, err := os.ReadFile(path)
+	if err == nil {
+		return body, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read cached %s: %w", pageURL, err)
+	}
+
+	body, err = f.next.Fetch(pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(f.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache dir: %w", err)
+	}
+	// Write to a temp file first so an interrupted run never leaves half a page
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, body, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to cache %s: %w", pageURL, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return nil, fmt.Errorf("failed to cache %s: %w", pageURL, err)
+	}
+
+	return body, nil
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^\p{L}\p{N}_.-]+`)
+
+// cacheFileName turns a page URL into a readable file name, e.g.
+// ".../wiki/Brock" into "Brock-<hash>.html". The short hash of the URL keeps
+// titles that only differ in unsafe characters apart.
+func cacheFileName(pageURL string) string {
+	name := pageURL
+	if u, err := url.Parse(pageURL); err == nil {
+		name = filepath.Base(u.Path)
+		if unescaped, err := url.PathUnescape(name); err == nil {
+			name = unescaped
+		}
+	}
+	sum := sha256.Sum256([]byte(pageURL))
+	return unsafeFileChars.ReplaceAllString(name, "_") + "-" + hex.EncodeToString(sum[:4]) + ".html"
+}
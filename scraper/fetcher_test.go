@@ -0,0 +1,87 @@
+package scraper
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingFetcher struct {
+	calls int
+	body  []byte
+	err   error
+}
+
+func (f *countingFetcher) Fetch(pageURL string) ([]byte, error) {
+	f.calls++
+	return f.body, f.err
+}
+
+func TestCachedFetcher(t *testing.T) {
+	next := &countingFetcher{body: []byte("<html>Brock</html>")}
+	dir := t.TempDir()
+	fetcher := NewCachedFetcher(dir, next)
+
+	for range 2 {
+		body, err := fetcher.Fetch("https://bulbapedia.bulbagarden.net/wiki/Brock")
+		require.NoError(t, err)
+		assert.Equal(t, "<html>Brock</html>", string(body))
+	}
+	assert.Equal(t, 1, next.calls)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Regexp(t, `^Brock-[0-9a-f]{8}\.html$`, files[0].Name())
+}
+
+func TestCachedFetcherError(t *testing.T) {
+	next := &countingFetcher{err: errors.New("connection refused")}
+	dir := t.TempDir()
+	fetcher := NewCachedFetcher(dir, next)
+
+	_, err := fetcher.Fetch("https://bulbapedia.bulbagarden.net/wiki/Brock")
+	assert.Error(t, err)
+
+	// Failures aren't cached
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestCacheFileName(t *testing.T) {
+	assert.Regexp(t, `^Pokémon_Red_and_Blue_Versions-[0-9a-f]{8}\.html$`,
+		cacheFileName("https://bulbapedia.bulbagarden.net/wiki/Pok%C3%A9mon_Red_and_Blue_Versions"))
+	assert.Regexp(t, `^Pokémon__Let_s_Go__Pikachu__and_Let_s_Go__Eevee_-[0-9a-f]{8}\.html$`,
+		cacheFileName("https://bulbapedia.bulbagarden.net/wiki/Pokémon:_Let's_Go,_Pikachu!_and_Let's_Go,_Eevee!"))
+	assert.NotEqual(t, cacheFileName("https://example.com/wiki/A_B"), cacheFileName("https://example.com/wiki/A:B"))
+}
+
+func TestHTTPFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, userAgent, r.Header.Get("User-Agent"))
+		if r.URL.Path == "/wiki/Missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("<html>Brock</html>"))
+	}))
+	defer server.Close()
+
+	rateLimiter := time.NewTicker(time.Millisecond)
+	defer rateLimiter.Stop()
+	fetcher := NewHTTPFetcher(rateLimiter)
+
+	body, err := fetcher.Fetch(server.URL + "/wiki/Brock")
+	require.NoError(t, err)
+	assert.Equal(t, "<html>Brock</html>", string(body))
+
+	_, err = fetcher.Fetch(server.URL + "/wiki/Missing")
+	assert.ErrorContains(t, err, "status 404")
+}
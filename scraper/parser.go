@@ -1 +1,284 @@
 package scraper
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// trainerSections are the sections of a game page that link to trainers
+var trainerSections = []struct {
+	id   string
+	role string
+}{
+	{"Gyms", "gym-leader"},
+	{"Elite_Four", "elite-four"},
+}
+
+// TrainerLink is a trainer linked from a game page, Href is relative to the wiki
+type TrainerLink struct {
+	Name string
+	Role string
+	Href string
+}
+
+// ParseTrainerLinks returns the gym leaders and then the Elite Four linked
+// from a game page, in the order the page lists them
+func ParseTrainerLinks(doc *goquery.Document) []TrainerLink {
+	var links []TrainerLink
+	seen := make(map[string]bool)
+	for _, section := range trainerSections {
+		for _, link := range sectionLinks(doc, section.id) {
+			if seen[link.Href] {
+				continue
+			}
+			seen[link.Href] = true
+			link.Role = section.role
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// sectionLinks returns the trainers linked from the first paragraph or list
+// after a section heading of a game page
+func sectionLinks(doc *goquery.Document, sectionID string) []TrainerLink {
+	heading := doc.Find("#" + sectionID)
+	if heading.Length() == 0 {
+		return nil
+	}
+	// The id is on a span inside the heading in older MediaWiki markup
+	if !heading.Is("h2, h3, h4, h5") {
+		heading = heading.Closest("h2, h3, h4, h5")
+	}
+	if wrapper := heading.Parent(); wrapper.HasClass("mw-heading") {
+		heading = wrapper
+	}
+
+	content := heading.NextFilteredUntil("p, ul", "h2, h3, h4, h5, .mw-heading").First()
+
+	var links []TrainerLink
+	content.Find("a").Each(func(i int, a *goquery.Selection) {
+		href, ok := a.Attr("href")
+		name := strings.TrimSpace(a.Text())
+		if !ok || name == "" || !isTrainerHref(href) {
+			return
+		}
+		links = append(links, TrainerLink{Name: name, Href: href})
+	})
+
+	return links
+}
+
+// genericPages are linked from trainer sections but aren't trainers
+var genericPages = map[string]bool{
+	"/wiki/Gym":                   true,
+	"/wiki/Gym_Leader":            true,
+	"/wiki/Badge":                 true,
+	"/wiki/Elite_Four":            true,
+	"/wiki/Champion":              true,
+	"/wiki/Pok%C3%A9mon_Champion": true,
+}
+
+// isTrainerHref skips links to types, places, badges and other wikis
+func isTrainerHref(href string) bool {
+	if !strings.HasPrefix(href, "/wiki/") || strings.Contains(href, "#") || genericPages[href] {
+		return false
+	}
+	for _, skip := range []string{"(type)", "_Gym", "_Badge", "_City", "_Town", "_Plateau", "Pok%C3%A9mon_League", "Pokémon_League"} {
+		if strings.Contains(href, skip) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseTrainerPage returns the infobox details of a trainer page and the
+// teams of its "Pokémon" section, one subsection per game. The name, role and
+// order come from the game page, see ParseTrainerLinks.
+func ParseTrainerPage(doc *goquery.Document) *Trainer {
+	t := &Trainer{}
+	content := doc.Find(".mw-parser-output").First()
+
+	infobox := content.Find("table.roundy").First()
+	infobox.Find("a").EachWithBreak(func(i int, a *goquery.Selection) bool {
+		title := strings.TrimSpace(a.AttrOr("title", ""))
+		href := a.AttrOr("href", "")
+		switch {
+		case t.Location == "" && (strings.HasSuffix(title, " Gym") || strings.Contains(title, "League") || strings.HasSuffix(title, " Plateau")):
+			t.Location = title
+		case t.Badge == "" && strings.HasSuffix(title, " Badge"):
+			t.Badge = title
+		case t.TypeSpecialty == "" && strings.HasSuffix(href, "_(type)"):
+			t.TypeSpecialty = slug(strings.TrimSuffix(title, " (type)"))
+		}
+		return t.Location == "" || t.Badge == "" || t.TypeSpecialty == ""
+	})
+
+	// The section is "Pokémon" on some pages and nested under the game series
+	// on others, so games are read from the level below wherever it is
+	pokemonLevel := 0
+	var games []string
+	battles := 0
+	content.Children().Each(func(i int, el *goquery.Selection) {
+		if level, text := headingOf(el); level > 0 {
+			switch {
+			case pokemonLevel > 0 && level <= pokemonLevel:
+				pokemonLevel = 0
+				games = nil
+			case pokemonLevel > 0 && level == pokemonLevel+1:
+				games = parseGames(text)
+				battles = 0
+			}
+			if pokemonLevel == 0 && text == "Pokémon" {
+				pokemonLevel = level
+			}
+			return
+		}
+		if pokemonLevel == 0 || len(games) == 0 || !el.Is("table, div") {
+			return
+		}
+
+		pokemon := parseTeam(el)
+		if len(pokemon) == 0 {
+			return
+		}
+		battles++
+		t.Teams = append(t.Teams, Team{Games: games, Battle: battles, Pokemon: pokemon})
+	})
+
+	return t
+}
+
+// headingOf returns the level and text of a h2-h5 heading, 0 for other elements
+func headingOf(el *goquery.Selection) (int, string) {
+	h := el
+	if el.HasClass("mw-heading") {
+		h = el.Children().Filter("h2, h3, h4, h5").First()
+	}
+	if h.Length() == 0 || !h.Is("h2, h3, h4, h5") {
+		return 0, ""
+	}
+	level, _ := strconv.Atoi(strings.TrimPrefix(goquery.NodeName(h), "h"))
+	return level, headingText(h)
+}
+
+func headingText(h *goquery.Selection) string {
+	if headline := h.Find(".mw-headline"); headline.Length() > 0 {
+		return strings.TrimSpace(headline.Text())
+	}
+	return strings.TrimSpace(h.Text())
+}
+
+var levelPattern = regexp.MustCompile(`Lv\.\s*(\d+)`)
+
+// parseTeam returns the pokemon of a party table. A pokemon's box is the
+// largest element around its species link that shows a single level, so the
+// sprite and name links of one pokemon end up in the same box.
+func parseTeam(party *goquery.Selection) []TeamPokemon {
+	var team []TeamPokemon
+	seen := make(map[*html.Node]bool)
+
+	party.Find("a[href]").Each(func(i int, a *goquery.Selection) {
+		species, ok := speciesFromHref(a.AttrOr("href", ""))
+		if !ok {
+			return
+		}
+
+		var box *goquery.Selection
+		a.ParentsUntilSelection(party).EachWithBreak(func(i int, el *goquery.Selection) bool {
+			levels := len(levelPattern.FindAllString(el.Text(), -1))
+			if levels == 1 {
+				box = el
+			}
+			return levels < 2
+		})
+		if box == nil || seen[box.Get(0)] {
+			return
+		}
+		seen[box.Get(0)] = true
+
+		level, _ := strconv.Atoi(levelPattern.FindStringSubmatch(box.Text())[1])
+		p := TeamPokemon{Species: species, Level: level, Moves: []string{}}
+
+		box.Find("a[href]").Each(func(i int, link *goquery.Selection) {
+			href := link.AttrOr("href", "")
+			if strings.HasSuffix(href, "_(move)") {
+				p.Moves = append(p.Moves, slug(pageTitle(href, "_(move)")))
+			}
+		})
+		box.Find("img").EachWithBreak(func(i int, img *goquery.Selection) bool {
+			if strings.Contains(img.AttrOr("src", ""), "Bag_") {
+				p.HeldItem = slug(img.AttrOr("alt", ""))
+				return false
+			}
+			return true
+		})
+
+		team = append(team, p)
+	})
+
+	return team
+}
+
+// speciesFromHref returns the species of a link like /wiki/Geodude_(Pok%C3%A9mon)
+func speciesFromHref(href string) (string, bool) {
+	for _, suffix := range []string{"_(Pok%C3%A9mon)", "_(Pokémon)"} {
+		if strings.HasPrefix(href, "/wiki/") && strings.HasSuffix(href, suffix) {
+			return slug(pageTitle(href, suffix)), true
+		}
+	}
+	return "", false
+}
+
+// pageTitle returns the unescaped title of a wiki link without a suffix
+func pageTitle(href, suffix string) string {
+	title := strings.TrimSuffix(strings.TrimPrefix(href, "/wiki/"), suffix)
+	if unescaped, err := url.PathUnescape(title); err == nil {
+		title = unescaped
+	}
+	return strings.ReplaceAll(title, "_", " ")
+}
+
+// parseGames turns a heading like "Pokémon Red, Green, and Blue" into version
+// names: red, green, blue
+func parseGames(heading string) []string {
+	heading = strings.TrimPrefix(heading, "Pokémon: ")
+	heading = strings.TrimPrefix(heading, "Pokémon ")
+	heading = strings.ReplaceAll(heading, "Let's Go, ", "Let's Go ")
+	heading = strings.ReplaceAll(heading, ", and ", ", ")
+	heading = strings.ReplaceAll(heading, " and ", ", ")
+
+	var games []string
+	for _, game := range strings.Split(heading, ",") {
+		if game = slug(game); game != "" {
+			games = append(games, game)
+		}
+	}
+	return games
+}
+
+var slugReplacer = strings.NewReplacer(
+	"♀", "-f",
+	"♂", "-m",
+	"é", "e",
+	"'", "",
+	"’", "",
+	".", "",
+	":", "",
+	"!", "",
+	" ", "-",
+)
+
+// slug turns a Bulbapedia name into a PokeAPI name, e.g. "Mr. Mime" into "mr-mime"
+func slug(name string) string {
+	s := slugReplacer.Replace(strings.ToLower(strings.TrimSpace(name)))
+	for strings.Contains(s, "--") {
+		s = strings.ReplaceAll(s, "--", "-")
+	}
+	return strings.Trim(s, "-")
+}
@@ -0,0 +1,107 @@
+package scraper
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadPage(t *testing.T, name string) *goquery.Document {
+	f, err := os.Open(filepath.Join("testdata", name+".html"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	doc, err := goquery.NewDocumentFromReader(f)
+	require.NoError(t, err)
+	return doc
+}
+
+func TestParseTrainerLinks(t *testing.T) {
+	links := ParseTrainerLinks(loadPage(t, "Pokémon_Red_and_Blue_Versions"))
+
+	// Types, places, badges, anchors and other wikis are skipped, Brock is only listed once
+	assert.Equal(t, []TrainerLink{
+		{Name: "Brock", Role: "gym-leader", Href: "/wiki/Brock"},
+		{Name: "Lorelei", Role: "elite-four", Href: "/wiki/Lorelei"},
+	}, links)
+}
+
+func TestParseTrainerPage(t *testing.T) {
+	brock := ParseTrainerPage(loadPage(t, "Brock"))
+
+	assert.Equal(t, "Pewter Gym", brock.Location)
+	assert.Equal(t, "Boulder Badge", brock.Badge)
+	assert.Equal(t, "rock", brock.TypeSpecialty)
+	require.Len(t, brock.Teams, 4)
+
+	rgb := brock.Teams[0]
+	assert.Equal(t, []string{"red", "green", "blue"}, rgb.Games)
+	assert.Equal(t, 1, rgb.Battle)
+	assert.Equal(t, []TeamPokemon{
+		{Species: "geodude", Level: 12, Moves: []string{"tackle", "defense-curl"}},
+		{Species: "onix", Level: 14, Moves: []string{"tackle", "screech", "bide"}},
+	}, rgb.Pokemon)
+
+	assert.Equal(t, []string{"yellow"}, brock.Teams[1].Games)
+	assert.Equal(t, 10, brock.Teams[1].Pokemon[0].Level)
+
+	first, rematch := brock.Teams[2], brock.Teams[3]
+	assert.Equal(t, []string{"heartgold", "soulsilver"}, first.Games)
+	assert.Equal(t, 1, first.Battle)
+	assert.Equal(t, TeamPokemon{Species: "graveler", Level: 51, HeldItem: "sitrus-berry", Moves: []string{"earthquake", "stone-edge"}}, first.Pokemon[0])
+	assert.Equal(t, "mr-mime", first.Pokemon[1].Species)
+	assert.Equal(t, 2, rematch.Battle)
+	assert.Equal(t, TeamPokemon{Species: "nidoran-m", Level: 55, HeldItem: "kings-rock", Moves: []string{"horn-attack"}}, rematch.Pokemon[0])
+	assert.Equal(t, "farfetchd", rematch.Pokemon[1].Species)
+}
+
+func TestParseTrainerPageNewHeadings(t *testing.T) {
+	lorelei := ParseTrainerPage(loadPage(t, "Lorelei"))
+
+	assert.Equal(t, "Indigo League", lorelei.Location)
+	assert.Empty(t, lorelei.Badge)
+	assert.Equal(t, "ice", lorelei.TypeSpecialty)
+	require.Len(t, lorelei.Teams, 2)
+	assert.Equal(t, []string{"red", "green", "blue", "yellow"}, lorelei.Teams[0].Games)
+	assert.Equal(t, TeamPokemon{Species: "dewgong", Level: 54, Moves: []string{"growl", "aurora-beam"}}, lorelei.Teams[0].Pokemon[0])
+	assert.Equal(t, []string{"firered", "leafgreen"}, lorelei.Teams[1].Games)
+	assert.Len(t, lorelei.Teams[1].Pokemon, 1)
+}
+
+func TestParseGames(t *testing.T) {
+	tests := []struct {
+		heading  string
+		expected []string
+	}{
+		{"Pokémon Red, Green, and Blue", []string{"red", "green", "blue"}},
+		{"Pokémon Black 2 and White 2", []string{"black-2", "white-2"}},
+		{"Pokémon Omega Ruby and Alpha Sapphire", []string{"omega-ruby", "alpha-sapphire"}},
+		{"Pokémon: Let's Go, Pikachu! and Let's Go, Eevee!", []string{"lets-go-pikachu", "lets-go-eevee"}},
+		{"Pokémon X and Y", []string{"x", "y"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.heading, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseGames(tt.heading))
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := map[string]string{
+		"Mr. Mime":     "mr-mime",
+		"Nidoran♀":     "nidoran-f",
+		"Farfetch'd":   "farfetchd",
+		"Flabébé":      "flabebe",
+		"King's Rock":  "kings-rock",
+		" Stone Edge ": "stone-edge",
+	}
+
+	for name, expected := range tests {
+		assert.Equal(t, expected, slug(name), name)
+	}
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/db"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+)
+
+// AssetService downloads images into an asset store and records them, an
+// image is only fetched again when its files went missing
+type AssetService struct {
+	store AssetStore
+	repo  AssetRepo
+}
+
+func NewAssetService(store AssetStore, repo AssetRepo) *AssetService {
+	return &AssetService{
+		store: store,
+		repo:  repo,
+	}
+}
+
+func (s *AssetService) Download(url string) (*dto.Asset, error) {
+	asset, err := s.repo.GetAssetBySourceURL(url)
+	if err == nil && s.store.Has(asset) {
+		return asset, nil
+	}
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+
+	asset, err = s.store.Fetch(url)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.InsertAsset(asset); err != nil {
+		return nil, err
+	}
+
+	return asset, nil
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/db"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockImageDownloader struct {
+	mock.Mock
+}
+
+func (m *MockImageDownloader) Download(url string) (*dto.Asset, error) {
+	args := m.Called(url)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.Asset), args.Error(1)
+}
+
+type MockAssetStore struct {
+	mock.Mock
+}
+
+func (m *MockAssetStore) Fetch(sourceURL string) (*dto.Asset, error) {
+	args := m.Called(sourceURL)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.Asset), args.Error(1)
+}
+
+func (m *MockAssetStore) Has(a *dto.Asset) bool {
+	args := m.Called(a)
+	return args.Bool(0)
+}
+
+type MockAssetRepo struct {
+	mock.Mock
+}
+
+func (m *MockAssetRepo) InsertAsset(a *dto.Asset) error {
+	args := m.Called(a)
+	return args.Error(0)
+}
+
+func (m *MockAssetRepo) GetAssetBySourceURL(sourceURL string) (*dto.Asset, error) {
+	args := m.Called(sourceURL)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.Asset), args.Error(1)
+}
+
+const artworkURL = "https://example.com/official-artwork/25.png"
+
+func TestAssetServiceDownload(t *testing.T) {
+	stored := &dto.Asset{Hash: "3f9a", SourceURL: artworkURL, Path: "images/assets/3f/3f9a.png"}
+	notFound := fmt.Errorf("asset from %s %w", artworkURL, db.ErrNotFound)
+
+	t.Run("downloads new images", func(t *testing.T) {
+		store := new(MockAssetStore)
+		repo := new(MockAssetRepo)
+		repo.On("GetAssetBySourceURL", artworkURL).Return(nil, notFound)
+		store.On("Fetch", artworkURL).Return(stored, nil).Once()
+		repo.On("InsertAsset", stored).Return(nil).Once()
+
+		got, err := NewAssetService(store, repo).Download(artworkURL)
+		require.NoError(t, err)
+		assert.Equal(t, stored, got)
+		store.AssertExpectations(t)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("reuses images on disk", func(t *testing.T) {
+		store := new(MockAssetStore)
+		repo := new(MockAssetRepo)
+		repo.On("GetAssetBySourceURL", artworkURL).Return(stored, nil)
+		store.On("Has", stored).Return(true)
+
+		got, err := NewAssetService(store, repo).Download(artworkURL)
+		require.NoError(t, err)
+		assert.Equal(t, stored, got)
+		store.AssertNotCalled(t, "Fetch", mock.Anything)
+	})
+
+	t.Run("downloads images that went missing again", func(t *testing.T) {
+		store := new(MockAssetStore)
+		repo := new(MockAssetRepo)
+		repo.On("GetAssetBySourceURL", artworkURL).Return(stored, nil)
+		store.On("Has", stored).Return(false)
+		store.On("Fetch", artworkURL).Return(stored, nil).Once()
+		repo.On("InsertAsset", stored).Return(nil).Once()
+
+		_, err := NewAssetService(store, repo).Download(artworkURL)
+		require.NoError(t, err)
+		store.AssertExpectations(t)
+	})
+
+	t.Run("broken images aren't recorded", func(t *testing.T) {
+		store := new(MockAssetStore)
+		repo := new(MockAssetRepo)
+		repo.On("GetAssetBySourceURL", artworkURL).Return(nil, notFound)
+		store.On("Fetch", artworkURL).Return(nil, errors.New("failed to decode image: unexpected EOF"))
+
+		_, err := NewAssetService(store, repo).Download(artworkURL)
+		assert.ErrorContains(t, err, "unexpected EOF")
+		repo.AssertNotCalled(t, "InsertAsset", mock.Anything)
+	})
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/db"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+)
+
+// DefaultSessionTTL is how long a token stays valid after login
+const DefaultSessionTTL = 30 * 24 * time.Hour
+
+const passwordIterations = 600_000
+
+var usernamePattern = regexp.MustCompile(`^[a-z0-9_-]{3,32}$`)
+
+// AuthService handles accounts and opaque bearer tokens. Only the SHA-256 of a
+// token is stored, passwords are hashed with PBKDF2-SHA256.
+type AuthService struct {
+	repo       UserRepo
+	sessionTTL time.Duration
+	now        func() time.Time
+}
+
+func NewAuthService(repo UserRepo, sessionTTL time.Duration) *AuthService {
+	return &AuthService{
+		repo:       repo,
+		sessionTTL: sessionTTL,
+		now:        time.Now,
+	}
+}
+
+// Register creates a user and logs them in
+func (s *AuthService) Register(username, password string) (*dto.User, string, error) {
+	username = strings.ToLower(strings.TrimSpace(username))
+	if !usernamePattern.MatchString(username) {
+		return nil, "", fmt.Errorf("%w: username must be 3-32 characters of a-z, 0-9, _ and -", ErrInvalidInput)
+	}
+	if len(password) < 8 {
+		return nil, "", fmt.Errorf("%w: password must be at least 8 characters", ErrInvalidInput)
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return nil, "", err
+	}
+	user, err := s.repo.InsertUser(username, hash, s.now())
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, err := s.createSession(user.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return user, token, nil
+}
+
+// Login returns a new token for valid credentials
+func (s *AuthService) Login(username, password string) (*dto.User, string, error) {
+	user, err := s.repo.GetUserByUsername(strings.ToLower(strings.TrimSpace(username)))
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, "", ErrUnauthorized
+		}
+		return nil, "", err
+	}
+
+	ok, err := verifyPassword(password, user.PasswordHash)
+	if err != nil {
+		return nil, "", err
+	}
+	if !ok {
+		return nil, "", ErrUnauthorized
+	}
+
+	token, err := s.createSession(user.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return user, token, nil
+}
+
+// Authenticate returns the user a token belongs to
+func (s *AuthService) Authenticate(token string) (*dto.User, error) {
+	if token == "" {
+		return nil, ErrUnauthorized
+	}
+
+	user, err := s.repo.GetSessionUser(hashToken(token), s.now())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Logout invalidates a token
+func (s *AuthService) Logout(token string) error {
+	return s.repo.DeleteSession(hashToken(token))
+}
+
+func (s *AuthService) createSession(userID int) (string, error) {
+	now := s.now()
+	if err := s.repo.DeleteExpiredSessions(now); err != nil {
+		return "", err
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+
+	if err := s.repo.InsertSession(hashToken(token), userID, now, now.Add(s.sessionTTL)); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// hashPassword returns "pbkdf2-sha256$<iterations>$<salt>$<key>"
+func hashPassword(password string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, passwordIterations, 32)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	return fmt.Sprintf("pbkdf2-sha256$%d$%s$%s",
+		passwordIterations,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func verifyPassword(password, encoded string) (bool, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 4 || parts[0] != "pbkdf2-sha256" {
+		return false, fmt.Errorf("unsupported password hash format")
+	}
+
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false, fmt.Errorf("invalid password hash iterations: %w", err)
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false, fmt.Errorf("invalid password hash salt: %w", err)
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false, fmt.Errorf("invalid password hash key: %w", err)
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, iterations, len(expected))
+	if err != nil {
+		return false, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	return subtle.ConstantTimeCompare(key, expected) == 1, nil
+}
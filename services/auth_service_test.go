@@ -0,0 +1,49 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordHash(t *testing.T) {
+	hash, err := hashPassword("pikachu123")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "pbkdf2-sha256$600000$"))
+
+	ok, err := verifyPassword("pikachu123", hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = verifyPassword("raichu123", hash)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	other, err := hashPassword("pikachu123")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other, "salts must differ")
+}
+
+func TestRegisterValidation(t *testing.T) {
+	// Invalid input never reaches the repository
+	auth := NewAuthService(nil, DefaultSessionTTL)
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+	}{
+		{"Username too short", "as", "pikachu123"},
+		{"Username with spaces", "ash ketchum", "pikachu123"},
+		{"Password too short", "ash", "pika"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := auth.Register(tt.username, tt.password)
+			assert.ErrorIs(t, err, ErrInvalidInput)
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/db"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
+)
+
+const maxPoolCounters = 10 // Counters returned at most when planning from the obtainable pool
+
+// BattlePlanner ranks the player's Pokemon against a trainer battle by type
+// matchup and stats. Both sides get their stats at their level with zero IVs
+// and EVs, so species, level and moves are what set counters apart.
+type BattlePlanner struct {
+	trainerRepo     TrainerRepo
+	playthroughRepo PlaythroughRepo
+	pokemonRepo     PokemonRepo
+	moveRepo        MoveRepo
+	versionRepo     VersionRepo
+	typeRepo        TypeRepo
+	encounterRepo   EncounterRepo
+	statCalculator  *StatCalculator
+}
+
+func NewBattlePlanner(
+	trainerRepo TrainerRepo,
+	playthroughRepo PlaythroughRepo,
+	pokemonRepo PokemonRepo,
+	moveRepo MoveRepo,
+	versionRepo VersionRepo,
+	typeRepo TypeRepo,
+	encounterRepo EncounterRepo,
+	statCalculator *StatCalculator,
+) *BattlePlanner {
+	return &BattlePlanner{
+		trainerRepo:     trainerRepo,
+		playthroughRepo: playthroughRepo,
+		pokemonRepo:     pokemonRepo,
+		moveRepo:        moveRepo,
+		versionRepo:     versionRepo,
+		typeRepo:        typeRepo,
+		encounterRepo:   encounterRepo,
+		statCalculator:  statCalculator,
+	}
+}
+
+// GetTrainers returns the trainer battles of a version with their teams
+func (p *BattlePlanner) GetTrainers(versionID int) ([]*dto.Trainer, error) {
+	if _, err := p.versionRepo.GetVersionByID(versionID); err != nil {
+		return nil, err
+	}
+	return p.trainerRepo.GetTrainers(versionID)
+}
+
+// PlanForParty ranks the party of a playthrough against a trainer battle of
+// the playthrough's version, at the levels the party is at
+func (p *BattlePlanner) PlanForParty(userID, playthroughID, trainerID int) (*dto.BattlePlan, error) {
+	playthrough, err := p.playthroughRepo.GetPlaythrough(userID, playthroughID)
+	if err != nil {
+		return nil, err
+	}
+	battle, err := p.prepare(trainerID)
+	if err != nil {
+		return nil, err
+	}
+	if battle.trainer.VersionID != playthrough.VersionID {
+		return nil, fmt.Errorf("%w: trainer %d is not in the version of playthrough %d", ErrInvalidInput, trainerID, playthroughID)
+	}
+	if len(playthrough.Party) == 0 {
+		return nil, fmt.Errorf("%w: playthrough %d has no party", ErrInvalidInput, playthroughID)
+	}
+
+	counters := make([]*dto.Counter, 0, len(playthrough.Party))
+	for _, m := range playthrough.Party {
+		c, err := p.counter(battle, m.PokemonID, m.Level)
+		if err != nil {
+			return nil, err
+		}
+		counters = append(counters, c)
+	}
+
+	return battle.plan(dto.PlanSourceParty, counters, len(counters)), nil
+}
+
+// PlanForPool ranks the species that can be encountered in the trainer's
+// version, planned at the level cap of the battle
+func (p *BattlePlanner) PlanForPool(trainerID int) (*dto.BattlePlan, error) {
+	battle, err := p.prepare(trainerID)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := p.encounterRepo.GetSpeciesLocations(battle.versionGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var counters []*dto.Counter
+	seen := make(map[int]bool)
+	for _, l := range locations {
+		if l.VersionID != battle.trainer.VersionID || seen[l.SpeciesID] {
+			continue
+		}
+		seen[l.SpeciesID] = true
+
+		// The default variety of a species shares its ID
+		c, err := p.counter(battle, l.SpeciesID, battle.levelCap)
+		if errors.Is(err, db.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		counters = append(counters, c)
+	}
+
+	return battle.plan(dto.PlanSourcePool, counters, maxPoolCounters), nil
+}
+
+// trainerBattle is a trainer with the resolved stats and types of its team
+type trainerBattle struct {
+	trainer        *dto.Trainer
+	versionGroupID int
+	generation     int
+	chart          *dto.TypeChart
+	levelCap       int
+	opponents      []opponent
+}
+
+type opponent struct {
+	slot int
+	name string
+	*combatant
+}
+
+func (p *BattlePlanner) prepare(trainerID int) (*trainerBattle, error) {
+	trainer, err := p.trainerRepo.GetTrainerByID(trainerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(trainer.Team) == 0 {
+		return nil, fmt.Errorf("%w: trainer %d has no team", ErrInvalidInput, trainerID)
+	}
+	version, err := p.versionRepo.GetVersionByID(trainer.VersionID)
+	if err != nil {
+		return nil, err
+	}
+	versionGroup, err := p.versionRepo.GetVersionGroupByID(version.VersionGroupID)
+	if err != nil {
+		return nil, err
+	}
+	generation, err := utils.GenerationNumber(versionGroup.GenerationName)
+	if err != nil {
+		return nil, err
+	}
+	chart, err := p.typeRepo.GetTypeChart(generation)
+	if err != nil {
+		return nil, err
+	}
+
+	battle := &trainerBattle{
+		trainer:        trainer,
+		versionGroupID: versionGroup.ID,
+		generation:     generation,
+		chart:          chart,
+	}
+	for _, m := range trainer.Team {
+		c, err := p.combatant(battle, m.SpeciesID, m.Level)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.SpeciesName, err)
+		}
+		battle.opponents = append(battle.opponents, opponent{slot: m.Slot, name: m.SpeciesName, combatant: c})
+		battle.levelCap = max(battle.levelCap, m.Level)
+	}
+
+	return battle, nil
+}
+
+func (p *BattlePlanner) combatant(b *trainerBattle, pokemonID, level int) (*combatant, error) {
+	stats, err := p.statCalculator.CalculateStats(&StatInput{
+		PokemonID:      pokemonID,
+		VersionGroupID: b.versionGroupID,
+		Level:          level,
+	})
+	if err != nil {
+		return nil, err
+	}
+	types, err := p.pokemonRepo.GetPokemonTypes(pokemonID, b.generation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &combatant{
+		level:     level,
+		stats:     *stats,
+		types:     types,
+		currentHP: stats.HP,
+	}, nil
+}
+
+// counter scores a Pokemon against every opponent with the damaging moves it
+// learns by level-up up to its level
+func (p *BattlePlanner) counter(b *trainerBattle, pokemonID, level int) (*dto.Counter, error) {
+	pokemon, err := p.pokemonRepo.GetPokemonByID(pokemonID)
+	if err != nil {
+		return nil, err
+	}
+	attacker, err := p.combatant(b, pokemonID, level)
+	if err != nil {
+		return nil, err
+	}
+	learnset, err := p.moveRepo.GetPokemonMoves(pokemonID, b.versionGroupID)
+	if err != nil {
+		return nil, err
+	}
+	moves := damagingMoves(learnset, level)
+
+	c := &dto.Counter{
+		PokemonID: pokemonID,
+		Name:      pokemon.Name,
+		Level:     level,
+		Types:     attacker.types,
+		Matchups:  make([]dto.Matchup, 0, len(b.opponents)),
+	}
+	total := 0.0
+	for _, o := range b.opponents {
+		m := b.matchup(attacker, o, moves)
+		c.Matchups = append(c.Matchups, m)
+		total += matchupScore(m)
+	}
+	c.Score = math.Round(total/float64(len(b.opponents))*10) / 10
+
+	return c, nil
+}
+
+// damagingMoves returns the moves with base power in a learnset that are
+// learned by level-up at or below level
+func damagingMoves(learnset []*dto.PokemonMove, level int) []*dto.Move {
+	var moves []*dto.Move
+	seen := make(map[int]bool)
+	for _, lm := range learnset {
+		if lm.LearnMethod != "level-up" || lm.LevelLearnedAt > level || lm.Move.Power == 0 || lm.Move.DamageClass == "status" || seen[lm.Move.ID] {
+			continue
+		}
+		seen[lm.Move.ID] = true
+		moves = append(moves, &lm.Move)
+	}
+	return moves
+}
+
+// matchup picks the move with the highest minimum damage against an opponent,
+// moves the opponent is immune to are never picked
+func (b *trainerBattle) matchup(attacker *combatant, o opponent, moves []*dto.Move) dto.Matchup {
+	m := dto.Matchup{
+		Slot:          o.slot,
+		Opponent:      o.name,
+		OpponentLevel: o.level,
+		Faster:        attacker.stats.Speed > o.stats.Speed,
+	}
+
+	// The threat is the opponent's best same-type attack against the attacker
+	for _, t := range o.types {
+		threat := &damageFormula{moveType: t, defender: attacker}
+		m.Threat = max(m.Threat, threat.typeEffectiveness(b.chart))
+	}
+
+	best := -1
+	for _, move := range moves {
+		damageClass := move.DamageClass
+		if b.generation < 4 {
+			damageClass = b.chart.DamageClasses[move.Type]
+		}
+		if damageClass == "" {
+			continue
+		}
+
+		f := &damageFormula{
+			generation:  b.generation,
+			attacker:    attacker,
+			defender:    o.combatant,
+			moveType:    move.Type,
+			damageClass: damageClass,
+			power:       move.Power,
+			stab:        slices.Contains(attacker.types, move.Type),
+		}
+		f.effectiveness = f.typeEffectiveness(b.chart)
+		if f.effectiveness == 0 {
+			continue
+		}
+		rolls := f.rolls()
+		if rolls[0] <= best {
+			continue
+		}
+
+		best = rolls[0]
+		m.Move = move.Name
+		m.MoveType = move.Type
+		m.Effectiveness = f.effectiveness
+		m.MinPercent = percentOf(rolls[0], o.stats.HP)
+		m.MaxPercent = percentOf(rolls[len(rolls)-1], o.stats.HP)
+	}
+
+	return m
+}
+
+// matchupScore rewards damage dealt up to a KO, moving first and resisting
+// the opponent's types
+func matchupScore(m dto.Matchup) float64 {
+	score := min(m.MinPercent, 100)
+	if m.Faster {
+		score *= 1.25
+	}
+	return score / max(m.Threat, 0.25)
+}
+
+// plan sorts counters by score and keeps the best limit of them
+func (b *trainerBattle) plan(source string, counters []*dto.Counter, limit int) *dto.BattlePlan {
+	sort.SliceStable(counters, func(i, j int) bool {
+		return counters[i].Score > counters[j].Score
+	})
+
+	plan := &dto.BattlePlan{
+		Trainer:  *b.trainer,
+		LevelCap: b.levelCap,
+		Source:   source,
+		Counters: make([]dto.Counter, 0, min(limit, len(counters))),
+	}
+	for _, c := range counters[:min(limit, len(counters))] {
+		plan.Counters = append(plan.Counters, *c)
+	}
+	return plan
+}
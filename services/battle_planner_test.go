@@ -0,0 +1,140 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/db"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBattlePlanner plans against Brock in red: Geodude and Onix. Squirtle
+// and Pikachu can be caught in red, Pidgey only in blue.
+func newTestBattlePlanner() *BattlePlanner {
+	trainerRepo := new(MockTrainerRepo)
+	trainerRepo.On("GetTrainerByID", 1).Return(&dto.Trainer{
+		ID: 1, Name: "Brock", Role: dto.TrainerGymLeader, VersionID: 1, Battle: 1,
+		Team: []dto.TrainerPokemon{
+			{Slot: 1, SpeciesID: 74, SpeciesName: "geodude", Level: 12, Moves: []string{"tackle"}},
+			{Slot: 2, SpeciesID: 95, SpeciesName: "onix", Level: 14, Moves: []string{"tackle", "screech"}},
+		},
+	}, nil)
+	trainerRepo.On("GetTrainerByID", 2).Return(nil, fmt.Errorf("trainer 2 %w", db.ErrNotFound))
+
+	versionRepo := new(MockVersionRepo)
+	versionRepo.On("GetVersionByID", 1).Return(&dto.Version{ID: 1, Name: "red", VersionGroupID: 1}, nil)
+	versionRepo.On("GetVersionGroupByID", 1).Return(&dto.VersionGroup{ID: 1, Name: "red-blue", GenerationName: "generation-i"}, nil)
+
+	pokemonRepo := new(MockPokemonRepo)
+	for _, p := range []struct {
+		pokemon *dto.Pokemon
+		types   []string
+	}{
+		{&dto.Pokemon{ID: 74, Name: "geodude", HP: 40, Attack: 80, Defense: 100, SpecialAttack: 30, SpecialDefense: 30, Speed: 20}, []string{"rock", "ground"}},
+		{&dto.Pokemon{ID: 95, Name: "onix", HP: 35, Attack: 45, Defense: 160, SpecialAttack: 30, SpecialDefense: 45, Speed: 70}, []string{"rock", "ground"}},
+		{&dto.Pokemon{ID: 7, Name: "squirtle", HP: 44, Attack: 48, Defense: 65, SpecialAttack: 50, SpecialDefense: 64, Speed: 43}, []string{"water"}},
+		{&dto.Pokemon{ID: 25, Name: "pikachu", HP: 35, Attack: 55, Defense: 30, SpecialAttack: 50, SpecialDefense: 40, Speed: 90}, []string{"electric"}},
+	} {
+		pokemonRepo.On("GetPokemonByID", p.pokemon.ID).Return(p.pokemon, nil)
+		pokemonRepo.On("GetPokemonTypes", p.pokemon.ID, 1).Return(p.types, nil)
+	}
+	pokemonRepo.On("GetPokemonByID", 151).Return(nil, fmt.Errorf("pokemon 151 %w", db.ErrNotFound))
+
+	moveRepo := new(MockMoveRepo)
+	moveRepo.On("GetPokemonMoves", 7, 1).Return([]*dto.PokemonMove{
+		{Move: dto.Move{ID: 33, Name: "tackle", Type: "normal", Power: 35, DamageClass: "physical"}, LearnMethod: "level-up", LevelLearnedAt: 1},
+		{Move: dto.Move{ID: 39, Name: "tail-whip", Type: "normal", DamageClass: "status"}, LearnMethod: "level-up", LevelLearnedAt: 1},
+		{Move: dto.Move{ID: 55, Name: "water-gun", Type: "water", Power: 40, DamageClass: "special"}, LearnMethod: "level-up", LevelLearnedAt: 8},
+		{Move: dto.Move{ID: 56, Name: "hydro-pump", Type: "water", Power: 120, DamageClass: "special"}, LearnMethod: "level-up", LevelLearnedAt: 42},
+		{Move: dto.Move{ID: 57, Name: "surf", Type: "water", Power: 95, DamageClass: "special"}, LearnMethod: "machine"},
+	}, nil)
+	moveRepo.On("GetPokemonMoves", 25, 1).Return([]*dto.PokemonMove{
+		{Move: dto.Move{ID: 84, Name: "thunder-shock", Type: "electric", Power: 40, DamageClass: "special"}, LearnMethod: "level-up", LevelLearnedAt: 1},
+		{Move: dto.Move{ID: 98, Name: "quick-attack", Type: "normal", Power: 40, DamageClass: "physical"}, LearnMethod: "level-up", LevelLearnedAt: 16},
+	}, nil)
+
+	typeRepo := new(MockTypeRepo)
+	typeRepo.On("GetTypeChart", 1).Return(&dto.TypeChart{
+		Multipliers: map[string]map[string]float64{
+			"water":    {"rock": 2, "ground": 2, "water": 0.5},
+			"electric": {"ground": 0, "water": 2},
+			"normal":   {"rock": 0.5},
+			"ground":   {"electric": 2},
+		},
+		DamageClasses: map[string]string{"normal": "physical", "rock": "physical", "ground": "physical", "water": "special", "electric": "special"},
+	}, nil)
+
+	encounterRepo := new(MockEncounterRepo)
+	encounterRepo.On("GetSpeciesLocations", 1).Return([]*dto.SpeciesLocation{
+		{SpeciesID: 7, SpeciesName: "squirtle", VersionID: 1, LocationName: "Pallet Town"},
+		{SpeciesID: 7, SpeciesName: "squirtle", VersionID: 1, LocationName: "Route 25"},
+		{SpeciesID: 16, SpeciesName: "pidgey", VersionID: 2, LocationName: "Route 1"},
+		{SpeciesID: 25, SpeciesName: "pikachu", VersionID: 1, LocationName: "Viridian Forest"},
+		{SpeciesID: 151, SpeciesName: "mew", VersionID: 1, LocationName: "Faraway Island"},
+	}, nil)
+
+	statCalculator := NewStatCalculator(pokemonRepo, versionRepo, new(MockNatureRepo))
+	return NewBattlePlanner(trainerRepo, nil, pokemonRepo, moveRepo, versionRepo, typeRepo, encounterRepo, statCalculator)
+}
+
+func TestPlanForPool(t *testing.T) {
+	planner := newTestBattlePlanner()
+
+	plan, err := planner.PlanForPool(1)
+	require.NoError(t, err)
+	assert.Equal(t, "Brock", plan.Trainer.Name)
+	assert.Equal(t, dto.PlanSourcePool, plan.Source)
+	assert.Equal(t, 14, plan.LevelCap)
+
+	// Pidgey is blue only and Mew has no pokemon data
+	require.Len(t, plan.Counters, 2)
+	squirtle, pikachu := plan.Counters[0], plan.Counters[1]
+
+	assert.Equal(t, "squirtle", squirtle.Name)
+	assert.Equal(t, 14, squirtle.Level)
+	require.Len(t, squirtle.Matchups, 2)
+	vsGeodude := squirtle.Matchups[0]
+	assert.Equal(t, "geodude", vsGeodude.Opponent)
+	assert.Equal(t, "water-gun", vsGeodude.Move)
+	assert.Equal(t, 4.0, vsGeodude.Effectiveness)
+	assert.Equal(t, 1.0, vsGeodude.Threat)
+	assert.True(t, vsGeodude.Faster)
+	assert.Greater(t, vsGeodude.MinPercent, 50.0)
+	assert.LessOrEqual(t, vsGeodude.MinPercent, vsGeodude.MaxPercent)
+	assert.Greater(t, squirtle.Score, 0.0)
+
+	// Thunder Shock can't hit ground types and Quick Attack comes too late
+	assert.Equal(t, "pikachu", pikachu.Name)
+	assert.Empty(t, pikachu.Matchups[0].Move)
+	assert.Equal(t, 2.0, pikachu.Matchups[0].Threat)
+	assert.Equal(t, 0.0, pikachu.Score)
+}
+
+func TestPlanForPoolUnknownTrainer(t *testing.T) {
+	planner := newTestBattlePlanner()
+
+	_, err := planner.PlanForPool(2)
+	assert.ErrorIs(t, err, db.ErrNotFound)
+}
+
+func TestDamagingMoves(t *testing.T) {
+	learnset := []*dto.PokemonMove{
+		{Move: dto.Move{ID: 33, Name: "tackle", Power: 35, DamageClass: "physical"}, LearnMethod: "level-up", LevelLearnedAt: 1},
+		{Move: dto.Move{ID: 33, Name: "tackle", Power: 35, DamageClass: "physical"}, LearnMethod: "level-up", LevelLearnedAt: 5},
+		{Move: dto.Move{ID: 45, Name: "growl", DamageClass: "status"}, LearnMethod: "level-up", LevelLearnedAt: 1},
+		{Move: dto.Move{ID: 69, Name: "seismic-toss", DamageClass: "physical"}, LearnMethod: "level-up", LevelLearnedAt: 1},
+		{Move: dto.Move{ID: 22, Name: "vine-whip", Power: 45, DamageClass: "physical"}, LearnMethod: "level-up", LevelLearnedAt: 13},
+		{Move: dto.Move{ID: 92, Name: "toxic", DamageClass: "status"}, LearnMethod: "machine"},
+	}
+
+	moves := damagingMoves(learnset, 10)
+	require.Len(t, moves, 1)
+	assert.Equal(t, "tackle", moves[0].Name)
+
+	moves = damagingMoves(learnset, 13)
+	assert.Len(t, moves, 2)
+	assert.Empty(t, damagingMoves(learnset[2:4], 100))
+	assert.Nil(t, damagingMoves(nil, 100))
+}
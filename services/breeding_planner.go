@@ -0,0 +1,155 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
+)
+
+const (
+	maxBreedingGenerations = 4  // Longest chain searched, counted in parents
+	maxBreedingChains      = 20 // Chains returned at most
+	maxPathsPerSpecies     = 5  // Ways kept to reach the target from one species
+)
+
+// BreedingPlanner finds the parents that can pass an egg move down to a
+// species. Only fathers pass moves to a different species, so every parent in
+// a chain must be able to be male and every child must be able to be female.
+type BreedingPlanner struct {
+	pokemonRepo PokemonRepo
+	versionRepo VersionRepo
+}
+
+func NewBreedingPlanner(pokemonRepo PokemonRepo, versionRepo VersionRepo) *BreedingPlanner {
+	return &BreedingPlanner{
+		pokemonRepo: pokemonRepo,
+		versionRepo: versionRepo,
+	}
+}
+
+// FindChains returns the shortest breeding chains that pass a move to a
+// species in a version group. A chain starts with a species that learns the
+// move by level-up, followed by species that learn it as an egg move and share
+// an egg group with the step before them. Chains are sorted by the level the
+// first parent learns the move at.
+func (p *BreedingPlanner) FindChains(speciesID, moveID, versionGroupID int) ([]*dto.BreedingChain, error) {
+	versionGroup, err := p.versionRepo.GetVersionGroupByID(versionGroupID)
+	if err != nil {
+		return nil, err
+	}
+	generation, err := utils.GenerationNumber(versionGroup.GenerationName)
+	if err != nil {
+		return nil, err
+	}
+	if generation < 2 {
+		return nil, fmt.Errorf("%w: there is no breeding in %s", ErrInvalidInput, versionGroup.Name)
+	}
+
+	candidates, err := p.pokemonRepo.GetBreedingCandidates(moveID, versionGroupID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]*dto.BreedingCandidate, len(candidates))
+	for _, c := range candidates {
+		byID[c.SpeciesID] = c
+	}
+
+	target := byID[speciesID]
+	if target == nil || !target.EggMove {
+		return nil, fmt.Errorf("%w: species %d can't learn move %d as an egg move in %s", ErrInvalidInput, speciesID, moveID, versionGroup.Name)
+	}
+
+	chains := []*dto.BreedingChain{}
+	if !canBeMother(target) {
+		return chains, nil
+	}
+
+	// Breadth-first from the target towards the parents. paths holds the
+	// shortest ways from a species down to the target, species reached at an
+	// earlier generation are never revisited.
+	paths := map[int][][]int{speciesID: {{speciesID}}}
+	frontier := []int{speciesID}
+	for generations := 1; generations <= maxBreedingGenerations && len(chains) == 0 && len(frontier) > 0; generations++ {
+		next := make(map[int][][]int)
+		for _, childID := range frontier {
+			child := byID[childID]
+			for _, father := range candidates {
+				if _, seen := paths[father.SpeciesID]; seen || !canBeFather(father) {
+					continue
+				}
+				if sharedEggGroup(father, child) == "" {
+					continue
+				}
+
+				for _, path := range paths[childID] {
+					chain := append([]int{father.SpeciesID}, path...)
+					if father.LevelLearnedAt != nil {
+						chains = append(chains, buildBreedingChain(chain, byID))
+					} else if len(next[father.SpeciesID]) < maxPathsPerSpecies {
+						next[father.SpeciesID] = append(next[father.SpeciesID], chain)
+					}
+				}
+			}
+		}
+
+		frontier = frontier[:0]
+		for id, ps := range next {
+			paths[id] = ps
+			if canBeMother(byID[id]) {
+				frontier = append(frontier, id)
+			}
+		}
+		slices.Sort(frontier)
+	}
+
+	slices.SortStableFunc(chains, func(a, b *dto.BreedingChain) int {
+		if a.Steps[0].Level != b.Steps[0].Level {
+			return a.Steps[0].Level - b.Steps[0].Level
+		}
+		return strings.Compare(a.Steps[0].Name, b.Steps[0].Name)
+	})
+	if len(chains) > maxBreedingChains {
+		chains = chains[:maxBreedingChains]
+	}
+
+	return chains, nil
+}
+
+func buildBreedingChain(speciesIDs []int, byID map[int]*dto.BreedingCandidate) *dto.BreedingChain {
+	chain := &dto.BreedingChain{Steps: make([]dto.BreedingStep, len(speciesIDs))}
+	for i, id := range speciesIDs {
+		c := byID[id]
+		step := dto.BreedingStep{SpeciesID: c.SpeciesID, Name: c.Name, LearnMethod: "egg"}
+		if i == 0 {
+			step.LearnMethod = "level-up"
+			step.Level = *c.LevelLearnedAt
+		}
+		if i < len(speciesIDs)-1 {
+			step.EggGroup = sharedEggGroup(c, byID[speciesIDs[i+1]])
+		}
+		chain.Steps[i] = step
+	}
+	return chain
+}
+
+// sharedEggGroup returns the first egg group two species can breed through,
+// Ditto never passes moves
+func sharedEggGroup(a, b *dto.BreedingCandidate) string {
+	for _, group := range a.EggGroups {
+		if group != "no-eggs" && group != "ditto" && slices.Contains(b.EggGroups, group) {
+			return group
+		}
+	}
+	return ""
+}
+
+func canBeFather(c *dto.BreedingCandidate) bool {
+	return c.GenderRate >= 0 && c.GenderRate < 8
+}
+
+func canBeMother(c *dto.BreedingCandidate) bool {
+	return c.GenderRate > 0
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBreedingPlanner(candidates []*dto.BreedingCandidate) *BreedingPlanner {
+	pokemonRepo := new(MockPokemonRepo)
+	pokemonRepo.On("GetBreedingCandidates", 1, 8).Return(candidates, nil)
+
+	versionRepo := new(MockVersionRepo)
+	versionRepo.On("GetVersionGroupByID", 1).Return(&dto.VersionGroup{ID: 1, Name: "red-blue", GenerationName: "generation-i"}, nil)
+	versionRepo.On("GetVersionGroupByID", 8).Return(&dto.VersionGroup{ID: 8, Name: "diamond-pearl", GenerationName: "generation-iv"}, nil)
+
+	return NewBreedingPlanner(pokemonRepo, versionRepo)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestFindBreedingChains(t *testing.T) {
+	bulbasaur := &dto.BreedingCandidate{SpeciesID: 1, Name: "bulbasaur", GenderRate: 1, EggGroups: []string{"monster", "plant"}, EggMove: true}
+	tropius := &dto.BreedingCandidate{SpeciesID: 357, Name: "tropius", GenderRate: 4, EggGroups: []string{"monster", "plant"}, EggMove: true}
+	exeggcute := &dto.BreedingCandidate{SpeciesID: 102, Name: "exeggcute", GenderRate: 4, EggGroups: []string{"plant"}, LevelLearnedAt: intPtr(17)}
+	nidoranF := &dto.BreedingCandidate{SpeciesID: 29, Name: "nidoran-f", GenderRate: 8, EggGroups: []string{"field", "monster"}, LevelLearnedAt: intPtr(1)}
+	chikorita := &dto.BreedingCandidate{SpeciesID: 152, Name: "chikorita", GenderRate: 1, EggGroups: []string{"monster", "plant"}, LevelLearnedAt: intPtr(31)}
+	oddish := &dto.BreedingCandidate{SpeciesID: 43, Name: "oddish", GenderRate: 4, EggGroups: []string{"plant"}, LevelLearnedAt: intPtr(25)}
+
+	t.Run("Direct fathers", func(t *testing.T) {
+		planner := newTestBreedingPlanner([]*dto.BreedingCandidate{bulbasaur, tropius, exeggcute, nidoranF, chikorita, oddish})
+
+		chains, err := planner.FindChains(1, 1, 8)
+		require.NoError(t, err)
+		require.Len(t, chains, 3)
+		assert.Equal(t, []dto.BreedingStep{
+			{SpeciesID: 102, Name: "exeggcute", LearnMethod: "level-up", Level: 17, EggGroup: "plant"},
+			{SpeciesID: 1, Name: "bulbasaur", LearnMethod: "egg"},
+		}, chains[0].Steps)
+		assert.Equal(t, "oddish", chains[1].Steps[0].Name)
+		assert.Equal(t, "chikorita", chains[2].Steps[0].Name)
+	})
+
+	t.Run("Chain through an egg move father", func(t *testing.T) {
+		// Only nidoran-f learns it by level-up in the monster group, but can't be a father
+		plantless := *bulbasaur
+		plantless.EggGroups = []string{"monster"}
+		planner := newTestBreedingPlanner([]*dto.BreedingCandidate{&plantless, tropius, exeggcute, nidoranF})
+
+		chains, err := planner.FindChains(1, 1, 8)
+		require.NoError(t, err)
+		require.Len(t, chains, 1)
+		assert.Equal(t, []dto.BreedingStep{
+			{SpeciesID: 102, Name: "exeggcute", LearnMethod: "level-up", Level: 17, EggGroup: "plant"},
+			{SpeciesID: 357, Name: "tropius", LearnMethod: "egg", EggGroup: "monster"},
+			{SpeciesID: 1, Name: "bulbasaur", LearnMethod: "egg"},
+		}, chains[0].Steps)
+	})
+
+	t.Run("No compatible parents", func(t *testing.T) {
+		planner := newTestBreedingPlanner([]*dto.BreedingCandidate{bulbasaur, nidoranF})
+
+		chains, err := planner.FindChains(1, 1, 8)
+		require.NoError(t, err)
+		assert.Empty(t, chains)
+	})
+
+	t.Run("Not an egg move", func(t *testing.T) {
+		planner := newTestBreedingPlanner([]*dto.BreedingCandidate{bulbasaur, exeggcute})
+
+		_, err := planner.FindChains(102, 1, 8)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+
+	t.Run("No breeding in generation 1", func(t *testing.T) {
+		planner := newTestBreedingPlanner(nil)
+
+		_, err := planner.FindChains(1, 1, 1)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
@@ -0,0 +1,410 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
+)
+
+// Combatant is one side of a damage calculation. Stats are calculated from IVs,
+// EVs and Nature unless Stats is set, CurrentHP defaults to the maximum HP.
+// In Gen 1 the Special stat is read from SpecialAttack for both sides.
+type Combatant struct {
+	PokemonID int         `json:"pokemonId"`
+	Level     int         `json:"level"`
+	Stats     *StatSpread `json:"stats,omitempty"`
+	IVs       StatSpread  `json:"ivs"`
+	EVs       StatSpread  `json:"evs"`
+	Nature    string      `json:"nature"`
+	Ability   string      `json:"ability"`  // Ignored before Gen 3
+	HeldItem  string      `json:"heldItem"` // Ignored in Gen 1
+	CurrentHP int         `json:"currentHp"`
+}
+
+type DamageInput struct {
+	Attacker       Combatant `json:"attacker"`
+	Defender       Combatant `json:"defender"`
+	MoveID         int       `json:"moveId"`
+	VersionGroupID int       `json:"versionGroupId"`
+	Critical       bool      `json:"critical"`
+}
+
+// DamageResult holds every damage roll of a single hit. Percentages are of the
+// defender's maximum HP, KOChance is the share of rolls that KO from CurrentHP.
+type DamageResult struct {
+	Move          string  `json:"move"`
+	MoveType      string  `json:"moveType"`
+	DamageClass   string  `json:"damageClass"` // After the pre-Gen 4 split by type
+	Power         int     `json:"power"`
+	STAB          bool    `json:"stab"`
+	Effectiveness float64 `json:"effectiveness"`
+	Critical      bool    `json:"critical"`
+	Rolls         []int   `json:"rolls"` // Lowest roll first
+	Min           int     `json:"min"`
+	Max           int     `json:"max"`
+	MinPercent    float64 `json:"minPercent"`
+	MaxPercent    float64 `json:"maxPercent"`
+	DefenderHP    int     `json:"defenderHp"`
+	KOChance      float64 `json:"koChance"` // 0-1
+	HitsToKO      int     `json:"hitsToKo"` // Guaranteed hits to KO, 0 if the move does no damage
+}
+
+// abilityImmunities are abilities that make the holder immune to a move type
+var abilityImmunities = map[string]string{
+	"levitate":      "ground",
+	"flash-fire":    "fire",
+	"water-absorb":  "water",
+	"dry-skin":      "water",
+	"storm-drain":   "water",
+	"volt-absorb":   "electric",
+	"motor-drive":   "electric",
+	"lightning-rod": "electric",
+	"sap-sipper":    "grass",
+}
+
+type DamageCalculator struct {
+	statCalculator *StatCalculator
+	pokemonRepo    PokemonRepo
+	versionRepo    VersionRepo
+	moveRepo       MoveRepo
+	typeRepo       TypeRepo
+}
+
+func NewDamageCalculator(statCalculator *StatCalculator, pokemonRepo PokemonRepo, versionRepo VersionRepo, moveRepo MoveRepo, typeRepo TypeRepo) *DamageCalculator {
+	return &DamageCalculator{
+		statCalculator: statCalculator,
+		pokemonRepo:    pokemonRepo,
+		versionRepo:    versionRepo,
+		moveRepo:       moveRepo,
+		typeRepo:       typeRepo,
+	}
+}
+
+// Calculate returns the damage rolls of a move using the mechanics of the
+// version group's generation: the Gen 1-2 formula with 217-255 rolls, the
+// Gen 3+ formula with 85-100 rolls, damage class by type before Gen 4 and the
+// generation's type chart and crit multiplier.
+func (c *DamageCalculator) Calculate(in *DamageInput) (*DamageResult, error) {
+	versionGroup, err := c.versionRepo.GetVersionGroupByID(in.VersionGroupID)
+	if err != nil {
+		return nil, err
+	}
+	generation, err := utils.GenerationNumber(versionGroup.GenerationName)
+	if err != nil {
+		return nil, err
+	}
+
+	move, err := c.moveRepo.GetMoveForVersionGroup(in.MoveID, in.VersionGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if move.DamageClass == "status" {
+		return nil, fmt.Errorf("move %s is a status move", move.Name)
+	}
+	if move.Power == 0 {
+		return nil, fmt.Errorf("move %s has no base power", move.Name)
+	}
+
+	chart, err := c.typeRepo.GetTypeChart(generation)
+	if err != nil {
+		return nil, err
+	}
+
+	damageClass := move.DamageClass
+	if generation < 4 {
+		damageClass = chart.DamageClasses[move.Type]
+		if damageClass == "" {
+			return nil, fmt.Errorf("type %s has no damage class in generation %d", move.Type, generation)
+		}
+	}
+
+	attacker, err := c.prepareCombatant(&in.Attacker, in.VersionGroupID, generation)
+	if err != nil {
+		return nil, fmt.Errorf("attacker: %w", err)
+	}
+	defender, err := c.prepareCombatant(&in.Defender, in.VersionGroupID, generation)
+	if err != nil {
+		return nil, fmt.Errorf("defender: %w", err)
+	}
+
+	calc := &damageFormula{
+		generation:  generation,
+		attacker:    attacker,
+		defender:    defender,
+		moveType:    move.Type,
+		damageClass: damageClass,
+		power:       move.Power,
+		critical:    in.Critical,
+		stab:        slices.Contains(attacker.types, move.Type),
+	}
+	calc.effectiveness = calc.typeEffectiveness(chart)
+
+	rolls := calc.rolls()
+	result := &DamageResult{
+		Move:          move.Name,
+		MoveType:      move.Type,
+		DamageClass:   damageClass,
+		Power:         move.Power,
+		STAB:          calc.stab,
+		Effectiveness: calc.effectiveness,
+		Critical:      in.Critical,
+		Rolls:         rolls,
+		Min:           rolls[0],
+		Max:           rolls[len(rolls)-1],
+		DefenderHP:    defender.currentHP,
+	}
+	result.MinPercent = percentOf(result.Min, defender.stats.HP)
+	result.MaxPercent = percentOf(result.Max, defender.stats.HP)
+
+	kos := 0
+	for _, r := range rolls {
+		if r >= defender.currentHP {
+			kos++
+		}
+	}
+	result.KOChance = float64(kos) / float64(len(rolls))
+	if result.Min > 0 {
+		result.HitsToKO = (defender.currentHP + result.Min - 1) / result.Min
+	}
+
+	return result, nil
+}
+
+// combatant is a Combatant with resolved stats and types
+type combatant struct {
+	level     int
+	stats     StatSpread
+	types     []string
+	ability   string
+	item      string
+	currentHP int
+}
+
+func (c *DamageCalculator) prepareCombatant(in *Combatant, versionGroupID, generation int) (*combatant, error) {
+	if in.Level < 1 || in.Level > 100 {
+		return nil, fmt.Errorf("level must be between 1 and 100, got %d", in.Level)
+	}
+
+	var stats StatSpread
+	if in.Stats != nil {
+		stats = *in.Stats
+	} else {
+		calculated, err := c.statCalculator.CalculateStats(&StatInput{
+			PokemonID:      in.PokemonID,
+			VersionGroupID: versionGroupID,
+			Level:          in.Level,
+			Nature:         in.Nature,
+			IVs:            in.IVs,
+			EVs:            in.EVs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		stats = *calculated
+	}
+	if stats.HP < 1 {
+		return nil, fmt.Errorf("HP must be at least 1, got %d", stats.HP)
+	}
+
+	types, err := c.pokemonRepo.GetPokemonTypes(in.PokemonID, generation)
+	if err != nil {
+		return nil, err
+	}
+
+	prepared := &combatant{
+		level:     in.Level,
+		stats:     stats,
+		types:     types,
+		currentHP: stats.HP,
+	}
+	if in.CurrentHP != 0 {
+		if in.CurrentHP < 0 || in.CurrentHP > stats.HP {
+			return nil, fmt.Errorf("current HP must be between 1 and %d, got %d", stats.HP, in.CurrentHP)
+		}
+		prepared.currentHP = in.CurrentHP
+	}
+	if generation >= 3 {
+		prepared.ability = in.Ability
+	}
+	if generation >= 2 {
+		prepared.item = in.HeldItem
+	}
+
+	return prepared, nil
+}
+
+// damageFormula holds everything needed to compute the damage rolls of one hit
+type damageFormula struct {
+	generation    int
+	attacker      *combatant
+	defender      *combatant
+	moveType      string
+	damageClass   string
+	power         int
+	critical      bool
+	stab          bool
+	effectiveness float64
+}
+
+func (f *damageFormula) typeEffectiveness(chart *dto.TypeChart) float64 {
+	effectiveness := 1.0
+	for _, defendingType := range f.defender.types {
+		if multiplier, ok := chart.Multipliers[f.moveType][defendingType]; ok {
+			effectiveness *= multiplier
+		}
+	}
+
+	if abilityImmunities[f.defender.ability] == f.moveType {
+		return 0
+	}
+	if f.defender.ability == "wonder-guard" && effectiveness <= 1 {
+		return 0
+	}
+	return effectiveness
+}
+
+// stats returns the attacking and defending stat for the move's damage class
+// after ability and item modifiers
+func (f *damageFormula) stats() (int, int) {
+	var attack, defense int
+	switch {
+	case f.damageClass == "physical":
+		attack, defense = f.attacker.stats.Attack, f.defender.stats.Defense
+	case f.generation == 1:
+		// Gen 1 has a single Special stat
+		attack, defense = f.attacker.stats.SpecialAttack, f.defender.stats.SpecialAttack
+	default:
+		attack, defense = f.attacker.stats.SpecialAttack, f.defender.stats.SpecialDefense
+	}
+
+	physical := f.damageClass == "physical"
+	if physical && (f.attacker.ability == "huge-power" || f.attacker.ability == "pure-power") {
+		attack *= 2
+	}
+	if (physical && f.attacker.item == "choice-band") || (!physical && f.attacker.item == "choice-specs") {
+		attack = attack * 3 / 2
+	}
+	if f.defender.ability == "thick-fat" && (f.moveType == "fire" || f.moveType == "ice") {
+		attack /= 2
+	}
+	if !physical && f.defender.item == "assault-vest" {
+		defense = defense * 3 / 2
+	}
+
+	return max(attack, 1), max(defense, 1)
+}
+
+func (f *damageFormula) rolls() []int {
+	if f.generation <= 2 {
+		return f.legacyRolls()
+	}
+
+	power := f.power
+	if f.attacker.ability == "technician" && power <= 60 {
+		power = power * 3 / 2
+	}
+	attack, defense := f.stats()
+
+	base := (2*f.attacker.level/5+2)*power*attack/defense/50 + 2
+	if f.critical {
+		if f.generation >= 6 {
+			base = f.modify(base, 3, 2)
+		} else {
+			base *= 2
+		}
+		if f.attacker.ability == "sniper" {
+			base = f.modify(base, 3, 2)
+		}
+	}
+
+	rolls := make([]int, 0, 16)
+	for r := 85; r <= 100; r++ {
+		damage := base * r / 100
+		if f.stab {
+			if f.attacker.ability == "adaptability" {
+				damage *= 2
+			} else {
+				damage = f.modify(damage, 3, 2)
+			}
+		}
+		damage = int(float64(damage) * f.effectiveness)
+		damage = f.finalModifiers(damage)
+		if f.effectiveness > 0 {
+			damage = max(damage, 1)
+		}
+		rolls = append(rolls, damage)
+	}
+	return rolls
+}
+
+// finalModifiers applies the Gen 3+ ability and item damage modifiers
+func (f *damageFormula) finalModifiers(damage int) int {
+	if f.effectiveness > 1 {
+		switch f.defender.ability {
+		case "filter", "solid-rock", "prism-armor":
+			damage = f.modify(damage, 3, 4)
+		}
+		if f.attacker.item == "expert-belt" {
+			damage = f.modify(damage, 6, 5)
+		}
+	}
+	if f.effectiveness > 0 && f.effectiveness < 1 && f.attacker.ability == "tinted-lens" {
+		damage *= 2
+	}
+	if (f.defender.ability == "multiscale" || f.defender.ability == "shadow-shield") && f.defender.currentHP == f.defender.stats.HP {
+		damage = f.modify(damage, 1, 2)
+	}
+	if f.attacker.item == "life-orb" {
+		damage = f.modify(damage, 13, 10)
+	}
+	return damage
+}
+
+// modify applies a multiplier, Gen 5+ rounds half down where Gen 3-4 truncate
+func (f *damageFormula) modify(value, numerator, denominator int) int {
+	if f.generation >= 5 {
+		return (2*value*numerator + denominator - 1) / (2 * denominator)
+	}
+	return value * numerator / denominator
+}
+
+// legacyRolls uses the Gen 1-2 formula, critical hits double the level in
+// Gen 1 and the damage in Gen 2
+func (f *damageFormula) legacyRolls() []int {
+	attack, defense := f.stats()
+	if attack > 255 || defense > 255 {
+		attack, defense = max(attack/4, 1), max(defense/4, 1)
+	}
+
+	level := f.attacker.level
+	if f.critical && f.generation == 1 {
+		level *= 2
+	}
+
+	base := (2*level/5 + 2) * f.power * attack / defense / 50
+	if f.critical && f.generation == 2 {
+		base *= 2
+	}
+	base = min(base, 997) + 2
+	if f.stab {
+		base += base / 2
+	}
+	base = int(float64(base) * f.effectiveness)
+
+	rolls := make([]int, 0, 39)
+	for r := 217; r <= 255; r++ {
+		damage := base
+		if base > 1 {
+			damage = base * r / 255
+		}
+		rolls = append(rolls, damage)
+	}
+	return rolls
+}
+
+func percentOf(damage, hp int) float64 {
+	return math.Round(float64(damage)*1000/float64(hp)) / 10
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockTypeRepo struct {
+	mock.Mock
+}
+
+func (m *MockTypeRepo) InsertType(t *external.Type) error {
+	args := m.Called(t)
+	return args.Error(0)
+}
+
+func (m *MockTypeRepo) GetTypeChart(generation int) (*dto.TypeChart, error) {
+	args := m.Called(generation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TypeChart), args.Error(1)
+}
+
+func newTestDamageCalculator() *DamageCalculator {
+	pokemonRepo := new(MockPokemonRepo)
+	pokemonRepo.On("GetPokemonTypes", 471, mock.Anything).Return([]string{"ice"}, nil)
+	pokemonRepo.On("GetPokemonTypes", 445, mock.Anything).Return([]string{"dragon", "ground"}, nil)
+	pokemonRepo.On("GetPokemonTypes", 25, mock.Anything).Return([]string{"electric"}, nil)
+	pokemonRepo.On("GetPokemonTypes", 130, mock.Anything).Return([]string{"water", "flying"}, nil)
+	pokemonRepo.On("GetPokemonTypes", 94, mock.Anything).Return([]string{"ghost", "poison"}, nil)
+
+	versionRepo := new(MockVersionRepo)
+	versionRepo.On("GetVersionGroupByID", 1).Return(&dto.VersionGroup{ID: 1, Name: "red-blue", GenerationName: "generation-i"}, nil)
+	versionRepo.On("GetVersionGroupByID", 5).Return(&dto.VersionGroup{ID: 5, Name: "ruby-sapphire", GenerationName: "generation-iii"}, nil)
+	versionRepo.On("GetVersionGroupByID", 8).Return(&dto.VersionGroup{ID: 8, Name: "diamond-pearl", GenerationName: "generation-iv"}, nil)
+
+	moveRepo := new(MockMoveRepo)
+	iceFang := &dto.Move{ID: 423, Name: "ice-fang", Type: "ice", Power: 65, DamageClass: "physical"}
+	moveRepo.On("GetMoveForVersionGroup", 423, 8).Return(iceFang, nil)
+	moveRepo.On("GetMoveForVersionGroup", 85, 1).Return(&dto.Move{ID: 85, Name: "thunderbolt", Type: "electric", Power: 95, DamageClass: "special"}, nil)
+	moveRepo.On("GetMoveForVersionGroup", 247, 5).Return(&dto.Move{ID: 247, Name: "shadow-ball", Type: "ghost", Power: 80, DamageClass: "special"}, nil)
+	moveRepo.On("GetMoveForVersionGroup", 104, 8).Return(&dto.Move{ID: 104, Name: "double-team", Type: "normal", DamageClass: "status"}, nil)
+
+	chart := &dto.TypeChart{
+		Multipliers: map[string]map[string]float64{
+			"ice":      {"dragon": 2, "ground": 2, "water": 0.5},
+			"electric": {"water": 2, "flying": 2, "ground": 0},
+			"ghost":    {"ghost": 2, "normal": 0},
+		},
+		DamageClasses: map[string]string{"ice": "special", "electric": "special", "ghost": "physical"},
+	}
+	typeRepo := new(MockTypeRepo)
+	typeRepo.On("GetTypeChart", mock.Anything).Return(chart, nil)
+
+	statCalculator := NewStatCalculator(pokemonRepo, versionRepo, new(MockNatureRepo))
+	return NewDamageCalculator(statCalculator, pokemonRepo, versionRepo, moveRepo, typeRepo)
+}
+
+func TestCalculateDamage(t *testing.T) {
+	calculator := newTestDamageCalculator()
+
+	// Level 75 Glaceon's Ice Fang against Garchomp
+	glaceonVsGarchomp := func() *DamageInput {
+		return &DamageInput{
+			Attacker:       Combatant{PokemonID: 471, Level: 75, Stats: &StatSpread{HP: 201, Attack: 123}},
+			Defender:       Combatant{PokemonID: 445, Level: 65, Stats: &StatSpread{HP: 243, Defense: 163}},
+			MoveID:         423,
+			VersionGroupID: 8,
+		}
+	}
+
+	t.Run("Gen 4 STAB and 4x effectiveness", func(t *testing.T) {
+		result, err := calculator.Calculate(glaceonVsGarchomp())
+		require.NoError(t, err)
+		assert.Equal(t, "physical", result.DamageClass)
+		assert.True(t, result.STAB)
+		assert.Equal(t, 4.0, result.Effectiveness)
+		assert.Len(t, result.Rolls, 16)
+		assert.Equal(t, 168, result.Min)
+		assert.Equal(t, 196, result.Max)
+		assert.Equal(t, 69.1, result.MinPercent)
+		assert.Equal(t, 0.0, result.KOChance)
+		assert.Equal(t, 2, result.HitsToKO)
+	})
+
+	t.Run("KO chance from current HP", func(t *testing.T) {
+		in := glaceonVsGarchomp()
+		in.Defender.CurrentHP = 180
+		result, err := calculator.Calculate(in)
+		require.NoError(t, err)
+		assert.Greater(t, result.KOChance, 0.0)
+		assert.Less(t, result.KOChance, 1.0)
+		assert.Equal(t, 180, result.DefenderHP)
+	})
+
+	t.Run("Critical hit doubles damage in Gen 4", func(t *testing.T) {
+		in := glaceonVsGarchomp()
+		in.Critical = true
+		result, err := calculator.Calculate(in)
+		require.NoError(t, err)
+		assert.Equal(t, 1.0, result.KOChance)
+	})
+
+	t.Run("Gen 1 formula with the Special stat", func(t *testing.T) {
+		result, err := calculator.Calculate(&DamageInput{
+			Attacker:       Combatant{PokemonID: 25, Level: 50, Stats: &StatSpread{HP: 95, SpecialAttack: 70}},
+			Defender:       Combatant{PokemonID: 130, Level: 50, Stats: &StatSpread{HP: 155, SpecialAttack: 120, SpecialDefense: 200}},
+			MoveID:         85,
+			VersionGroupID: 1,
+		})
+		require.NoError(t, err)
+		assert.Len(t, result.Rolls, 39)
+		assert.Equal(t, 132, result.Min)
+		assert.Equal(t, 156, result.Max)
+		// Only the two highest rolls KO
+		assert.InDelta(t, 2.0/39, result.KOChance, 1e-9)
+	})
+
+	t.Run("Damage class by type before Gen 4", func(t *testing.T) {
+		result, err := calculator.Calculate(&DamageInput{
+			Attacker:       Combatant{PokemonID: 94, Level: 50, Stats: &StatSpread{HP: 120, Attack: 70, SpecialAttack: 150}},
+			Defender:       Combatant{PokemonID: 94, Level: 50, Stats: &StatSpread{HP: 120, Defense: 60, SpecialDefense: 80}},
+			MoveID:         247,
+			VersionGroupID: 5,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "physical", result.DamageClass)
+	})
+
+	t.Run("Defender ability", func(t *testing.T) {
+		in := glaceonVsGarchomp()
+		in.Defender.Ability = "thick-fat"
+		result, err := calculator.Calculate(in)
+		require.NoError(t, err)
+		assert.Equal(t, 84, result.Min)
+		assert.Equal(t, 100, result.Max)
+	})
+
+	t.Run("Type immunity", func(t *testing.T) {
+		in := glaceonVsGarchomp()
+		in.Attacker.PokemonID = 25
+		in.MoveID, in.VersionGroupID = 85, 1
+		result, err := calculator.Calculate(in)
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, result.Effectiveness)
+		assert.Equal(t, 0, result.Max)
+		assert.Equal(t, 0, result.HitsToKO)
+	})
+
+	t.Run("Status moves are rejected", func(t *testing.T) {
+		in := glaceonVsGarchomp()
+		in.MoveID = 104
+		_, err := calculator.Calculate(in)
+		assert.Error(t, err)
+	})
+}
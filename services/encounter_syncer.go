@@ -0,0 +1,194 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/progress"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
+)
+
+type EncounterSyncer struct {
+	client            EncounterAPIClient
+	repo              EncounterRepo
+	rateLimiter       *time.Ticker
+	pokemonEncounters map[int][]external.LocationAreaEncounter // In-memory cache of fetched encounters by Pokemon ID
+	syncedAreas       map[int]bool                             // In-memory cache of synced location area IDs
+	syncedLocations   map[int]bool                             // In-memory cache of synced location IDs
+	mu                sync.Mutex                               // Protects cache maps
+
+	Progress *progress.Tracker // Optional
+}
+
+func NewEncounterSyncer(client EncounterAPIClient, repo EncounterRepo, rateLimiter *time.Ticker) *EncounterSyncer {
+	return &EncounterSyncer{
+		client:            client,
+		repo:              repo,
+		rateLimiter:       rateLimiter,
+		pokemonEncounters: make(map[int][]external.LocationAreaEncounter),
+		syncedAreas:       make(map[int]bool),
+		syncedLocations:   make(map[int]bool),
+	}
+}
+
+// SyncPokemonEncounters inserts the encounters of a Pokemon for a single version,
+// syncing the locations and location areas they reference. Returns the number of
+// encounter slots inserted.
+func (s *EncounterSyncer) SyncPokemonEncounters(pokemonID, versionID int) (int, error) {
+	encounters, err := s.fetchPokemonEncounters(pokemonID)
+	if err != nil {
+		return 0, err
+	}
+
+	inserted := 0
+	for _, e := range encounters {
+		for _, vd := range e.VersionDetails {
+			vID, err := utils.ExtractIDFromURL(vd.Version.Url)
+			if err != nil {
+				return inserted, fmt.Errorf("failed to extract version ID: %w", err)
+			}
+			if vID != versionID {
+				continue
+			}
+
+			locationAreaID, err := utils.ExtractIDFromURL(e.LocationArea.Url)
+			if err != nil {
+				return inserted, fmt.Errorf("failed to extract location area ID: %w", err)
+			}
+			if err := s.SyncLocationArea(locationAreaID); err != nil {
+				return inserted, fmt.Errorf("failed to sync location area %d: %w", locationAreaID, err)
+			}
+
+			for _, detail := range mergeEncounterSlots(vd.EncounterDetails) {
+				if err := s.repo.InsertEncounter(pokemonID, locationAreaID, versionID, &detail); err != nil {
+					return inserted, err
+				}
+				inserted++
+				s.Progress.Inserted(1)
+			}
+		}
+	}
+
+	return inserted, nil
+}
+
+// mergeEncounterSlots sums the chance of encounter details that only differ by
+// their chance. PokeAPI lists every slot of an area, so a Pokemon in two 10%
+// slots at the same levels is found 20% of the time.
+func mergeEncounterSlots(details []external.EncounterDetail) []external.EncounterDetail {
+	type slotKey struct {
+		method             string
+		minLevel, maxLevel int
+		conditions         string
+	}
+
+	merged := make([]external.EncounterDetail, 0, len(details))
+	indexes := make(map[slotKey]int)
+	for _, detail := range details {
+		conditions := make([]string, 0, len(detail.ConditionValues))
+		for _, cv := range detail.ConditionValues {
+			conditions = append(conditions, cv.Name)
+		}
+		slices.Sort(conditions)
+
+		key := slotKey{detail.Method.Name, detail.MinLevel, detail.MaxLevel, strings.Join(conditions, ",")}
+		if i, ok := indexes[key]; ok {
+			merged[i].Chance += detail.Chance
+			continue
+		}
+		indexes[key] = len(merged)
+		merged = append(merged, detail)
+	}
+	return merged
+}
+
+// SyncLocationArea inserts a location area and its parent location
+func (s *EncounterSyncer) SyncLocationArea(id int) error {
+	// Check cache first
+	s.mu.Lock()
+	if s.syncedAreas[id] {
+		s.mu.Unlock()
+		s.Progress.CacheHit()
+		return nil
+	}
+	s.mu.Unlock()
+
+	area, err := s.client.FetchLocationArea(id)
+	if err != nil {
+		return err
+	}
+
+	locationID, err := utils.ExtractIDFromURL(area.Location.Url)
+	if err != nil {
+		return err
+	}
+	if err := s.SyncLocation(locationID); err != nil {
+		return fmt.Errorf("failed to sync location %d: %w", locationID, err)
+	}
+
+	if err := s.repo.InsertLocationArea(area); err != nil {
+		return err
+	}
+	s.Progress.Inserted(1)
+
+	// Mark as synced in cache
+	s.mu.Lock()
+	s.syncedAreas[id] = true
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *EncounterSyncer) SyncLocation(id int) error {
+	// Check cache first
+	s.mu.Lock()
+	if s.syncedLocations[id] {
+		s.mu.Unlock()
+		s.Progress.CacheHit()
+		return nil
+	}
+	s.mu.Unlock()
+
+	location, err := s.client.FetchLocation(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.InsertLocation(location); err != nil {
+		return err
+	}
+	s.Progress.Inserted(1)
+
+	// Mark as synced in cache
+	s.mu.Lock()
+	s.syncedLocations[id] = true
+	s.mu.Unlock()
+
+	return nil
+}
+
+// fetchPokemonEncounters fetches once per Pokemon, the result is reused for every version
+func (s *EncounterSyncer) fetchPokemonEncounters(pokemonID int) ([]external.LocationAreaEncounter, error) {
+	s.mu.Lock()
+	if encounters, ok := s.pokemonEncounters[pokemonID]; ok {
+		s.mu.Unlock()
+		s.Progress.CacheHit()
+		return encounters, nil
+	}
+	s.mu.Unlock()
+
+	encounters, err := s.client.FetchPokemonEncounters(pokemonID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.pokemonEncounters[pokemonID] = encounters
+	s.mu.Unlock()
+
+	return encounters, nil
+}
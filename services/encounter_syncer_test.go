@@ -0,0 +1,212 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockEncounterAPIClient struct {
+	mock.Mock
+}
+
+func (m *MockEncounterAPIClient) FetchPokemonEncounters(pokemonID int) ([]external.LocationAreaEncounter, error) {
+	args := m.Called(pokemonID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]external.LocationAreaEncounter), args.Error(1)
+}
+
+func (m *MockEncounterAPIClient) FetchLocationArea(id int) (*external.LocationArea, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*external.LocationArea), args.Error(1)
+}
+
+func (m *MockEncounterAPIClient) FetchLocation(id int) (*external.Location, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*external.Location), args.Error(1)
+}
+
+type MockEncounterRepo struct {
+	mock.Mock
+}
+
+func (m *MockEncounterRepo) InsertLocation(l *external.Location) error {
+	args := m.Called(l)
+	return args.Error(0)
+}
+
+func (m *MockEncounterRepo) InsertLocationArea(la *external.LocationArea) error {
+	args := m.Called(la)
+	return args.Error(0)
+}
+
+func (m *MockEncounterRepo) InsertEncounter(pokemonID, locationAreaID, versionID int, detail *external.EncounterDetail) error {
+	args := m.Called(pokemonID, locationAreaID, versionID, detail)
+	return args.Error(0)
+}
+
+func (m *MockEncounterRepo) GetEncounters(pokemonID, versionID int) ([]*dto.Encounter, error) {
+	args := m.Called(pokemonID, versionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.Encounter), args.Error(1)
+}
+
+func (m *MockEncounterRepo) GetLocationEncounters(locationAreaID, versionID int) ([]*dto.Encounter, error) {
+	args := m.Called(locationAreaID, versionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.Encounter), args.Error(1)
+}
+
+func (m *MockEncounterRepo) GetSpeciesLocations(versionGroupID int) ([]*dto.SpeciesLocation, error) {
+	args := m.Called(versionGroupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.SpeciesLocation), args.Error(1)
+}
+
+func (m *MockEncounterRepo) GetVersionExclusives(versionGroupID int) ([]*dto.VersionExclusives, error) {
+	args := m.Called(versionGroupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.VersionExclusives), args.Error(1)
+}
+
+func (m *MockEncounterRepo) GetLocationByID(id int) (*dto.Location, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.Location), args.Error(1)
+}
+
+func (m *MockEncounterRepo) GetVersionRegions(versionID int) ([]string, error) {
+	args := m.Called(versionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestSyncPokemonEncounters(t *testing.T) {
+	t.Run("Only inserts encounters of the requested version", func(t *testing.T) {
+		mockClient := new(MockEncounterAPIClient)
+		mockRepo := new(MockEncounterRepo)
+
+		mockClient.On("FetchPokemonEncounters", 16).Return([]external.LocationAreaEncounter{
+			{
+				LocationArea: external.Response{Name: "johto-route-29-area", Url: "https://pokeapi.co/api/v2/location-area/201/"},
+				VersionDetails: []external.EncounterVersionDetail{
+					{
+						Version: external.Response{Name: "gold", Url: "https://pokeapi.co/api/v2/version/4/"},
+						EncounterDetails: []external.EncounterDetail{
+							{MinLevel: 2, MaxLevel: 2, Chance: 30, Method: external.Response{Name: "walk"}},
+							{MinLevel: 3, MaxLevel: 3, Chance: 10, Method: external.Response{Name: "walk"}},
+						},
+					},
+					{
+						Version: external.Response{Name: "silver", Url: "https://pokeapi.co/api/v2/version/5/"},
+						EncounterDetails: []external.EncounterDetail{
+							{MinLevel: 2, MaxLevel: 2, Chance: 30, Method: external.Response{Name: "walk"}},
+						},
+					},
+				},
+			},
+		}, nil).Once()
+		mockClient.On("FetchLocationArea", 201).Return(&external.LocationArea{
+			ID:       201,
+			Name:     "johto-route-29-area",
+			Location: external.Response{Name: "johto-route-29", Url: "https://pokeapi.co/api/v2/location/182/"},
+		}, nil).Once()
+		mockClient.On("FetchLocation", 182).Return(&external.Location{ID: 182, Name: "johto-route-29"}, nil).Once()
+
+		mockRepo.On("InsertLocation", mock.AnythingOfType("*external.Location")).Return(nil).Once()
+		mockRepo.On("InsertLocationArea", mock.AnythingOfType("*external.LocationArea")).Return(nil).Once()
+		mockRepo.On("InsertEncounter", 16, 201, 4, mock.AnythingOfType("*external.EncounterDetail")).Return(nil).Twice()
+		mockRepo.On("InsertEncounter", 16, 201, 5, mock.AnythingOfType("*external.EncounterDetail")).Return(nil).Once()
+
+		rateLimiter := time.NewTicker(1 * time.Millisecond)
+		defer rateLimiter.Stop()
+
+		syncer := NewEncounterSyncer(mockClient, mockRepo, rateLimiter)
+
+		inserted, err := syncer.SyncPokemonEncounters(16, 4)
+		require.NoError(t, err)
+		assert.Equal(t, 2, inserted)
+
+		// Second version reuses the fetched encounters and synced locations
+		inserted, err = syncer.SyncPokemonEncounters(16, 5)
+		require.NoError(t, err)
+		assert.Equal(t, 1, inserted)
+
+		mockClient.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Sums the chance of identical slots", func(t *testing.T) {
+		mockClient := new(MockEncounterAPIClient)
+		mockRepo := new(MockEncounterRepo)
+
+		// Pidgey fills two 10% walk slots at level 2 in the morning
+		mockClient.On("FetchPokemonEncounters", 16).Return([]external.LocationAreaEncounter{
+			{
+				LocationArea: external.Response{Name: "johto-route-29-area", Url: "https://pokeapi.co/api/v2/location-area/201/"},
+				VersionDetails: []external.EncounterVersionDetail{
+					{
+						Version: external.Response{Name: "gold", Url: "https://pokeapi.co/api/v2/version/4/"},
+						EncounterDetails: []external.EncounterDetail{
+							{MinLevel: 2, MaxLevel: 2, Chance: 10, Method: external.Response{Name: "walk"}, ConditionValues: []external.Response{{Name: "time-morning"}}},
+							{MinLevel: 2, MaxLevel: 2, Chance: 10, Method: external.Response{Name: "walk"}, ConditionValues: []external.Response{{Name: "time-morning"}}},
+							{MinLevel: 2, MaxLevel: 2, Chance: 10, Method: external.Response{Name: "walk"}, ConditionValues: []external.Response{{Name: "time-day"}}},
+						},
+					},
+				},
+			},
+		}, nil).Once()
+		mockClient.On("FetchLocationArea", 201).Return(&external.LocationArea{
+			ID:       201,
+			Name:     "johto-route-29-area",
+			Location: external.Response{Name: "johto-route-29", Url: "https://pokeapi.co/api/v2/location/182/"},
+		}, nil).Once()
+		mockClient.On("FetchLocation", 182).Return(&external.Location{ID: 182, Name: "johto-route-29"}, nil).Once()
+
+		mockRepo.On("InsertLocation", mock.AnythingOfType("*external.Location")).Return(nil).Once()
+		mockRepo.On("InsertLocationArea", mock.AnythingOfType("*external.LocationArea")).Return(nil).Once()
+		mockRepo.On("InsertEncounter", 16, 201, 4, mock.MatchedBy(func(d *external.EncounterDetail) bool {
+			return d.Chance == 20 && d.ConditionValues[0].Name == "time-morning"
+		})).Return(nil).Once()
+		mockRepo.On("InsertEncounter", 16, 201, 4, mock.MatchedBy(func(d *external.EncounterDetail) bool {
+			return d.Chance == 10 && d.ConditionValues[0].Name == "time-day"
+		})).Return(nil).Once()
+
+		rateLimiter := time.NewTicker(1 * time.Millisecond)
+		defer rateLimiter.Stop()
+
+		syncer := NewEncounterSyncer(mockClient, mockRepo, rateLimiter)
+
+		inserted, err := syncer.SyncPokemonEncounters(16, 4)
+		require.NoError(t, err)
+		assert.Equal(t, 2, inserted)
+
+		mockClient.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+}
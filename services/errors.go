@@ -0,0 +1,12 @@
+package services
+
+import "errors"
+
+// ErrInvalidInput is wrapped by validation errors of user supplied data
+var ErrInvalidInput = errors.New("invalid input")
+
+// ErrUnauthorized is returned for wrong credentials and unknown or expired tokens
+var ErrUnauthorized = errors.New("unauthorized")
+
+// ErrRuleViolation is returned when an action breaks the rules of a challenge run
+var ErrRuleViolation = errors.New("rule violation")
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/igdb"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
+)
+
+// Matches at least this good are confirmed without a review
+const autoConfirmConfidence = 0.8
+
+// generationPlatforms are the platforms the games of a generation came out on
+var generationPlatforms = map[int][]string{
+	1: {"Game Boy"},
+	2: {"Game Boy Color", "Game Boy"},
+	3: {"Game Boy Advance", "Nintendo GameCube"},
+	4: {"Nintendo DS"},
+	5: {"Nintendo DS"},
+	6: {"Nintendo 3DS"},
+	7: {"Nintendo 3DS", "Nintendo Switch"},
+	8: {"Nintendo Switch"},
+	9: {"Nintendo Switch", "Nintendo Switch 2"},
+}
+
+// generationYears are the first and last year a generation had a release
+var generationYears = map[int][2]int{
+	1: {1996, 1999},
+	2: {1999, 2001},
+	3: {2002, 2005},
+	4: {2006, 2010},
+	5: {2010, 2012},
+	6: {2013, 2014},
+	7: {2016, 2018},
+	8: {2019, 2022},
+	9: {2022, 2025},
+}
+
+// GameMatcher finds the IGDB games of versions the igdb package doesn't know
+// by searching IGDB and scoring the results on name, platform and release
+// year. Good matches are confirmed, the others are kept for a review.
+type GameMatcher struct {
+	igdbClient  IGDBClient
+	versionRepo VersionRepo
+	now         func() time.Time
+}
+
+func NewGameMatcher(igdbClient IGDBClient, versionRepo VersionRepo) *GameMatcher {
+	return &GameMatcher{
+		igdbClient:  igdbClient,
+		versionRepo: versionRepo,
+		now:         time.Now,
+	}
+}
+
+// MatchUnmapped searches IGDB for every version without a built-in or
+// confirmed game and stores the best result. It returns the new mappings,
+// confirmed and pending, in version order.
+func (m *GameMatcher) MatchUnmapped(ctx context.Context) ([]*dto.GameMapping, error) {
+	versions, err := m.versionRepo.GetVersions()
+	if err != nil {
+		return nil, err
+	}
+	mappings, err := m.versionRepo.GetGameMappings()
+	if err != nil {
+		return nil, err
+	}
+
+	mapped := igdb.KnownGameIDs()
+	for _, gm := range mappings {
+		if gm.Status == dto.GameMappingConfirmed {
+			mapped[gm.VersionName] = gm.IGDBID
+		}
+	}
+
+	generations := make(map[int]int) // By version group ID
+	matched := []*dto.GameMapping{}
+	for _, v := range versions {
+		if _, ok := mapped[v.Name]; ok {
+			continue
+		}
+
+		generation, ok := generations[v.VersionGroupID]
+		if !ok {
+			vg, err := m.versionRepo.GetVersionGroupByID(v.VersionGroupID)
+			if err != nil {
+				return nil, err
+			}
+			// An unknown generation only loses the platform and year hints
+			generation, _ = utils.GenerationNumber(vg.GenerationName)
+			generations[v.VersionGroupID] = generation
+		}
+
+		results, err := m.igdbClient.SearchGame(ctx, searchTerm(v.Name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to search IGDB for %s: %w", v.Name, err)
+		}
+		best, confidence := bestMatch(v.Name, generation, results)
+		if best == nil {
+			logger().Info("no IGDB results", "version", v.Name)
+			continue
+		}
+
+		gm := &dto.GameMapping{
+			VersionName: v.Name,
+			IGDBID:      best.ID,
+			IGDBName:    best.Name,
+			Confidence:  confidence,
+			Status:      dto.GameMappingPending,
+			UpdatedAt:   m.now().Unix(),
+		}
+		if confidence >= autoConfirmConfidence {
+			gm.Status = dto.GameMappingConfirmed
+		}
+		if err := m.versionRepo.InsertGameMapping(gm); err != nil {
+			return nil, err
+		}
+		matched = append(matched, gm)
+	}
+
+	return matched, nil
+}
+
+// PendingMappings returns the matches waiting for a review
+func (m *GameMatcher) PendingMappings() ([]*dto.GameMapping, error) {
+	mappings, err := m.versionRepo.GetGameMappings()
+	if err != nil {
+		return nil, err
+	}
+	pending := []*dto.GameMapping{}
+	for _, gm := range mappings {
+		if gm.Status == dto.GameMappingPending {
+			pending = append(pending, gm)
+		}
+	}
+	return pending, nil
+}
+
+// Confirm maps a version to an IGDB game by hand, replacing any match
+func (m *GameMatcher) Confirm(ctx context.Context, versionName string, igdbID int) (*dto.GameMapping, error) {
+	game, err := m.igdbClient.GetGameByID(ctx, igdbID)
+	if err != nil {
+		return nil, err
+	}
+
+	gm := &dto.GameMapping{
+		VersionName: versionName,
+		IGDBID:      game.ID,
+		IGDBName:    game.Name,
+		Confidence:  1,
+		Status:      dto.GameMappingConfirmed,
+		UpdatedAt:   m.now().Unix(),
+	}
+	if err := m.versionRepo.InsertGameMapping(gm); err != nil {
+		return nil, err
+	}
+	return gm, nil
+}
+
+// searchTerm turns a version name like "lets-go-pikachu" into an IGDB search
+func searchTerm(versionName string) string {
+	return "Pokémon " + strings.ReplaceAll(versionName, "-", " ")
+}
+
+// bestMatch returns the highest scoring search result and its score
+func bestMatch(versionName string, generation int, results []igdb.Game) (*igdb.Game, float64) {
+	var best *igdb.Game
+	bestScore := -1.0
+	for i := range results {
+		if score := matchScore(versionName, generation, &results[i]); score > bestScore {
+			best, bestScore = &results[i], score
+		}
+	}
+	return best, bestScore
+}
+
+// matchScore rates a search result from 0 to 1: 0.6 for the name, 0.2 for a
+// platform and 0.2 for a release year of the version's generation
+func matchScore(versionName string, generation int, game *igdb.Game) float64 {
+	// Hyphens separate words in version names but not in titles like "Z-A"
+	versionTokens := nameTokens(strings.ReplaceAll(versionName, "-", " "))
+	gameTokens := nameTokens(strings.ReplaceAll(game.Name, "-", ""))
+	score := 0.6 * nameSimilarity(versionTokens, gameTokens)
+
+	for _, p := range game.Platforms {
+		if slices.Contains(generationPlatforms[generation], p.Name) {
+			score += 0.2
+			break
+		}
+	}
+
+	if years, ok := generationYears[generation]; ok && game.FirstReleaseDate > 0 {
+		year := time.Unix(game.FirstReleaseDate, 0).UTC().Year()
+		if year >= years[0] && year <= years[1] {
+			score += 0.2
+		}
+	}
+
+	return math.Round(score*100) / 100
+}
+
+var nonWordChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// nameTokens returns the words of a name without "pokemon" and "version",
+// e.g. "Pokémon: Let's Go, Pikachu!" gives lets, go, pikachu
+func nameTokens(name string) []string {
+	name = strings.ToLower(name)
+	name = strings.NewReplacer("é", "e", "'", "", "’", "").Replace(name)
+
+	var tokens []string
+	for _, t := range nonWordChars.Split(name, -1) {
+		switch t {
+		case "", "pokemon", "version", "versions":
+			continue
+		}
+		if !slices.Contains(tokens, t) {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
+
+// nameSimilarity is the share of words two names have in common, so
+// "Pokémon Red and Blue" is a worse match for red than "Pokémon Red"
+func nameSimilarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	common := 0
+	for _, t := range a {
+		if slices.Contains(b, t) {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/igdb"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func releaseDate(year int) int64 {
+	return time.Date(year, time.November, 1, 0, 0, 0, 0, time.UTC).Unix()
+}
+
+func TestMatchScore(t *testing.T) {
+	switchGame := []igdb.Platform{{Name: "Nintendo Switch"}}
+
+	tests := []struct {
+		name       string
+		version    string
+		generation int
+		game       igdb.Game
+		want       float64
+	}{
+		{"exact name, platform and year", "shining-pearl", 8, igdb.Game{Name: "Pokémon Shining Pearl", Platforms: switchGame, FirstReleaseDate: releaseDate(2021)}, 1},
+		{"older game with a shared word", "shining-pearl", 8, igdb.Game{Name: "Pokémon Pearl Version", Platforms: []igdb.Platform{{Name: "Nintendo DS"}}, FirstReleaseDate: releaseDate(2006)}, 0.3},
+		{"punctuation is ignored", "lets-go-eevee", 7, igdb.Game{Name: "Pokémon: Let's Go, Eevee!", Platforms: switchGame, FirstReleaseDate: releaseDate(2018)}, 1},
+		{"bundles match worse", "red", 1, igdb.Game{Name: "Pokémon Red and Blue", Platforms: []igdb.Platform{{Name: "Game Boy"}}, FirstReleaseDate: releaseDate(1998)}, 0.6},
+		{"unknown generation only has the name", "legends-za", 0, igdb.Game{Name: "Pokémon Legends: Z-A", Platforms: switchGame, FirstReleaseDate: releaseDate(2025)}, 0.6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchScore(tt.version, tt.generation, &tt.game))
+		})
+	}
+}
+
+func TestMatchUnmapped(t *testing.T) {
+	versionRepo := new(MockVersionRepo)
+	versionRepo.On("GetVersions").Return([]*dto.Version{
+		{ID: 1, Name: "red", VersionGroupID: 1},
+		{ID: 2, Name: "shining-pearl", VersionGroupID: 2},
+		{ID: 3, Name: "brilliant-diamond", VersionGroupID: 2},
+		{ID: 4, Name: "new-game", VersionGroupID: 3},
+		{ID: 5, Name: "lost-game", VersionGroupID: 3},
+	}, nil)
+	versionRepo.On("GetGameMappings").Return([]*dto.GameMapping{
+		{VersionName: "brilliant-diamond", IGDBID: 144051, Status: dto.GameMappingConfirmed},
+	}, nil)
+	versionRepo.On("GetVersionGroupByID", 2).Return(&dto.VersionGroup{ID: 2, GenerationName: "generation-viii"}, nil).Once()
+	versionRepo.On("GetVersionGroupByID", 3).Return(&dto.VersionGroup{ID: 3, GenerationName: "generation-x"}, nil).Once()
+	versionRepo.On("InsertGameMapping", mock.AnythingOfType("*dto.GameMapping")).Return(nil).Twice()
+
+	igdbClient := new(MockIGDBClient)
+	igdbClient.On("SearchGame", mock.Anything, "Pokémon shining pearl").Return([]igdb.Game{
+		{ID: 1518, Name: "Pokémon Pearl", Platforms: []igdb.Platform{{Name: "Nintendo DS"}}, FirstReleaseDate: releaseDate(2006)},
+		{ID: 144050, Name: "Pokémon Shining Pearl", Platforms: []igdb.Platform{{Name: "Nintendo Switch"}}, FirstReleaseDate: releaseDate(2021)},
+	}, nil)
+	igdbClient.On("SearchGame", mock.Anything, "Pokémon new game").Return([]igdb.Game{
+		{ID: 500, Name: "Pokémon New Game", Platforms: []igdb.Platform{{Name: "Nintendo Switch 3"}}},
+	}, nil)
+	igdbClient.On("SearchGame", mock.Anything, "Pokémon lost game").Return([]igdb.Game{}, nil)
+
+	matcher := NewGameMatcher(igdbClient, versionRepo)
+	matcher.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	got, err := matcher.MatchUnmapped(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []*dto.GameMapping{
+		{VersionName: "shining-pearl", IGDBID: 144050, IGDBName: "Pokémon Shining Pearl", Confidence: 1, Status: dto.GameMappingConfirmed, UpdatedAt: 1700000000},
+		{VersionName: "new-game", IGDBID: 500, IGDBName: "Pokémon New Game", Confidence: 0.6, Status: dto.GameMappingPending, UpdatedAt: 1700000000},
+	}, got)
+
+	// Built-in and confirmed versions aren't searched again
+	igdbClient.AssertNotCalled(t, "SearchGame", mock.Anything, "Pokémon red")
+	igdbClient.AssertNotCalled(t, "SearchGame", mock.Anything, "Pokémon brilliant diamond")
+	versionRepo.AssertExpectations(t)
+}
+
+func TestConfirmGameMapping(t *testing.T) {
+	igdbClient := new(MockIGDBClient)
+	igdbClient.On("GetGameByID", mock.Anything, 500).Return(&igdb.Game{ID: 500, Name: "Pokémon New Game"}, nil)
+
+	var stored *dto.GameMapping
+	versionRepo := new(MockVersionRepo)
+	versionRepo.On("InsertGameMapping", mock.AnythingOfType("*dto.GameMapping")).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*dto.GameMapping)
+	}).Return(nil).Once()
+	versionRepo.On("GetGameMappings").Return([]*dto.GameMapping{
+		{VersionName: "new-game", IGDBID: 500, Status: dto.GameMappingConfirmed},
+		{VersionName: "other-game", IGDBID: 501, Status: dto.GameMappingPending},
+	}, nil)
+
+	matcher := NewGameMatcher(igdbClient, versionRepo)
+	got, err := matcher.Confirm(context.Background(), "new-game", 500)
+	require.NoError(t, err)
+	assert.Equal(t, stored, got)
+	assert.Equal(t, dto.GameMappingConfirmed, got.Status)
+	assert.Equal(t, "Pokémon New Game", got.IGDBName)
+
+	pending, err := matcher.PendingMappings()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "other-game", pending[0].VersionName)
+}
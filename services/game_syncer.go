@@ -2,20 +2,35 @@ package services
 
 import (
 	"fmt"
-	"log"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/progress"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
 )
 
 type GameSyncer struct {
-	versionSyncer *VersionSyncer
-	pokedexSyncer *PokedexSyncer
-	pokemonSyncer *PokemonSyncer
-	moveSyncer    *MoveSyncer
-	rateLimiter   *time.Ticker
+	versionSyncer   *VersionSyncer
+	pokedexSyncer   *PokedexSyncer
+	pokemonSyncer   *PokemonSyncer
+	moveSyncer      *MoveSyncer
+	encounterSyncer *EncounterSyncer
+	itemSyncer      *ItemSyncer
+	rateLimiter     *time.Ticker
+	// Cache of version-group.order by ID, used to decide when a form was introduced
+	versionGroupOrders map[int]int
+	mu                 sync.Mutex // Protects versionGroupOrders
+	// Species and moves counted towards the progress totals, species are true
+	// once synced
+	seenSpecies map[int]bool
+	seenMoves   map[int]bool
+
+	Progress *progress.Tracker // Optional
+	Failures *Failures         // Optional, games and species that fail are skipped with it
 }
 
 func NewGameSyncer(
@@ -23,23 +38,31 @@ func NewGameSyncer(
 	pokedexSyncer *PokedexSyncer,
 	pokemonSyncer *PokemonSyncer,
 	moveSyncer *MoveSyncer,
+	encounterSyncer *EncounterSyncer,
+	itemSyncer *ItemSyncer,
 	rateLimiter *time.Ticker,
 ) *GameSyncer {
 	return &GameSyncer{
-		versionSyncer: versionSyncer,
-		pokedexSyncer: pokedexSyncer,
-		pokemonSyncer: pokemonSyncer,
-		moveSyncer:    moveSyncer,
-		rateLimiter:   rateLimiter,
+		versionSyncer:      versionSyncer,
+		pokedexSyncer:      pokedexSyncer,
+		pokemonSyncer:      pokemonSyncer,
+		moveSyncer:         moveSyncer,
+		encounterSyncer:    encounterSyncer,
+		itemSyncer:         itemSyncer,
+		rateLimiter:        rateLimiter,
+		versionGroupOrders: make(map[int]int),
+		seenSpecies:        make(map[int]bool),
+		seenMoves:          make(map[int]bool),
 	}
 }
+
 func (g *GameSyncer) SyncAllGames(limit int) error {
 	allVersions, err := g.versionSyncer.client.FetchAll(fmt.Sprintf("version?limit=%d", limit))
 	if err != nil {
 		return fmt.Errorf("failed to fetch versions: %w", err)
 	}
 
-	fmt.Printf("Found %d versions to sync", len(allVersions))
+	g.Progress.AddTotal(progress.Versions, len(allVersions))
 
 	for i, version := range allVersions {
 		if i > 0 {
@@ -50,24 +73,33 @@ func (g *GameSyncer) SyncAllGames(limit int) error {
 		if err != nil {
 			return fmt.Errorf("failed to extract version ID from %s: %w", version.Url, err)
 		}
-		log.Printf("Syncing game %s (%d/%d)...", version.Name, i+1, len(allVersions))
-
-		if err := g.SyncGame(versionID); err != nil {
-			return fmt.Errorf("failed to sync game %d (%s): %w", versionID, version.Name, err)
+		if err := g.syncGame(versionID); err != nil {
+			err = fmt.Errorf("failed to sync game %d (%s): %w", versionID, version.Name, err)
+			if err := g.Failures.Record(dto.ResourceGame, versionID, 0, err); err != nil {
+				return err
+			}
+			g.Progress.Done(progress.Versions)
 		}
-		log.Printf("✓ Completed %s", version.Name)
 	}
 
 	return nil
 }
 
 func (g *GameSyncer) SyncGame(id int) error {
+	g.Progress.AddTotal(progress.Versions, 1)
+	return g.syncGame(id)
+}
+
+// syncGame syncs a version counted in the progress totals
+func (g *GameSyncer) syncGame(id int) error {
 	version, err := g.versionSyncer.client.FetchVersion(id)
 	if err != nil {
 		return err
 	}
 
 	if version.Name == "green-japan" || version.Name == "red-japan" || version.Name == "blue-japan" {
+		g.Progress.Skipped()
+		g.Progress.Done(progress.Versions)
 		return nil
 	}
 
@@ -80,34 +112,34 @@ func (g *GameSyncer) SyncGame(id int) error {
 		return err
 	}
 
-	log.Printf("Inserting version group %d (%s)...", versionGroup.ID, versionGroup.Name)
 	if err := g.versionSyncer.InsertVersionGroup(versionGroup); err != nil {
 		return fmt.Errorf("failed to insert version group %d (%s): %w", versionGroup.ID, versionGroup.Name, err)
 	}
+	g.Progress.Inserted(1)
 
-	log.Printf("Inserting version %d (%s) with version_group_id %d...", version.ID, version.Name, versionGroupId)
 	if err := g.versionSyncer.InsertVersion(version); err != nil {
 		return fmt.Errorf("failed to insert version %d (%s): %w", version.ID, version.Name, err)
 	}
-	log.Printf("Version %d inserted successfully", version.ID)
+	g.Progress.Inserted(1)
 
 	// Check if this is a special game version (Colosseum, XD, etc.) that doesn't have traditional Pokedexes
 	if specialPokemonIDs := models.GetSpecialGamePokemon(version.Name); specialPokemonIDs != nil {
-		log.Printf("Special game detected: %s - Processing %d Pokemon...", version.Name, len(specialPokemonIDs))
-		return g.syncSpecialGamePokemon(specialPokemonIDs, version.Name, versionGroupId)
+		if err := g.syncSpecialGamePokemon(specialPokemonIDs, version, versionGroup); err != nil {
+			return err
+		}
+		g.Progress.Done(progress.Versions)
+		logger().Info("synced version", "version", version.Name)
+		return nil
 	}
 
-	log.Printf("Processing %d pokedexes for version group %d...", len(versionGroup.Pokedexes), versionGroup.ID)
-
 	// Cache fetched pokedexes to avoid duplicate fetches
 	pokedexCache := make(map[int]*external.Pokedex)
 
-	for i, pdex := range versionGroup.Pokedexes {
+	for _, pdex := range versionGroup.Pokedexes {
 		pokedexId, err := utils.ExtractIDFromURL(pdex.Url)
 		if err != nil {
 			return fmt.Errorf("failed to extract pokedex ID: %w", err)
 		}
-		log.Printf("  [%d/%d] Fetching pokedex %d...", i+1, len(versionGroup.Pokedexes), pokedexId)
 		pokedex, err := g.pokedexSyncer.client.FetchPokedex(pokedexId)
 		if err != nil {
 			return fmt.Errorf("failed to fetch pokedex %d: %w", pokedexId, err)
@@ -115,15 +147,26 @@ func (g *GameSyncer) SyncGame(id int) error {
 
 		// Store in cache for later use
 		pokedexCache[pokedexId] = pokedex
+		g.Progress.AddTotal(progress.Entries, len(pokedex.PokemonEntries))
 
-		log.Printf("  [%d/%d] Inserting pokedex %d (%s)...", i+1, len(versionGroup.Pokedexes), pokedex.ID, pokedex.Name)
 		if err := g.pokedexSyncer.InsertPokedex(pokedex); err != nil {
 			return fmt.Errorf("failed to insert pokedex %d: %w", pokedex.ID, err)
 		}
+		g.Progress.Inserted(1)
+	}
+
+	// Pokedexes overlap, count every species once
+	for _, pokedex := range pokedexCache {
+		for _, pe := range pokedex.PokemonEntries {
+			speciesID, err := utils.ExtractIDFromURL(pe.PokemonSpecies.Url)
+			if err != nil {
+				return fmt.Errorf("failed to extract species ID: %w", err)
+			}
+			g.countSpecies(speciesID)
+		}
 	}
 
 	// Insert version_group_pokedex relationships AFTER all pokedexes are inserted
-	log.Printf("Inserting version_group_pokedex relationships...")
 	if err := g.pokedexSyncer.InsertVersionGroupPokedex(versionGroup); err != nil {
 		return fmt.Errorf("failed to insert version_group_pokedex for vg %d: %w", versionGroup.ID, err)
 	}
@@ -141,130 +184,376 @@ func (g *GameSyncer) SyncGame(id int) error {
 			return fmt.Errorf("pokedex %d not found in cache", pokedexId)
 		}
 
-		log.Printf("Processing %d Pokemon entries for pokedex %d (%s)...", len(pokedex.PokemonEntries), pokedex.ID, pokedex.Name)
 		for _, pe := range pokedex.PokemonEntries {
 			speciesID, err := utils.ExtractIDFromURL(pe.PokemonSpecies.Url)
 			if err != nil {
 				return fmt.Errorf("failed to extract species ID: %w", err)
 			}
 
-			log.Printf("  Syncing species %d...", speciesID)
-			_, err = g.pokemonSyncer.SyncSpecies(speciesID)
-			if err != nil {
-				return fmt.Errorf("failed to sync species %d: %w", speciesID, err)
-			}
-
-			log.Printf("  Syncing pokemon %d with types, moves, and abilities...", speciesID)
-			if err := g.syncPokemonData(speciesID, versionGroupId); err != nil {
-				return fmt.Errorf("failed to sync pokemon %d: %w", speciesID, err)
-			}
-
-			log.Printf("  Inserting pokedex entry for species %d...", speciesID)
-			err = g.pokedexSyncer.InsertPokedexEntry(&external.PokedexEntry{
-				PokedexID:   pokedexId,
-				SpeciesID:   speciesID,
-				EntryNumber: pe.EntryNumber,
-			})
-			if err != nil {
-				return fmt.Errorf("failed to insert pokedex entry for species %d: %w", speciesID, err)
+			if err := g.syncEntry(speciesID, pokedexId, pe.EntryNumber, version, versionGroup); err != nil {
+				if err := g.Failures.Record(dto.ResourceSpecies, speciesID, version.ID, err); err != nil {
+					return err
+				}
 			}
+			g.speciesDone(speciesID)
+			g.Progress.Done(progress.Entries)
 		}
 	}
 
+	g.Progress.Done(progress.Versions)
+	logger().Info("synced version", "version", version.Name)
 	return nil
 }
 
+// countSpecies adds a species to the progress totals the first time it's seen
+func (g *GameSyncer) countSpecies(id int) {
+	if _, ok := g.seenSpecies[id]; ok {
+		return
+	}
+	g.seenSpecies[id] = false
+	g.Progress.AddTotal(progress.Pokemon, 1)
+}
+
+// speciesDone marks a counted species done the first time it's synced
+func (g *GameSyncer) speciesDone(id int) {
+	if g.seenSpecies[id] {
+		return
+	}
+	g.seenSpecies[id] = true
+	g.Progress.Done(progress.Pokemon)
+}
+
 // syncSpecialGamePokemon handles syncing Pokemon for special game versions like Colosseum and XD
 // that don't have traditional Pokedexes in PokeAPI
-func (g *GameSyncer) syncSpecialGamePokemon(pokemonIDs []int, versionName string, versionGroupID int) error {
-	log.Printf("Syncing %d Pokemon for special game version...", len(pokemonIDs))
+func (g *GameSyncer) syncSpecialGamePokemon(pokemonIDs []int, version *external.Version, specialVersionGroup *external.VersionGroup) error {
+	versionName := version.Name
+	versionGroupID := specialVersionGroup.ID
 
 	// Create a virtual pokedex for this special game
-	// Use a high ID that won't conflict with real pokedexes (real ones are < 100)
-	virtualPokedexID := 1000 + versionGroupID
 	virtualPokedex := &external.Pokedex{
-		ID:   virtualPokedexID,
+		ID:   virtualPokedexID(versionGroupID),
 		Name: versionName + "-pokedex",
 		Region: external.Response{
 			Name: "unknown", // Special games don't have a specific region
 		},
 	}
 
-	log.Printf("Creating virtual pokedex %d (%s)...", virtualPokedex.ID, virtualPokedex.Name)
 	if err := g.pokedexSyncer.InsertPokedex(virtualPokedex); err != nil {
 		return fmt.Errorf("failed to insert virtual pokedex: %w", err)
 	}
+	g.Progress.Inserted(1)
 
 	// Link the virtual pokedex to the version group
 	versionGroup := &external.VersionGroup{
 		ID: versionGroupID,
 		Pokedexes: []external.Response{
-			{Name: virtualPokedex.Name, Url: fmt.Sprintf("https://pokeapi.co/api/v2/pokedex/%d/", virtualPokedexID)},
+			{Name: virtualPokedex.Name, Url: fmt.Sprintf("https://pokeapi.co/api/v2/pokedex/%d/", virtualPokedex.ID)},
 		},
 	}
 	if err := g.pokedexSyncer.InsertVersionGroupPokedex(versionGroup); err != nil {
 		return fmt.Errorf("failed to insert version_group_pokedex: %w", err)
 	}
 
-	// Sync each Pokemon and create pokedex entries
+	g.Progress.AddTotal(progress.Entries, len(pokemonIDs))
+	for _, pokemonID := range pokemonIDs {
+		g.countSpecies(pokemonID)
+	}
+
+	// Sync each Pokemon and create pokedex entries, numbered in list order
 	for i, pokemonID := range pokemonIDs {
-		log.Printf("  [%d/%d] Processing Pokemon %d...", i+1, len(pokemonIDs), pokemonID)
+		if err := g.syncEntry(pokemonID, virtualPokedex.ID, i+1, version, specialVersionGroup); err != nil {
+			if err := g.Failures.Record(dto.ResourceSpecies, pokemonID, version.ID, err); err != nil {
+				return err
+			}
+		}
+		g.speciesDone(pokemonID)
+		g.Progress.Done(progress.Entries)
+	}
+
+	return nil
+}
+
+// virtualPokedexID is the ID of the pokedex made up for a special game. Real
+// pokedex IDs are below 100.
+func virtualPokedexID(versionGroupID int) int {
+	return 1000 + versionGroupID
+}
+
+// syncEntry syncs a species with its varieties in a version and stores its
+// pokedex entry
+func (g *GameSyncer) syncEntry(speciesID, pokedexID, entryNumber int, version *external.Version, versionGroup *external.VersionGroup) error {
+	species, err := g.pokemonSyncer.SyncSpecies(speciesID)
+	if err != nil {
+		return fmt.Errorf("failed to sync species %d: %w", speciesID, err)
+	}
+
+	if err := g.syncSpeciesVarieties(species, version, versionGroup); err != nil {
+		return fmt.Errorf("failed to sync varieties of species %d: %w", speciesID, err)
+	}
+
+	err = g.pokedexSyncer.InsertPokedexEntry(&external.PokedexEntry{
+		PokedexID:   pokedexID,
+		SpeciesID:   speciesID,
+		EntryNumber: entryNumber,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert pokedex entry for species %d: %w", speciesID, err)
+	}
+	g.Progress.Inserted(1)
+
+	return nil
+}
+
+// SyncGameSpecies syncs one species of a game the way SyncGame does, with an
+// entry in every pokedex of the game that lists it. The game's version group
+// and pokedexes must already be synced.
+func (g *GameSyncer) SyncGameSpecies(versionID, speciesID int) error {
+	version, err := g.versionSyncer.client.FetchVersion(versionID)
+	if err != nil {
+		return err
+	}
+	versionGroupID, err := utils.ExtractIDFromURL(version.VersionGroup.Url)
+	if err != nil {
+		return err
+	}
+	versionGroup, err := g.versionSyncer.client.FetchVersionGroup(versionGroupID)
+	if err != nil {
+		return err
+	}
+
+	type entry struct{ pokedexID, number int }
+	var entries []entry
+	if specialPokemonIDs := models.GetSpecialGamePokemon(version.Name); specialPokemonIDs != nil {
+		if i := slices.Index(specialPokemonIDs, speciesID); i >= 0 {
+			entries = append(entries, entry{virtualPokedexID(versionGroup.ID), i + 1})
+		}
+	} else {
+		for _, pdex := range versionGroup.Pokedexes {
+			pokedexID, err := utils.ExtractIDFromURL(pdex.Url)
+			if err != nil {
+				return fmt.Errorf("failed to extract pokedex ID: %w", err)
+			}
+			pokedex, err := g.pokedexSyncer.client.FetchPokedex(pokedexID)
+			if err != nil {
+				return fmt.Errorf("failed to fetch pokedex %d: %w", pokedexID, err)
+			}
+			for _, pe := range pokedex.PokemonEntries {
+				if id, err := utils.ExtractIDFromURL(pe.PokemonSpecies.Url); err == nil && id == speciesID {
+					entries = append(entries, entry{pokedexID, pe.EntryNumber})
+				}
+			}
+		}
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("species %d is in no pokedex of %s", speciesID, version.Name)
+	}
+
+	g.countSpecies(speciesID)
+	g.Progress.AddTotal(progress.Entries, len(entries))
+	for _, e := range entries {
+		if err := g.syncEntry(speciesID, e.pokedexID, e.number, version, versionGroup); err != nil {
+			return err
+		}
+		g.Progress.Done(progress.Entries)
+	}
+	g.speciesDone(speciesID)
+
+	return nil
+}
+
+// syncSpeciesVarieties syncs every variety of a species (regional forms, Megas, Gmax, ...)
+// that is available in the version group, links its forms to the version group
+// and syncs where it can be encountered in the version
+func (g *GameSyncer) syncSpeciesVarieties(species *external.Species, version *external.Version, versionGroup *external.VersionGroup) error {
+	varieties := species.Varieties
+	if len(varieties) == 0 {
+		// The default variety shares its ID with the species
+		varieties = []external.Variety{{
+			IsDefault: true,
+			Pokemon: external.Response{
+				Name: species.Name,
+				Url:  fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%d/", species.ID),
+			},
+		}}
+	}
+
+	for _, variety := range varieties {
+		pokemonID, err := utils.ExtractIDFromURL(variety.Pokemon.Url)
+		if err != nil {
+			return fmt.Errorf("failed to extract pokemon ID: %w", err)
+		}
 
-		// Sync species
-		_, err := g.pokemonSyncer.SyncSpecies(pokemonID)
+		// Availability is decided before anything is stored, so varieties
+		// that aren't in the game never reach the database
+		pokemon, err := g.pokemonSyncer.FetchPokemon(pokemonID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch pokemon %d: %w", pokemonID, err)
+		}
+		available, err := g.isVarietyAvailable(variety, pokemon, versionGroup)
 		if err != nil {
-			return fmt.Errorf("failed to sync species %d: %w", pokemonID, err)
+			return err
+		}
+		if !available {
+			g.Progress.Skipped()
+			continue
 		}
 
-		// Sync Pokemon and get full data
-		if err := g.syncPokemonData(pokemonID, versionGroupID); err != nil {
+		if err := g.pokemonSyncer.StorePokemon(pokemon); err != nil {
 			return fmt.Errorf("failed to sync pokemon %d: %w", pokemonID, err)
 		}
 
-		// Create pokedex entry (using array index + 1 as entry number)
-		log.Printf("  Inserting pokedex entry for species %d...", pokemonID)
-		err = g.pokedexSyncer.InsertPokedexEntry(&external.PokedexEntry{
-			PokedexID:   virtualPokedexID,
-			SpeciesID:   pokemonID,
-			EntryNumber: i + 1, // Sequential numbering
-		})
-		if err != nil {
-			return fmt.Errorf("failed to insert pokedex entry for species %d: %w", pokemonID, err)
+		forms := make([]*external.PokemonForm, 0, len(pokemon.Forms))
+		for _, f := range pokemon.Forms {
+			formID, err := utils.ExtractIDFromURL(f.Url)
+			if err != nil {
+				return fmt.Errorf("failed to extract pokemon form ID: %w", err)
+			}
+			form, err := g.pokemonSyncer.SyncPokemonForm(formID)
+			if err != nil {
+				return fmt.Errorf("failed to sync pokemon form %d: %w", formID, err)
+			}
+			forms = append(forms, form)
+		}
+
+		if err := g.syncPokemonData(pokemon, versionGroup.ID); err != nil {
+			return fmt.Errorf("failed to sync pokemon %d: %w", pokemon.ID, err)
+		}
+
+		if _, err := g.encounterSyncer.SyncPokemonEncounters(pokemon.ID, version.ID); err != nil {
+			return fmt.Errorf("failed to sync encounters for pokemon %d: %w", pokemon.ID, err)
+		}
+
+		for _, form := range forms {
+			introduced, err := g.isIntroducedBy(form, versionGroup)
+			if err != nil {
+				return err
+			}
+			// The default form of the default variety is always there, whatever PokeAPI says
+			if !introduced && !(variety.IsDefault && form.IsDefault) {
+				continue
+			}
+			if err := g.pokemonSyncer.InsertPokemonFormVersionGroup(form.ID, versionGroup.ID); err != nil {
+				return fmt.Errorf("failed to insert pokemon_form_version_group for form %d: %w", form.ID, err)
+			}
 		}
 	}
 
-	log.Printf("✓ Completed syncing %d Pokemon for special game", len(pokemonIDs))
 	return nil
 }
 
-// syncPokemonData syncs a single Pokemon including its types, moves, and abilities
-// versionGroupID is used to filter which moves to insert (Pokemon learn different moves in different games)
-func (g *GameSyncer) syncPokemonData(pokemonID int, versionGroupID int) error {
-	// Sync Pokemon
-	pokemon, err := g.pokemonSyncer.SyncPokemon(pokemonID)
+// isVarietyAvailable decides whether a non-default variety exists in a version group.
+// Battle-only varieties like Megas have no learnset, the games they appear in are fixed.
+// Varieties with a learnset are available where they learn moves (raichu-alola from sun-moon on).
+// Any other variety falls back to the introduction of its default form.
+func (g *GameSyncer) isVarietyAvailable(variety external.Variety, pokemon *external.Pokemon, versionGroup *external.VersionGroup) (bool, error) {
+	if variety.IsDefault {
+		return true, nil
+	}
+
+	if versionGroups, ok := models.GetBattleOnlyFormVersionGroups(pokemon.Name); ok {
+		return slices.Contains(versionGroups, versionGroup.Name), nil
+	}
+
+	if len(pokemon.Moves) > 0 {
+		return learnsMovesIn(pokemon, versionGroup.ID), nil
+	}
+
+	for _, f := range pokemon.Forms {
+		formID, err := utils.ExtractIDFromURL(f.Url)
+		if err != nil {
+			return false, fmt.Errorf("failed to extract pokemon form ID: %w", err)
+		}
+		form, err := g.pokemonSyncer.FetchPokemonForm(formID)
+		if err != nil {
+			return false, fmt.Errorf("failed to fetch pokemon form %d: %w", formID, err)
+		}
+		if form.IsDefault {
+			return g.isIntroducedBy(form, versionGroup)
+		}
+	}
+
+	return false, nil
+}
+
+// isIntroducedBy reports whether a form was introduced in or before the version group
+func (g *GameSyncer) isIntroducedBy(form *external.PokemonForm, versionGroup *external.VersionGroup) (bool, error) {
+	if form.VersionGroup.Url == "" {
+		return true, nil
+	}
+
+	introducedID, err := utils.ExtractIDFromURL(form.VersionGroup.Url)
 	if err != nil {
-		return fmt.Errorf("failed to sync pokemon: %w", err)
+		return false, fmt.Errorf("failed to extract version group ID: %w", err)
+	}
+	if introducedID == versionGroup.ID {
+		return true, nil
+	}
+
+	g.mu.Lock()
+	introducedOrder, ok := g.versionGroupOrders[introducedID]
+	g.mu.Unlock()
+	if !ok {
+		introducedVersionGroup, err := g.versionSyncer.FetchVersionGroup(introducedID)
+		if err != nil {
+			return false, fmt.Errorf("failed to fetch version group %d: %w", introducedID, err)
+		}
+		introducedOrder = introducedVersionGroup.Order
+		g.mu.Lock()
+		g.versionGroupOrders[introducedID] = introducedOrder
+		g.mu.Unlock()
+	}
+
+	return introducedOrder <= versionGroup.Order, nil
+}
+
+func learnsMovesIn(pokemon *external.Pokemon, versionGroupID int) bool {
+	for _, m := range pokemon.Moves {
+		for _, vgDetail := range m.VersionGroupDetails {
+			if vgID, err := utils.ExtractIDFromURL(vgDetail.VersionGroup.Url); err == nil && vgID == versionGroupID {
+				return true
+			}
+		}
 	}
+	return false
+}
 
+// syncPokemonData syncs a single Pokemon's types, moves, and abilities
+// versionGroupID is used to filter which moves to insert (Pokemon learn different moves in different games)
+func (g *GameSyncer) syncPokemonData(pokemon *external.Pokemon, versionGroupID int) error {
 	// Insert types
-	log.Printf("    Inserting %d types for pokemon %d (%s)...", len(pokemon.Types), pokemon.ID, pokemon.Name)
 	for _, t := range pokemon.Types {
 		if err := g.pokemonSyncer.InsertType(&t, pokemon.ID); err != nil {
 			return fmt.Errorf("failed to insert type %s for pokemon %d: %w", t.Type.Name, pokemon.ID, err)
 		}
 	}
+	if err := g.pokemonSyncer.InsertPastTypes(pokemon); err != nil {
+		return err
+	}
 
-	// Sync and insert moves
-	log.Printf("    Syncing %d moves for pokemon %d (%s)...", len(pokemon.Moves), pokemon.ID, pokemon.Name)
-	movesInserted := 0
+	// Count the moves first seen here towards the progress totals
+	moveIDs := make([]int, 0, len(pokemon.Moves))
 	for _, m := range pokemon.Moves {
 		moveId, err := utils.ExtractIDFromURL(m.Move.Url)
 		if err != nil {
 			return fmt.Errorf("failed to extract move ID: %w", err)
 		}
+		moveIDs = append(moveIDs, moveId)
+		if !g.seenMoves[moveId] {
+			g.seenMoves[moveId] = true
+			g.Progress.AddTotal(progress.Moves, 1)
+		}
+	}
+
+	// Sync and insert moves
+	movesInserted := 0
+	for i, m := range pokemon.Moves {
+		moveId := moveIDs[i]
 
 		// Sync the move itself (inserts into moves table)
 		if err := g.moveSyncer.SyncMove(moveId); err != nil {
+			// The move was counted towards the total above, it is done either way
+			g.Progress.Failed()
+			g.Progress.Done(progress.Moves)
 			return fmt.Errorf("failed to sync move %d for pokemon %d: %w", moveId, pokemon.ID, err)
 		}
 
@@ -288,13 +577,18 @@ func (g *GameSyncer) syncPokemonData(pokemonID int, versionGroupID int) error {
 					return fmt.Errorf("failed to insert pokemon_move: %w", err)
 				}
 				movesInserted++
+
+				if vgDetail.MoveLearnMethod.Name == "machine" {
+					if err := g.syncMachine(moveId, versionGroupID); err != nil {
+						return err
+					}
+				}
 			}
 		}
 	}
-	log.Printf("    Inserted %d pokemon_moves for version_group %d", movesInserted, versionGroupID)
+	g.Progress.Inserted(movesInserted)
 
 	// Insert abilities
-	log.Printf("    Inserting %d abilities for pokemon %d (%s)...", len(pokemon.Abilities), pokemon.ID, pokemon.Name)
 	for _, a := range pokemon.Abilities {
 		if err := g.pokemonSyncer.InsertAbility(&a, pokemon.ID); err != nil {
 			return fmt.Errorf("failed to insert ability %s for pokemon %d: %w", a.Ability.Name, pokemon.ID, err)
@@ -303,3 +597,20 @@ func (g *GameSyncer) syncPokemonData(pokemonID int, versionGroupID int) error {
 
 	return nil
 }
+
+// syncMachine syncs the TM/HM/TR teaching a move in a version group, if there is one
+func (g *GameSyncer) syncMachine(moveID, versionGroupID int) error {
+	machineID, err := g.moveSyncer.MachineID(moveID, versionGroupID)
+	if err != nil {
+		return fmt.Errorf("failed to find machine for move %d: %w", moveID, err)
+	}
+	if machineID == 0 {
+		return nil
+	}
+
+	if err := g.itemSyncer.SyncMachine(machineID); err != nil {
+		return fmt.Errorf("failed to sync machine %d for move %d: %w", machineID, moveID, err)
+	}
+
+	return nil
+}
@@ -1 +1,216 @@
 package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsVarietyAvailable(t *testing.T) {
+	blackWhite := &external.VersionGroup{ID: 11, Name: "black-white", Order: 11}
+	xy := &external.VersionGroup{ID: 15, Name: "x-y", Order: 15}
+	sunMoon := &external.VersionGroup{ID: 17, Name: "sun-moon", Order: 17}
+	swordShield := &external.VersionGroup{ID: 20, Name: "sword-shield", Order: 20}
+	scarletViolet := &external.VersionGroup{ID: 25, Name: "scarlet-violet", Order: 25}
+
+	learnsInSunMoon := []external.MoveResponse{
+		{
+			Move: external.Response{Name: "thunderbolt", Url: "https://pokeapi.co/api/v2/move/85/"},
+			VersionGroupDetails: []external.VersionGroupDetail{
+				{VersionGroup: external.Response{Name: "sun-moon", Url: "https://pokeapi.co/api/v2/version-group/17/"}},
+			},
+		},
+	}
+	venusaurMega := &external.Pokemon{
+		ID:    10033,
+		Name:  "venusaur-mega",
+		Forms: []external.Response{{Name: "venusaur-mega", Url: "https://pokeapi.co/api/v2/pokemon-form/10033/"}},
+	}
+	pikachuGmax := &external.Pokemon{
+		ID:    10199,
+		Name:  "pikachu-gmax",
+		Forms: []external.Response{{Name: "pikachu-gmax", Url: "https://pokeapi.co/api/v2/pokemon-form/10404/"}},
+	}
+	floetteEternal := &external.Pokemon{
+		ID:    10061,
+		Name:  "floette-eternal",
+		Forms: []external.Response{{Name: "floette-eternal", Url: "https://pokeapi.co/api/v2/pokemon-form/10061/"}},
+	}
+	floetteEternalForm := &external.PokemonForm{
+		ID:           10061,
+		Name:         "floette-eternal",
+		IsDefault:    true,
+		VersionGroup: external.Response{Name: "x-y", Url: "https://pokeapi.co/api/v2/version-group/15/"},
+	}
+
+	tests := []struct {
+		name         string
+		variety      external.Variety
+		pokemon      *external.Pokemon
+		versionGroup *external.VersionGroup
+		expected     bool
+	}{
+		{
+			name:         "Default variety is always available",
+			variety:      external.Variety{IsDefault: true},
+			pokemon:      &external.Pokemon{ID: 26, Name: "raichu"},
+			versionGroup: xy,
+			expected:     true,
+		},
+		{
+			name:         "Regional form available where it has a learnset",
+			variety:      external.Variety{IsDefault: false},
+			pokemon:      &external.Pokemon{ID: 10100, Name: "raichu-alola", Moves: learnsInSunMoon},
+			versionGroup: sunMoon,
+			expected:     true,
+		},
+		{
+			name:         "Regional form not available before its learnset",
+			variety:      external.Variety{IsDefault: false},
+			pokemon:      &external.Pokemon{ID: 10100, Name: "raichu-alola", Moves: learnsInSunMoon},
+			versionGroup: xy,
+			expected:     false,
+		},
+		{
+			name:         "Mega available in a game with Megas",
+			variety:      external.Variety{IsDefault: false},
+			pokemon:      venusaurMega,
+			versionGroup: sunMoon,
+			expected:     true,
+		},
+		{
+			name:         "Mega not available in Sword/Shield",
+			variety:      external.Variety{IsDefault: false},
+			pokemon:      venusaurMega,
+			versionGroup: swordShield,
+			expected:     false,
+		},
+		{
+			name:         "Mega not available in Scarlet/Violet",
+			variety:      external.Variety{IsDefault: false},
+			pokemon:      venusaurMega,
+			versionGroup: scarletViolet,
+			expected:     false,
+		},
+		{
+			name:         "Gigantamax available in Sword/Shield",
+			variety:      external.Variety{IsDefault: false},
+			pokemon:      pikachuGmax,
+			versionGroup: swordShield,
+			expected:     true,
+		},
+		{
+			name:         "Gigantamax not available in Scarlet/Violet",
+			variety:      external.Variety{IsDefault: false},
+			pokemon:      pikachuGmax,
+			versionGroup: scarletViolet,
+			expected:     false,
+		},
+		{
+			name:         "Variety without learnset available from its introduction",
+			variety:      external.Variety{IsDefault: false},
+			pokemon:      floetteEternal,
+			versionGroup: sunMoon,
+			expected:     true,
+		},
+		{
+			name:         "Variety without learnset not available before its introduction",
+			variety:      external.Variety{IsDefault: false},
+			pokemon:      floetteEternal,
+			versionGroup: blackWhite,
+			expected:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := new(MockVersionAPIClient)
+			mockClient.On("FetchVersionGroup", 15).Return(xy, nil).Maybe()
+			mockPokemonClient := new(MockPokemonAPIClient)
+			mockPokemonClient.On("FetchPokemonForm", 10061).Return(floetteEternalForm, nil).Maybe()
+
+			rateLimiter := time.NewTicker(1 * time.Millisecond)
+			defer rateLimiter.Stop()
+
+			versionSyncer := NewVersionSyncer(mockClient, new(MockIGDBClient), new(MockVersionRepo), new(MockImageDownloader), rateLimiter)
+			pokemonSyncer := NewPokemonSyncer(mockPokemonClient, new(MockPokemonRepo), new(MockImageDownloader), rateLimiter)
+			syncer := NewGameSyncer(versionSyncer, nil, pokemonSyncer, nil, nil, nil, rateLimiter)
+
+			available, err := syncer.isVarietyAvailable(tt.variety, tt.pokemon, tt.versionGroup)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, available)
+		})
+	}
+}
+
+func TestSyncSpeciesVarietiesSkipsUnavailableVarieties(t *testing.T) {
+	scarletViolet := &external.VersionGroup{ID: 25, Name: "scarlet-violet", Order: 25}
+	species := &external.Species{
+		ID:   3,
+		Name: "venusaur",
+		Varieties: []external.Variety{
+			{IsDefault: false, Pokemon: external.Response{Name: "venusaur-mega", Url: "https://pokeapi.co/api/v2/pokemon/10033/"}},
+		},
+	}
+
+	mockClient := new(MockPokemonAPIClient)
+	mockClient.On("FetchPokemon", 10033).Return(&external.Pokemon{
+		ID:      10033,
+		Name:    "venusaur-mega",
+		Species: external.Response{Name: "venusaur", Url: "https://pokeapi.co/api/v2/pokemon-species/3/"},
+		Forms:   []external.Response{{Name: "venusaur-mega", Url: "https://pokeapi.co/api/v2/pokemon-form/10033/"}},
+	}, nil)
+	mockRepo := new(MockPokemonRepo)
+	mockImages := new(MockImageDownloader)
+
+	rateLimiter := time.NewTicker(1 * time.Millisecond)
+	defer rateLimiter.Stop()
+
+	pokemonSyncer := NewPokemonSyncer(mockClient, mockRepo, mockImages, rateLimiter)
+	syncer := NewGameSyncer(nil, nil, pokemonSyncer, nil, nil, nil, rateLimiter)
+
+	err := syncer.syncSpeciesVarieties(species, &external.Version{ID: 40, Name: "scarlet"}, scarletViolet)
+	require.NoError(t, err)
+
+	// Nothing of the Mega is fetched beyond the pokemon, stored or downloaded
+	mockClient.AssertNotCalled(t, "FetchPokemonForm", mock.Anything)
+	mockRepo.AssertNotCalled(t, "InsertPokemon", mock.Anything)
+	mockRepo.AssertNotCalled(t, "InsertPokemonForm", mock.Anything)
+	mockImages.AssertNotCalled(t, "Download", mock.Anything)
+}
+
+func TestSyncPokemonDataMarksFailedMovesDone(t *testing.T) {
+	pokemon := &external.Pokemon{
+		ID:   25,
+		Name: "pikachu",
+		Moves: []external.MoveResponse{
+			{Move: external.Response{Name: "thunderbolt", Url: "https://pokeapi.co/api/v2/move/85/"}},
+		},
+	}
+
+	mockPokemonRepo := new(MockPokemonRepo)
+	mockPokemonRepo.On("InsertPastTypes", pokemon).Return(nil)
+	mockMoveClient := new(MockMoveAPIClient)
+	mockMoveClient.On("FetchMove", 85).Return(nil, errors.New("connection reset"))
+
+	rateLimiter := time.NewTicker(1 * time.Millisecond)
+	defer rateLimiter.Stop()
+
+	pokemonSyncer := NewPokemonSyncer(new(MockPokemonAPIClient), mockPokemonRepo, new(MockImageDownloader), rateLimiter)
+	moveSyncer := NewMoveSyncer(mockMoveClient, new(MockMoveRepo), rateLimiter)
+	syncer := NewGameSyncer(nil, nil, pokemonSyncer, moveSyncer, nil, nil, rateLimiter)
+	syncer.Progress = progress.NewTracker()
+
+	err := syncer.syncPokemonData(pokemon, 17)
+	require.Error(t, err)
+
+	s := syncer.Progress.Snapshot()
+	assert.Equal(t, []progress.Stage{{Name: progress.Moves, Done: 1, Total: 1}}, s.Stages)
+	assert.Equal(t, 1, s.Failed)
+}
@@ -1,15 +1,20 @@
 package services
 
 import (
+	"context"
+	"time"
+
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/igdb"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/scraper"
 )
 
 type PokemonAPIClient interface {
 	FetchAll(path string) ([]external.Response, error)
 	FetchPokemon(id int) (*external.Pokemon, error)
 	FetchSpecies(id int) (*external.Species, error)
+	FetchPokemonForm(id int) (*external.PokemonForm, error)
 }
 
 type VersionAPIClient interface {
@@ -21,6 +26,7 @@ type VersionAPIClient interface {
 type MoveAPIClient interface {
 	FetchAll(path string) ([]external.Response, error)
 	FetchMove(id int) (*external.Move, error)
+	FetchVersionGroup(id int) (*external.VersionGroup, error)
 }
 
 type PokedexAPIClient interface {
@@ -28,24 +34,67 @@ type PokedexAPIClient interface {
 	FetchPokedex(id int) (*external.Pokedex, error)
 }
 
+type EncounterAPIClient interface {
+	FetchPokemonEncounters(pokemonID int) ([]external.LocationAreaEncounter, error)
+	FetchLocationArea(id int) (*external.LocationArea, error)
+	FetchLocation(id int) (*external.Location, error)
+}
+
+type ItemAPIClient interface {
+	FetchAll(path string) ([]external.Response, error)
+	FetchItem(id int) (*external.Item, error)
+	FetchMachine(id int) (*external.Machine, error)
+}
+
+type NatureAPIClient interface {
+	FetchAll(path string) ([]external.Response, error)
+	FetchNature(id int) (*external.Nature, error)
+	FetchCharacteristic(id int) (*external.Characteristic, error)
+}
+
+type TypeAPIClient interface {
+	FetchAll(path string) ([]external.Response, error)
+	FetchType(id int) (*external.Type, error)
+}
+
 type MoveRepo interface {
 	InsertMove(v *external.Move) error
+	InsertMovePastValues(v *external.Move, versionGroupOrders map[int]int) error
+	InsertMoveStatChanges(v *external.Move) error
 	InsertPokemonMove(pokemonID, moveID, versionGroupID int, learnMethod string, levelLearnedAt int) error
 	GetMoveByID(id int) (*dto.Move, error)
+	GetMoveForVersionGroup(id, versionGroupID int) (*dto.Move, error)
+	GetPokemonMoves(pokemonID, versionGroupID int) ([]*dto.PokemonMove, error)
 }
 
 type VersionRepo interface {
 	InsertVersion(v *external.Version) error
 	InsertVersionGroup(v *external.VersionGroup) error
 	GetVersionByID(id int) (*dto.Version, error)
+	GetVersionGroupByID(id int) (*dto.VersionGroup, error)
+	GetVersionGroupVersions(versionGroupName string) ([]*dto.Version, error)
+	InsertVersionMetadata(m *dto.VersionMetadata) error
+	GetVersionMetadata(versionID int) (*dto.VersionMetadata, error)
+	GetVersions() ([]*dto.Version, error)
+	InsertGameMapping(m *dto.GameMapping) error
+	GetGameMappings() ([]*dto.GameMapping, error)
 }
 
 type PokemonRepo interface {
 	InsertPokemon(p *external.Pokemon) error
 	InsertType(p *external.PokemonType, pokemonId int) error
+	InsertPastTypes(p *external.Pokemon) error
 	InsertAbility(p *external.Ability, pokemonId int) error
 	InsertSpecies(p *external.Species) error
+	InsertPokemonForm(f *external.PokemonForm) error
+	InsertPokemonFormVersionGroup(formID, versionGroupID int) error
+	InsertPokemonSprites(pokemonID int, sprites []*dto.PokemonSprite) error
+	GetPokemonSprites(pokemonID int, versionGroup string) ([]*dto.PokemonSprite, error)
 	GetPokemonByID(id int) (*dto.Pokemon, error)
+	GetSpeciesForms(speciesID, versionGroupID int) ([]*dto.PokemonForm, error)
+	GetPokemonTypes(pokemonID, generation int) ([]string, error)
+	GetSpeciesByID(id int) (*dto.Species, error)
+	GetBreedingCandidates(moveID, versionGroupID int) ([]*dto.BreedingCandidate, error)
 }
 
 type PokedexRepo interface {
@@ -53,8 +102,111 @@ type PokedexRepo interface {
 	InsertPokedexEntry(p *external.PokedexEntry) error
 	InsertVersionGroupPokedex(versionGroupPokedex *external.VersionGroup) error
 	GetPokedexByID(id int) (*dto.Pokedex, error)
+	GetPokedexEntries(pokedexID int) ([]*dto.PokemonEntry, error)
+	GetVersionGroupPokedexes(versionGroupID int) ([]*dto.Pokedex, error)
+}
+
+type EncounterRepo interface {
+	InsertLocation(l *external.Location) error
+	InsertLocationArea(la *external.LocationArea) error
+	InsertEncounter(pokemonID, locationAreaID, versionID int, detail *external.EncounterDetail) error
+	GetEncounters(pokemonID, versionID int) ([]*dto.Encounter, error)
+	GetLocationEncounters(locationAreaID, versionID int) ([]*dto.Encounter, error)
+	GetSpeciesLocations(versionGroupID int) ([]*dto.SpeciesLocation, error)
+	GetVersionExclusives(versionGroupID int) ([]*dto.VersionExclusives, error)
+	GetLocationByID(id int) (*dto.Location, error)
+	GetVersionRegions(versionID int) ([]string, error)
+}
+
+type ItemRepo interface {
+	InsertItem(item *external.Item) error
+	InsertMachine(m *external.Machine) error
+	GetItemByName(name string) (*dto.Item, error)
+	GetMachinesByVersionGroup(versionGroupID int) ([]*dto.Machine, error)
+}
+
+type NatureRepo interface {
+	InsertNature(n *external.Nature) error
+	InsertCharacteristic(c *external.Characteristic) error
+	GetNatureByName(name string) (*dto.Nature, error)
+	GetCharacteristicByID(id int) (*dto.Characteristic, error)
+}
+
+type TrainerScraper interface {
+	ScrapeTrainers(versionGroup string) ([]*scraper.Trainer, error)
+}
+
+type TypeRepo interface {
+	InsertType(t *external.Type) error
+	GetTypeChart(generation int) (*dto.TypeChart, error)
+}
+
+type TrainerRepo interface {
+	InsertTrainer(t *dto.Trainer) error
+	GetTrainers(versionID int) ([]*dto.Trainer, error)
+	GetTrainerByID(id int) (*dto.Trainer, error)
+}
+
+type UserRepo interface {
+	InsertUser(username, passwordHash string, createdAt time.Time) (*dto.User, error)
+	GetUserByUsername(username string) (*dto.User, error)
+	InsertSession(tokenHash string, userID int, createdAt, expiresAt time.Time) error
+	GetSessionUser(tokenHash string, now time.Time) (*dto.User, error)
+	DeleteSession(tokenHash string) error
+	DeleteExpiredSessions(now time.Time) error
+}
+
+type PlaythroughRepo interface {
+	InsertPlaythrough(p *dto.Playthrough, now time.Time) error
+	GetPlaythroughs(userID int) ([]*dto.Playthrough, error)
+	GetPlaythrough(userID, id int) (*dto.Playthrough, error)
+	UpdatePlaythrough(p *dto.Playthrough, now time.Time) error
+	DeletePlaythrough(userID, id int) error
+	SetSpeciesStatus(playthroughID, speciesID int, status string, now time.Time) error
+	DeleteSpeciesStatus(playthroughID, speciesID int, now time.Time) error
+	SetParty(playthroughID int, party []dto.PartyMember, now time.Time) error
+}
+
+type LivingDexRepo interface {
+	InsertCaught(userID, pokedexID, speciesID int, now time.Time) error
+	DeleteCaught(userID, pokedexID, speciesID int) error
+	GetCaughtSpecies(userID, pokedexID int) (map[int]bool, error)
+}
+
+// ImageDownloader downloads an image once and returns where it's stored
+type ImageDownloader interface {
+	Download(url string) (*dto.Asset, error)
+}
+
+type AssetStore interface {
+	Fetch(sourceURL string) (*dto.Asset, error)
+	Has(a *dto.Asset) bool
+}
+
+type AssetRepo interface {
+	InsertAsset(a *dto.Asset) error
+	GetAssetBySourceURL(sourceURL string) (*dto.Asset, error)
 }
 
 type IGDBClient interface {
-	GetPokemonGameCover(versionName string) (*igdb.Game, error)
+	GetGames(ctx context.Context, gameIDs map[string]int) (map[string]*igdb.Game, error)
+	SearchGame(ctx context.Context, gameName string) ([]igdb.Game, error)
+	GetGameByID(ctx context.Context, gameID int) (*igdb.Game, error)
+}
+
+type NuzlockeRepo interface {
+	InsertRun(run *dto.NuzlockeRun, now time.Time) error
+	GetRuns(userID int) ([]*dto.NuzlockeRun, error)
+	GetRun(userID, id int) (*dto.NuzlockeRun, error)
+	DeleteRun(userID, id int) error
+	InsertEncounter(e *dto.NuzlockeEncounter, now time.Time) error
+	UpdateEncounter(e *dto.NuzlockeEncounter, now time.Time) error
+	DeleteEncounter(runID, id int, now time.Time) error
+}
+
+type SyncFailureRepo interface {
+	InsertSyncFailure(f *dto.SyncFailure) error
+	GetSyncFailures() ([]*dto.SyncFailure, error)
+	DeleteSyncFailure(f *dto.SyncFailure) error
+	DeleteSyncFailures() error
 }
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/progress"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
+)
+
+type ItemSyncer struct {
+	client         ItemAPIClient
+	repo           ItemRepo
+	images         ImageDownloader
+	rateLimiter    *time.Ticker
+	syncedItems    map[int]bool // In-memory cache of synced item IDs
+	syncedMachines map[int]bool // In-memory cache of synced machine IDs
+	mu             sync.Mutex   // Protects cache maps
+
+	Progress *progress.Tracker // Optional
+	Failures *Failures         // Optional, items that fail are skipped with it
+}
+
+func NewItemSyncer(client ItemAPIClient, repo ItemRepo, images ImageDownloader, rateLimiter *time.Ticker) *ItemSyncer {
+	return &ItemSyncer{
+		client:         client,
+		repo:           repo,
+		images:         images,
+		rateLimiter:    rateLimiter,
+		syncedItems:    make(map[int]bool),
+		syncedMachines: make(map[int]bool),
+	}
+}
+
+func (s *ItemSyncer) SyncItem(id int) error {
+	// Check cache first
+	s.mu.Lock()
+	if s.syncedItems[id] {
+		s.mu.Unlock()
+		s.Progress.CacheHit()
+		return nil
+	}
+	s.mu.Unlock()
+
+	item, err := s.client.FetchItem(id)
+	if err != nil {
+		return err
+	}
+
+	// Download and save the item sprite locally
+	if item.Sprites.Default != "" {
+		asset, err := s.images.Download(item.Sprites.Default)
+		if err != nil {
+			logger().Warn("failed to download item sprite", "item", item.Name, "err", err)
+			s.Progress.Failed()
+			item.Sprites.Default = ""
+		} else {
+			item.Sprites.Default = asset.Path
+		}
+	}
+
+	if err := s.repo.InsertItem(item); err != nil {
+		return err
+	}
+	s.Progress.Inserted(1)
+
+	// Mark as synced in cache
+	s.mu.Lock()
+	s.syncedItems[id] = true
+	s.mu.Unlock()
+
+	return nil
+}
+
+// SyncMachine inserts a TM/HM/TR to move mapping along with its item.
+// The move and version group it references must already be synced.
+func (s *ItemSyncer) SyncMachine(id int) error {
+	// Check cache first
+	s.mu.Lock()
+	if s.syncedMachines[id] {
+		s.mu.Unlock()
+		s.Progress.CacheHit()
+		return nil
+	}
+	s.mu.Unlock()
+
+	machine, err := s.client.FetchMachine(id)
+	if err != nil {
+		return err
+	}
+
+	itemID, err := utils.ExtractIDFromURL(machine.Item.Url)
+	if err != nil {
+		return err
+	}
+	if err := s.SyncItem(itemID); err != nil {
+		return fmt.Errorf("failed to sync item %d: %w", itemID, err)
+	}
+
+	if err := s.repo.InsertMachine(machine); err != nil {
+		return err
+	}
+	s.Progress.Inserted(1)
+
+	// Mark as synced in cache
+	s.mu.Lock()
+	s.syncedMachines[id] = true
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *ItemSyncer) SyncAll(limit int) error {
+	allItems, err := s.client.FetchAll(fmt.Sprintf("item?limit=%d", limit))
+	if err != nil {
+		return fmt.Errorf("failed to fetch items: %w", err)
+	}
+	s.Progress.AddTotal(progress.Items, len(allItems))
+
+	for i, ai := range allItems {
+		if i > 0 {
+			<-s.rateLimiter.C
+		}
+
+		id, err := utils.ExtractIDFromURL(ai.Url)
+		if err != nil {
+			return err
+		}
+
+		if err := s.SyncItem(id); err != nil {
+			err = fmt.Errorf("failed to sync item %d (%s): %w", id, ai.Name, err)
+			if err := s.Failures.Record(dto.ResourceItem, id, 0, err); err != nil {
+				return err
+			}
+		}
+		s.Progress.Done(progress.Items)
+	}
+
+	return nil
+}
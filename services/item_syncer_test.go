@@ -0,0 +1,96 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockItemAPIClient struct {
+	mock.Mock
+}
+
+func (m *MockItemAPIClient) FetchAll(path string) ([]external.Response, error) {
+	args := m.Called(path)
+	return args.Get(0).([]external.Response), args.Error(1)
+}
+
+func (m *MockItemAPIClient) FetchItem(id int) (*external.Item, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*external.Item), args.Error(1)
+}
+
+func (m *MockItemAPIClient) FetchMachine(id int) (*external.Machine, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*external.Machine), args.Error(1)
+}
+
+type MockItemRepo struct {
+	mock.Mock
+}
+
+func (m *MockItemRepo) InsertItem(item *external.Item) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+func (m *MockItemRepo) InsertMachine(machine *external.Machine) error {
+	args := m.Called(machine)
+	return args.Error(0)
+}
+
+func (m *MockItemRepo) GetItemByName(name string) (*dto.Item, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.Item), args.Error(1)
+}
+
+func (m *MockItemRepo) GetMachinesByVersionGroup(versionGroupID int) ([]*dto.Machine, error) {
+	args := m.Called(versionGroupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.Machine), args.Error(1)
+}
+
+func TestSyncMachine(t *testing.T) {
+	t.Run("Syncs the machine item once", func(t *testing.T) {
+		mockClient := new(MockItemAPIClient)
+		mockRepo := new(MockItemRepo)
+
+		mockClient.On("FetchMachine", 24).Return(&external.Machine{
+			ID:           24,
+			Item:         external.Response{Name: "tm24", Url: "https://pokeapi.co/api/v2/item/328/"},
+			Move:         external.Response{Name: "thunderbolt", Url: "https://pokeapi.co/api/v2/move/85/"},
+			VersionGroup: external.Response{Name: "red-blue", Url: "https://pokeapi.co/api/v2/version-group/1/"},
+		}, nil).Once()
+		mockClient.On("FetchItem", 328).Return(&external.Item{ID: 328, Name: "tm24"}, nil).Once()
+
+		mockRepo.On("InsertItem", mock.AnythingOfType("*external.Item")).Return(nil).Once()
+		mockRepo.On("InsertMachine", mock.AnythingOfType("*external.Machine")).Return(nil).Once()
+
+		rateLimiter := time.NewTicker(1 * time.Millisecond)
+		defer rateLimiter.Stop()
+
+		syncer := NewItemSyncer(mockClient, mockRepo, new(MockImageDownloader), rateLimiter)
+
+		require.NoError(t, syncer.SyncMachine(24))
+		// Cached, no second fetch
+		require.NoError(t, syncer.SyncMachine(24))
+
+		mockClient.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+)
+
+// LivingDexService tracks which pokedex entries a user has caught. Pokedexes
+// and encounters come from the reference data, caught state from the user data.
+type LivingDexService struct {
+	repo          LivingDexRepo
+	pokedexRepo   PokedexRepo
+	versionRepo   VersionRepo
+	encounterRepo EncounterRepo
+	now           func() time.Time
+}
+
+func NewLivingDexService(repo LivingDexRepo, pokedexRepo PokedexRepo, versionRepo VersionRepo, encounterRepo EncounterRepo) *LivingDexService {
+	return &LivingDexService{
+		repo:          repo,
+		pokedexRepo:   pokedexRepo,
+		versionRepo:   versionRepo,
+		encounterRepo: encounterRepo,
+		now:           time.Now,
+	}
+}
+
+// GetLivingDex returns every entry of a pokedex with its caught state
+func (s *LivingDexService) GetLivingDex(userID, pokedexID int) (*dto.LivingDex, error) {
+	pokedex, entries, caught, err := s.load(userID, pokedexID)
+	if err != nil {
+		return nil, err
+	}
+
+	dex := &dto.LivingDex{
+		DexProgress: dexProgress(pokedex, entries, caught),
+		Entries:     make([]dto.DexEntry, 0, len(entries)),
+	}
+	for _, e := range entries {
+		dex.Entries = append(dex.Entries, dto.DexEntry{
+			EntryNumber: e.EntryNumber,
+			SpeciesID:   e.SpeciesID,
+			Name:        e.Name,
+			Caught:      caught[e.SpeciesID],
+		})
+	}
+
+	return dex, nil
+}
+
+// SetCaught marks a pokedex entry as caught or uncaught and returns the new progress
+func (s *LivingDexService) SetCaught(userID, pokedexID, speciesID int, isCaught bool) (*dto.DexProgress, error) {
+	pokedex, entries, _, err := s.load(userID, pokedexID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(entries, func(e *dto.PokemonEntry) bool { return e.SpeciesID == speciesID }) {
+		return nil, fmt.Errorf("%w: species %d is not in pokedex %s", ErrInvalidInput, speciesID, pokedex.Name)
+	}
+
+	if isCaught {
+		err = s.repo.InsertCaught(userID, pokedexID, speciesID, s.now())
+	} else {
+		err = s.repo.DeleteCaught(userID, pokedexID, speciesID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	caught, err := s.repo.GetCaughtSpecies(userID, pokedexID)
+	if err != nil {
+		return nil, err
+	}
+	p := dexProgress(pokedex, entries, caught)
+	return &p, nil
+}
+
+// GetGameProgress returns the progress of every pokedex of a game's version group
+func (s *LivingDexService) GetGameProgress(userID, versionID int) (*dto.GameProgress, error) {
+	version, err := s.versionRepo.GetVersionByID(versionID)
+	if err != nil {
+		return nil, err
+	}
+	pokedexes, err := s.pokedexRepo.GetVersionGroupPokedexes(version.VersionGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	game := &dto.GameProgress{
+		Version:   *version,
+		Pokedexes: make([]dto.DexProgress, 0, len(pokedexes)),
+	}
+	species := make(map[int]bool)
+	for _, pokedex := range pokedexes {
+		_, entries, caught, err := s.load(userID, pokedex.ID)
+		if err != nil {
+			return nil, err
+		}
+		game.Pokedexes = append(game.Pokedexes, dexProgress(pokedex, entries, caught))
+
+		for _, e := range entries {
+			species[e.SpeciesID] = species[e.SpeciesID] || caught[e.SpeciesID]
+		}
+	}
+
+	game.Total = len(species)
+	for _, isCaught := range species {
+		if isCaught {
+			game.Caught++
+		}
+	}
+	game.Percent = completion(game.Caught, game.Total)
+
+	return game, nil
+}
+
+// GetMissing returns the uncaught entries of a pokedex with the locations they
+// can be encountered at in the versions of a game's version group
+func (s *LivingDexService) GetMissing(userID, pokedexID, versionID int) ([]*dto.MissingEntry, error) {
+	_, entries, caught, err := s.load(userID, pokedexID)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.versionRepo.GetVersionByID(versionID)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := s.encounterRepo.GetSpeciesLocations(version.VersionGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rows are ordered by species, version and location
+	availability := make(map[int][]dto.VersionAvailability)
+	catchableByChain := make(map[int][]string)
+	versions := make(map[int]bool)
+	for _, l := range locations {
+		versions[l.VersionID] = true
+
+		a := availability[l.SpeciesID]
+		if len(a) == 0 || a[len(a)-1].VersionID != l.VersionID {
+			a = append(a, dto.VersionAvailability{VersionID: l.VersionID, Version: l.VersionName})
+		}
+		a[len(a)-1].Locations = append(a[len(a)-1].Locations, l.LocationName)
+		availability[l.SpeciesID] = a
+
+		if l.EvolutionChainID != 0 && !slices.Contains(catchableByChain[l.EvolutionChainID], l.SpeciesName) {
+			catchableByChain[l.EvolutionChainID] = append(catchableByChain[l.EvolutionChainID], l.SpeciesName)
+		}
+	}
+
+	missing := []*dto.MissingEntry{}
+	for _, e := range entries {
+		if caught[e.SpeciesID] {
+			continue
+		}
+
+		entry := &dto.MissingEntry{
+			EntryNumber:  e.EntryNumber,
+			SpeciesID:    e.SpeciesID,
+			Name:         e.Name,
+			Availability: availability[e.SpeciesID],
+		}
+		if entry.Availability == nil {
+			entry.Availability = []dto.VersionAvailability{}
+			entry.EvolutionFamily = catchableByChain[e.EvolutionChainID]
+		} else if len(entry.Availability) < len(versions) {
+			for _, a := range entry.Availability {
+				entry.ExclusiveTo = append(entry.ExclusiveTo, a.Version)
+			}
+		}
+		missing = append(missing, entry)
+	}
+
+	return missing, nil
+}
+
+// WriteChecklist writes a pokedex as CSV with one row per entry
+func (s *LivingDexService) WriteChecklist(w io.Writer, userID, pokedexID int) error {
+	_, entries, caught, err := s.load(userID, pokedexID)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"number", "species", "caught"}); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		record := []string{strconv.Itoa(e.EntryNumber), e.Name, strconv.FormatBool(caught[e.SpeciesID])}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+func (s *LivingDexService) load(userID, pokedexID int) (*dto.Pokedex, []*dto.PokemonEntry, map[int]bool, error) {
+	pokedex, err := s.pokedexRepo.GetPokedexByID(pokedexID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	entries, err := s.pokedexRepo.GetPokedexEntries(pokedexID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	caught, err := s.repo.GetCaughtSpecies(userID, pokedexID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return pokedex, entries, caught, nil
+}
+
+func dexProgress(pokedex *dto.Pokedex, entries []*dto.PokemonEntry, caught map[int]bool) dto.DexProgress {
+	p := dto.DexProgress{Pokedex: *pokedex, Total: len(entries)}
+	for _, e := range entries {
+		if caught[e.SpeciesID] {
+			p.Caught++
+		}
+	}
+	p.Percent = completion(p.Caught, p.Total)
+	return p
+}
+
+// completion returns caught/total as a percentage with one decimal
+func completion(caught, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return percentOf(caught, total)
+}
@@ -1,24 +1,37 @@
 package services
 
 import (
+	"fmt"
 	"sync"
 	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/progress"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
 )
 
 type MoveSyncer struct {
-	client      MoveAPIClient
-	repo        MoveRepo
-	rateLimiter *time.Ticker
-	syncedMoves map[int]bool // In-memory cache of synced move IDs
-	mu          sync.Mutex   // Protects syncedMoves map
+	client       MoveAPIClient
+	repo         MoveRepo
+	rateLimiter  *time.Ticker
+	syncedMoves  map[int]bool                   // In-memory cache of synced move IDs
+	moveMachines map[int][]external.MoveMachine // Machines of synced moves, by move ID
+	orders       map[int]int                    // Order of fetched version groups, by ID
+	mu           sync.Mutex                     // Protects cache maps
+
+	Progress *progress.Tracker // Optional
+	Failures *Failures         // Optional, SyncAll skips moves that fail with it
 }
 
 func NewMoveSyncer(client MoveAPIClient, repo MoveRepo, ticker *time.Ticker) *MoveSyncer {
 	return &MoveSyncer{
-		client:      client,
-		repo:        repo,
-		rateLimiter: ticker,
-		syncedMoves: make(map[int]bool),
+		client:       client,
+		repo:         repo,
+		rateLimiter:  ticker,
+		syncedMoves:  make(map[int]bool),
+		moveMachines: make(map[int][]external.MoveMachine),
+		orders:       make(map[int]int),
 	}
 }
 
@@ -28,6 +41,7 @@ func (m *MoveSyncer) SyncMove(id int) error {
 	if m.syncedMoves[id] {
 		m.mu.Unlock()
 		// Already synced in this session, skip API call
+		m.Progress.CacheHit()
 		return nil
 	}
 	m.mu.Unlock()
@@ -42,10 +56,137 @@ func (m *MoveSyncer) SyncMove(id int) error {
 		return err
 	}
 
+	orders, err := m.versionGroupOrders(move)
+	if err != nil {
+		return err
+	}
+	if err := m.repo.InsertMovePastValues(move, orders); err != nil {
+		return err
+	}
+
+	if err := m.repo.InsertMoveStatChanges(move); err != nil {
+		return err
+	}
+
 	// Mark as synced in cache
 	m.mu.Lock()
 	m.syncedMoves[id] = true
+	m.moveMachines[id] = move.Machines
 	m.mu.Unlock()
+	m.Progress.Inserted(1)
+	m.Progress.Done(progress.Moves)
 
 	return nil
 }
+
+// versionGroupOrders returns the order of the version groups in the move's
+// past_values. They're fetched instead of read from the database, a single
+// game sync doesn't store the later version groups a past value points to.
+// Each fetch waits for the rate limiter like every other request.
+func (m *MoveSyncer) versionGroupOrders(move *external.Move) (map[int]int, error) {
+	orders := make(map[int]int, len(move.PastValues))
+	for _, pv := range move.PastValues {
+		id, err := utils.ExtractIDFromURL(pv.VersionGroup.Url)
+		if err != nil {
+			return nil, err
+		}
+
+		m.mu.Lock()
+		order, ok := m.orders[id]
+		m.mu.Unlock()
+		if !ok {
+			<-m.rateLimiter.C
+			versionGroup, err := m.client.FetchVersionGroup(id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch version group %d: %w", id, err)
+			}
+			order = versionGroup.Order
+			m.mu.Lock()
+			m.orders[id] = order
+			m.mu.Unlock()
+		}
+		orders[id] = order
+	}
+	return orders, nil
+}
+
+// MachineID returns the ID of the machine teaching a synced move in a version group,
+// or 0 if the move has no machine there
+func (m *MoveSyncer) MachineID(moveID, versionGroupID int) (int, error) {
+	m.mu.Lock()
+	machines := m.moveMachines[moveID]
+	m.mu.Unlock()
+
+	for _, mm := range machines {
+		vgID, err := utils.ExtractIDFromURL(mm.VersionGroup.Url)
+		if err != nil {
+			return 0, err
+		}
+		if vgID == versionGroupID {
+			return utils.ExtractIDFromURL(mm.Machine.URL)
+		}
+	}
+
+	return 0, nil
+}
+
+// SyncAll syncs every move with the given number of workers. Moves are still
+// started at the rate limiter's pace, more workers only overlap the waits for
+// responses. The first error stops the remaining moves from being started,
+// unless Failures is set.
+func (m *MoveSyncer) SyncAll(limit, workers int) error {
+	allMoves, err := m.client.FetchAll(fmt.Sprintf("move?limit=%d", limit))
+	if err != nil {
+		return fmt.Errorf("failed to fetch moves: %w", err)
+	}
+
+	m.Progress.AddTotal(progress.Moves, len(allMoves))
+
+	ids := make(chan int)
+	errs := make(chan error, max(1, workers))
+	var wg sync.WaitGroup
+
+	for range max(1, workers) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range ids {
+				if err := m.SyncMove(id); err != nil {
+					err = fmt.Errorf("failed to sync move %d: %w", id, err)
+					if err := m.Failures.Record(dto.ResourceMove, id, 0, err); err != nil {
+						errs <- err
+						return
+					}
+					m.Progress.Done(progress.Moves)
+				}
+			}
+		}()
+	}
+
+	var sendErr error
+send:
+	for i, am := range allMoves {
+		id, err := utils.ExtractIDFromURL(am.Url)
+		if err != nil {
+			sendErr = err
+			break
+		}
+		if i > 0 {
+			<-m.rateLimiter.C
+		}
+		select {
+		case ids <- id:
+		case err := <-errs:
+			sendErr = err
+			break send
+		}
+	}
+	close(ids)
+	wg.Wait()
+	close(errs)
+
+	if sendErr != nil {
+		return sendErr
+	}
+	return <-errs // nil once closed and empty
+}
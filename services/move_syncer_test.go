@@ -1,12 +1,17 @@
 package services
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/progress"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type MockMoveAPIClient struct {
@@ -26,6 +31,14 @@ func (m *MockMoveAPIClient) FetchMove(id int) (*external.Move, error) {
 	return args.Get(0).(*external.Move), args.Error(1)
 }
 
+func (m *MockMoveAPIClient) FetchVersionGroup(id int) (*external.VersionGroup, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*external.VersionGroup), args.Error(1)
+}
+
 type MockMoveRepo struct {
 	mock.Mock
 }
@@ -35,6 +48,16 @@ func (mr *MockMoveRepo) InsertMove(m *external.Move) error {
 	return args.Error(0)
 }
 
+func (mr *MockMoveRepo) InsertMovePastValues(m *external.Move, versionGroupOrders map[int]int) error {
+	args := mr.Called(m, versionGroupOrders)
+	return args.Error(0)
+}
+
+func (mr *MockMoveRepo) InsertMoveStatChanges(m *external.Move) error {
+	args := mr.Called(m)
+	return args.Error(0)
+}
+
 func (mr *MockMoveRepo) InsertPokemonMove(pokemonID int, moveID int, versionGroupID int, learnMethod string, levelLearnedAt int) error {
 	args := mr.Called(pokemonID, moveID, versionGroupID, learnMethod, levelLearnedAt)
 	return args.Error(0)
@@ -48,6 +71,22 @@ func (mr *MockMoveRepo) GetMoveByID(id int) (*dto.Move, error) {
 	return args.Get(0).(*dto.Move), args.Error(1)
 }
 
+func (mr *MockMoveRepo) GetMoveForVersionGroup(id, versionGroupID int) (*dto.Move, error) {
+	args := mr.Called(id, versionGroupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.Move), args.Error(1)
+}
+
+func (mr *MockMoveRepo) GetPokemonMoves(pokemonID, versionGroupID int) ([]*dto.PokemonMove, error) {
+	args := mr.Called(pokemonID, versionGroupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.PokemonMove), args.Error(1)
+}
+
 func TestSyncMove(t *testing.T) {
 	t.Run("Sync move success", func(t *testing.T) {
 		mockClient := new(MockMoveAPIClient)
@@ -82,6 +121,8 @@ func TestSyncMove(t *testing.T) {
 
 		mockClient.On("FetchMove", 1).Return(mockResponse, nil)
 		mockRepo.On("InsertMove", mock.AnythingOfType("*external.Move")).Return(nil).Once()
+		mockRepo.On("InsertMovePastValues", mock.AnythingOfType("*external.Move"), mock.Anything).Return(nil).Once()
+		mockRepo.On("InsertMoveStatChanges", mock.AnythingOfType("*external.Move")).Return(nil).Once()
 
 		rateLimiter := time.NewTicker(1 * time.Millisecond)
 
@@ -97,3 +138,101 @@ func TestSyncMove(t *testing.T) {
 		// assert.Equal(t, mockResponse, response)
 	})
 }
+
+func TestMoveSyncer_SyncMovePastValueOrders(t *testing.T) {
+	mockClient := new(MockMoveAPIClient)
+	mockRepo := new(MockMoveRepo)
+	syncer := NewMoveSyncer(mockClient, mockRepo, time.NewTicker(time.Millisecond))
+
+	goldSilver := external.Response{Name: "gold-silver", Url: "https://pokeapi.co/api/v2/version-group/3/"}
+	bite := &external.Move{ID: 44, Name: "bite", PastValues: []external.MovePastValue{{Type: &external.Response{Name: "normal"}, VersionGroup: goldSilver}}}
+	crunch := &external.Move{ID: 242, Name: "crunch", PastValues: []external.MovePastValue{{VersionGroup: goldSilver}}}
+
+	mockClient.On("FetchMove", 44).Return(bite, nil)
+	mockClient.On("FetchMove", 242).Return(crunch, nil)
+	// Fetched once, the second move reuses the order
+	mockClient.On("FetchVersionGroup", 3).Return(&external.VersionGroup{ID: 3, Name: "gold-silver", Order: 3}, nil).Once()
+	mockRepo.On("InsertMove", mock.AnythingOfType("*external.Move")).Return(nil)
+	mockRepo.On("InsertMovePastValues", mock.AnythingOfType("*external.Move"), map[int]int{3: 3}).Return(nil).Twice()
+	mockRepo.On("InsertMoveStatChanges", mock.AnythingOfType("*external.Move")).Return(nil)
+
+	require.NoError(t, syncer.SyncMove(44))
+	require.NoError(t, syncer.SyncMove(242))
+	mockClient.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSyncAllMoves(t *testing.T) {
+	moves := make([]external.Response, 10)
+	for i := range moves {
+		moves[i] = external.Response{Url: fmt.Sprintf("https://pokeapi.co/api/v2/move/%d/", i+1)}
+	}
+
+	t.Run("Syncs every move with several workers", func(t *testing.T) {
+		mockClient := new(MockMoveAPIClient)
+		mockClient.On("FetchAll", "move?limit=10").Return(moves, nil)
+		for i := range moves {
+			mockClient.On("FetchMove", i+1).Return(&external.Move{ID: i + 1}, nil).Once()
+		}
+		mockRepo := new(MockMoveRepo)
+		mockRepo.On("InsertMove", mock.AnythingOfType("*external.Move")).Return(nil).Times(10)
+		mockRepo.On("InsertMovePastValues", mock.AnythingOfType("*external.Move"), mock.Anything).Return(nil).Times(10)
+		mockRepo.On("InsertMoveStatChanges", mock.AnythingOfType("*external.Move")).Return(nil).Times(10)
+
+		syncer := NewMoveSyncer(mockClient, mockRepo, time.NewTicker(time.Millisecond))
+		syncer.Progress = progress.NewTracker()
+		require.NoError(t, syncer.SyncAll(10, 3))
+		mockClient.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+
+		// Synced moves are cache hits from then on
+		require.NoError(t, syncer.SyncMove(1))
+		s := syncer.Progress.Snapshot()
+		assert.Equal(t, []progress.Stage{{Name: progress.Moves, Done: 10, Total: 10}}, s.Stages)
+		assert.Equal(t, 10, s.Inserted)
+		assert.Equal(t, 1, s.CacheHits)
+	})
+
+	t.Run("Stops at the first error", func(t *testing.T) {
+		mockClient := new(MockMoveAPIClient)
+		mockClient.On("FetchAll", "move?limit=10").Return(moves, nil)
+		mockClient.On("FetchMove", mock.Anything).Return(nil, errors.New("connection reset"))
+
+		syncer := NewMoveSyncer(mockClient, new(MockMoveRepo), time.NewTicker(time.Millisecond))
+		err := syncer.SyncAll(10, 2)
+		assert.ErrorContains(t, err, "connection reset")
+		// The list and at most one move per worker, nothing is started after that
+		assert.LessOrEqual(t, len(mockClient.Calls), 3)
+	})
+
+	t.Run("Records failures and carries on", func(t *testing.T) {
+		mockClient := new(MockMoveAPIClient)
+		mockClient.On("FetchAll", "move?limit=10").Return(moves, nil)
+		mockClient.On("FetchMove", 4).Return(nil, errors.New("404 Not Found")).Once()
+		for i := range moves {
+			if i+1 != 4 {
+				mockClient.On("FetchMove", i+1).Return(&external.Move{ID: i + 1}, nil).Once()
+			}
+		}
+		mockRepo := new(MockMoveRepo)
+		mockRepo.On("InsertMove", mock.AnythingOfType("*external.Move")).Return(nil).Times(9)
+		mockRepo.On("InsertMovePastValues", mock.AnythingOfType("*external.Move"), mock.Anything).Return(nil).Times(9)
+		mockRepo.On("InsertMoveStatChanges", mock.AnythingOfType("*external.Move")).Return(nil).Times(9)
+		failureRepo := new(MockSyncFailureRepo)
+		failureRepo.On("InsertSyncFailure", mock.MatchedBy(func(f *dto.SyncFailure) bool {
+			return f.Resource == dto.ResourceMove && f.ResourceID == 4
+		})).Return(nil).Once()
+
+		syncer := NewMoveSyncer(mockClient, mockRepo, time.NewTicker(time.Millisecond))
+		syncer.Progress = progress.NewTracker()
+		syncer.Failures = NewFailures(failureRepo)
+		require.NoError(t, syncer.SyncAll(10, 3))
+		mockClient.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+		failureRepo.AssertExpectations(t)
+
+		s := syncer.Progress.Snapshot()
+		assert.Equal(t, []progress.Stage{{Name: progress.Moves, Done: 10, Total: 10}}, s.Stages)
+		assert.Equal(t, 9, s.Inserted)
+	})
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/progress"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
+)
+
+type NatureSyncer struct {
+	client      NatureAPIClient
+	repo        NatureRepo
+	rateLimiter *time.Ticker
+
+	Progress *progress.Tracker // Optional
+	Failures *Failures         // Optional, natures and characteristics that fail are skipped with it
+}
+
+func NewNatureSyncer(client NatureAPIClient, repo NatureRepo, rateLimiter *time.Ticker) *NatureSyncer {
+	return &NatureSyncer{
+		client:      client,
+		repo:        repo,
+		rateLimiter: rateLimiter,
+	}
+}
+
+// SyncAll syncs all natures and characteristics, there are only 25 and 30 of them
+func (s *NatureSyncer) SyncAll(limit int) error {
+	allNatures, err := s.client.FetchAll(fmt.Sprintf("nature?limit=%d", limit))
+	if err != nil {
+		return fmt.Errorf("failed to fetch natures: %w", err)
+	}
+	s.Progress.AddTotal(progress.Natures, len(allNatures))
+
+	for i, an := range allNatures {
+		if i > 0 {
+			<-s.rateLimiter.C
+		}
+
+		id, err := utils.ExtractIDFromURL(an.Url)
+		if err != nil {
+			return err
+		}
+		if err := s.SyncNature(id); err != nil {
+			if err := s.Failures.Record(dto.ResourceNature, id, 0, err); err != nil {
+				return err
+			}
+		}
+		s.Progress.Done(progress.Natures)
+	}
+
+	allCharacteristics, err := s.client.FetchAll(fmt.Sprintf("characteristic?limit=%d", limit))
+	if err != nil {
+		return fmt.Errorf("failed to fetch characteristics: %w", err)
+	}
+	s.Progress.AddTotal(progress.Characteristics, len(allCharacteristics))
+
+	for _, ac := range allCharacteristics {
+		<-s.rateLimiter.C
+
+		id, err := utils.ExtractIDFromURL(ac.Url)
+		if err != nil {
+			return err
+		}
+		if err := s.SyncCharacteristic(id); err != nil {
+			if err := s.Failures.Record(dto.ResourceCharacteristic, id, 0, err); err != nil {
+				return err
+			}
+		}
+		s.Progress.Done(progress.Characteristics)
+	}
+
+	return nil
+}
+
+func (s *NatureSyncer) SyncNature(id int) error {
+	nature, err := s.client.FetchNature(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch nature %d: %w", id, err)
+	}
+	if err := s.repo.InsertNature(nature); err != nil {
+		return fmt.Errorf("failed to insert nature %d: %w", id, err)
+	}
+	s.Progress.Inserted(1)
+	return nil
+}
+
+func (s *NatureSyncer) SyncCharacteristic(id int) error {
+	characteristic, err := s.client.FetchCharacteristic(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch characteristic %d: %w", id, err)
+	}
+	if err := s.repo.InsertCharacteristic(characteristic); err != nil {
+		return fmt.Errorf("failed to insert characteristic %d: %w", id, err)
+	}
+	s.Progress.Inserted(1)
+	return nil
+}
@@ -0,0 +1,362 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/db"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+)
+
+const maxPartySize = 6
+
+type NuzlockeRunInput struct {
+	VersionID int               `json:"versionId"`
+	Name      string            `json:"name"`
+	Rules     dto.NuzlockeRules `json:"rules"`
+}
+
+// NuzlockeEncounterInput records the first encounter at a location. Status
+// defaults to alive.
+type NuzlockeEncounterInput struct {
+	LocationID int    `json:"locationId"`
+	SpeciesID  int    `json:"speciesId"`
+	Nickname   string `json:"nickname"`
+	Level      int    `json:"level"`
+	Status     string `json:"status"`
+	Shiny      bool   `json:"shiny"`
+	Gift       bool   `json:"gift"`
+}
+
+// NuzlockeEncounterUpdate holds the fields of an encounter that change during a run
+type NuzlockeEncounterUpdate struct {
+	Nickname     string `json:"nickname"`
+	Level        int    `json:"level"`
+	Status       string `json:"status"`
+	CauseOfDeath string `json:"causeOfDeath"`
+}
+
+// NuzlockeService tracks Nuzlocke runs and enforces their rules: one
+// encounter per location, dead pokemon stay dead and at most six alive.
+// Locations and species are checked against the reference data, locations
+// must be in the region of the run's game.
+type NuzlockeService struct {
+	repo          NuzlockeRepo
+	versionRepo   VersionRepo
+	encounterRepo EncounterRepo
+	pokemonRepo   PokemonRepo
+	now           func() time.Time
+}
+
+func NewNuzlockeService(repo NuzlockeRepo, versionRepo VersionRepo, encounterRepo EncounterRepo, pokemonRepo PokemonRepo) *NuzlockeService {
+	return &NuzlockeService{
+		repo:          repo,
+		versionRepo:   versionRepo,
+		encounterRepo: encounterRepo,
+		pokemonRepo:   pokemonRepo,
+		now:           time.Now,
+	}
+}
+
+func (s *NuzlockeService) Create(userID int, in *NuzlockeRunInput) (*dto.NuzlockeRun, error) {
+	if _, err := s.versionRepo.GetVersionByID(in.VersionID); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, fmt.Errorf("%w: version %d does not exist", ErrInvalidInput, in.VersionID)
+		}
+		return nil, err
+	}
+	name, err := validatePlaythroughName(in.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &dto.NuzlockeRun{
+		UserID:    userID,
+		VersionID: in.VersionID,
+		Name:      name,
+		Rules:     in.Rules,
+	}
+	if err := s.repo.InsertRun(run, s.now()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetRun(userID, run.ID)
+}
+
+func (s *NuzlockeService) List(userID int) ([]*dto.NuzlockeRun, error) {
+	return s.repo.GetRuns(userID)
+}
+
+func (s *NuzlockeService) Get(userID, id int) (*dto.NuzlockeRun, error) {
+	return s.repo.GetRun(userID, id)
+}
+
+func (s *NuzlockeService) Delete(userID, id int) error {
+	return s.repo.DeleteRun(userID, id)
+}
+
+// RecordEncounter adds an encounter to a run. Each location allows a single
+// encounter, except for shinies and gifts when their clauses are on. With
+// the dupes clause, species of an already caught evolution family are
+// rejected so the player can look for the next one.
+func (s *NuzlockeService) RecordEncounter(userID, runID int, in *NuzlockeEncounterInput) (*dto.NuzlockeRun, error) {
+	run, err := s.repo.GetRun(userID, runID)
+	if err != nil {
+		return nil, err
+	}
+	if in.Status == "" {
+		in.Status = dto.NuzlockeAlive
+	}
+	if in.Status == dto.NuzlockeDead {
+		return nil, fmt.Errorf("%w: status must be alive, boxed or missed, got %q", ErrInvalidInput, in.Status)
+	}
+	if err := validateNuzlockeEncounter(in.Status, in.Level); err != nil {
+		return nil, err
+	}
+
+	location, err := s.encounterRepo.GetLocationByID(in.LocationID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, fmt.Errorf("%w: location %d does not exist", ErrInvalidInput, in.LocationID)
+		}
+		return nil, err
+	}
+	if err := s.checkRegion(run, location); err != nil {
+		return nil, err
+	}
+	species, err := s.pokemonRepo.GetSpeciesByID(in.SpeciesID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, fmt.Errorf("%w: species %d does not exist", ErrInvalidInput, in.SpeciesID)
+		}
+		return nil, err
+	}
+
+	e := &dto.NuzlockeEncounter{
+		RunID:            runID,
+		LocationID:       location.ID,
+		LocationName:     location.DisplayName,
+		SpeciesID:        species.ID,
+		SpeciesName:      species.Name,
+		EvolutionChainID: species.EvolutionChainID,
+		Nickname:         strings.TrimSpace(in.Nickname),
+		Level:            in.Level,
+		Status:           in.Status,
+		Shiny:            in.Shiny,
+		Gift:             in.Gift,
+	}
+	if err := checkNuzlockeRules(run, e); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.InsertEncounter(e, s.now()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetRun(userID, runID)
+}
+
+// checkRegion rejects locations outside the regions of the run's version.
+// Locations without a region, e.g. some event locations, and versions whose
+// regions aren't synced yet can't be checked and are allowed.
+func (s *NuzlockeService) checkRegion(run *dto.NuzlockeRun, location *dto.Location) error {
+	if location.RegionName == "" {
+		return nil
+	}
+	regions, err := s.encounterRepo.GetVersionRegions(run.VersionID)
+	if err != nil {
+		return err
+	}
+	if len(regions) > 0 && !slices.Contains(regions, location.RegionName) {
+		return fmt.Errorf("%w: %s is in %s, the game takes place in %s", ErrInvalidInput, location.DisplayName, location.RegionName, strings.Join(regions, ", "))
+	}
+	return nil
+}
+
+// UpdateEncounter renames, levels up, boxes or kills a pokemon. Death is
+// permanent and a missed encounter can't be caught afterwards.
+func (s *NuzlockeService) UpdateEncounter(userID, runID, id int, in *NuzlockeEncounterUpdate) (*dto.NuzlockeRun, error) {
+	run, err := s.repo.GetRun(userID, runID)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(run.Encounters, func(e dto.NuzlockeEncounter) bool { return e.ID == id })
+	if i < 0 {
+		return nil, fmt.Errorf("nuzlocke encounter %d %w", id, db.ErrNotFound)
+	}
+	e := run.Encounters[i]
+
+	if err := validateNuzlockeEncounter(in.Status, in.Level); err != nil {
+		return nil, err
+	}
+	if in.Status != e.Status {
+		switch {
+		case e.Status == dto.NuzlockeDead:
+			return nil, fmt.Errorf("%w: %s is dead and can't be used again", ErrRuleViolation, encounterName(&e))
+		case e.Status == dto.NuzlockeMissed:
+			return nil, fmt.Errorf("%w: the encounter at %s was missed", ErrRuleViolation, e.LocationName)
+		case in.Status == dto.NuzlockeMissed:
+			return nil, fmt.Errorf("%w: a caught pokemon can't be marked as missed", ErrInvalidInput)
+		case in.Status == dto.NuzlockeAlive && countStatus(run.Encounters, dto.NuzlockeAlive) >= maxPartySize:
+			return nil, fmt.Errorf("%w: the party already has %d pokemon, box one first", ErrRuleViolation, maxPartySize)
+		}
+	}
+	if in.CauseOfDeath != "" && in.Status != dto.NuzlockeDead {
+		return nil, fmt.Errorf("%w: causeOfDeath is only allowed for dead pokemon", ErrInvalidInput)
+	}
+
+	e.Nickname = strings.TrimSpace(in.Nickname)
+	e.Level = in.Level
+	e.CauseOfDeath = strings.TrimSpace(in.CauseOfDeath)
+	if in.Status == dto.NuzlockeDead && e.Status != dto.NuzlockeDead {
+		now := time.Unix(s.now().Unix(), 0)
+		e.DiedAt = &now
+	}
+	e.Status = in.Status
+
+	if err := s.repo.UpdateEncounter(&e, s.now()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetRun(userID, runID)
+}
+
+// DeleteEncounter removes an encounter that was recorded by mistake
+func (s *NuzlockeService) DeleteEncounter(userID, runID, id int) (*dto.NuzlockeRun, error) {
+	if _, err := s.repo.GetRun(userID, runID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.DeleteEncounter(runID, id, s.now()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetRun(userID, runID)
+}
+
+// Stats returns the party, the graveyard and how the run is going
+func (s *NuzlockeService) Stats(userID, runID int) (*dto.NuzlockeStats, error) {
+	run, err := s.repo.GetRun(userID, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &dto.NuzlockeStats{
+		Encounters:    len(run.Encounters),
+		Party:         []dto.NuzlockeEncounter{},
+		Graveyard:     []dto.NuzlockeEncounter{},
+		DeathsByCause: make(map[string]int),
+	}
+	partyLevels := 0
+	for _, e := range run.Encounters {
+		switch e.Status {
+		case dto.NuzlockeAlive:
+			stats.Alive++
+			stats.Party = append(stats.Party, e)
+			partyLevels += e.Level
+		case dto.NuzlockeBoxed:
+			stats.Boxed++
+		case dto.NuzlockeDead:
+			stats.Dead++
+			stats.Graveyard = append(stats.Graveyard, e)
+			cause := e.CauseOfDeath
+			if cause == "" {
+				cause = "unknown"
+			}
+			stats.DeathsByCause[cause]++
+		case dto.NuzlockeMissed:
+			stats.Missed++
+		}
+	}
+
+	slices.SortStableFunc(stats.Graveyard, func(a, b dto.NuzlockeEncounter) int {
+		return diedAt(b).Compare(diedAt(a))
+	})
+	if caught := stats.Alive + stats.Boxed + stats.Dead; caught > 0 {
+		stats.SurvivalRate = percentOf(stats.Alive+stats.Boxed, caught)
+	}
+	if stats.Alive > 0 {
+		stats.PartyLevel = math.Round(float64(partyLevels)*10/float64(stats.Alive)) / 10
+	}
+
+	return stats, nil
+}
+
+// checkNuzlockeRules validates a new encounter against the encounters of a run
+func checkNuzlockeRules(run *dto.NuzlockeRun, e *dto.NuzlockeEncounter) error {
+	// Bonus encounters don't use up the location but still need a party slot
+	if e.Status == dto.NuzlockeAlive && countStatus(run.Encounters, dto.NuzlockeAlive) >= maxPartySize {
+		return fmt.Errorf("%w: the party already has %d pokemon, box the new one", ErrRuleViolation, maxPartySize)
+	}
+
+	if isBonusEncounter(run.Rules, e) {
+		return nil
+	}
+
+	for _, other := range run.Encounters {
+		if other.LocationID == e.LocationID && !isBonusEncounter(run.Rules, &other) {
+			return fmt.Errorf("%w: the encounter at %s was already used on %s", ErrRuleViolation, e.LocationName, encounterName(&other))
+		}
+	}
+
+	if run.Rules.DupesClause {
+		for _, other := range run.Encounters {
+			if other.Status != dto.NuzlockeMissed && sameFamily(&other, e) {
+				return fmt.Errorf("%w: %s is a dupe of %s, keep looking for a new species", ErrRuleViolation, e.SpeciesName, encounterName(&other))
+			}
+		}
+	}
+
+	return nil
+}
+
+// isBonusEncounter reports whether e is allowed on top of the location's encounter
+func isBonusEncounter(rules dto.NuzlockeRules, e *dto.NuzlockeEncounter) bool {
+	return (e.Shiny && rules.ShinyClause) || (e.Gift && rules.GiftClause)
+}
+
+func sameFamily(a, b *dto.NuzlockeEncounter) bool {
+	if a.EvolutionChainID == 0 || b.EvolutionChainID == 0 {
+		return a.SpeciesID == b.SpeciesID
+	}
+	return a.EvolutionChainID == b.EvolutionChainID
+}
+
+func diedAt(e dto.NuzlockeEncounter) time.Time {
+	if e.DiedAt == nil {
+		return time.Time{}
+	}
+	return *e.DiedAt
+}
+
+func countStatus(encounters []dto.NuzlockeEncounter, status string) int {
+	n := 0
+	for _, e := range encounters {
+		if e.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+func encounterName(e *dto.NuzlockeEncounter) string {
+	if e.Nickname != "" {
+		return e.Nickname
+	}
+	return e.SpeciesName
+}
+
+func validateNuzlockeEncounter(status string, level int) error {
+	switch status {
+	case dto.NuzlockeAlive, dto.NuzlockeBoxed, dto.NuzlockeDead, dto.NuzlockeMissed:
+	default:
+		return fmt.Errorf("%w: status must be alive, boxed, dead or missed, got %q", ErrInvalidInput, status)
+	}
+	if level < 1 || level > 100 {
+		return fmt.Errorf("%w: level must be between 1 and 100, got %d", ErrInvalidInput, level)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockNuzlockeRepo struct {
+	mock.Mock
+}
+
+func (m *MockNuzlockeRepo) InsertRun(run *dto.NuzlockeRun, now time.Time) error {
+	args := m.Called(run, now)
+	return args.Error(0)
+}
+
+func (m *MockNuzlockeRepo) GetRuns(userID int) ([]*dto.NuzlockeRun, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.NuzlockeRun), args.Error(1)
+}
+
+func (m *MockNuzlockeRepo) GetRun(userID, id int) (*dto.NuzlockeRun, error) {
+	args := m.Called(userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.NuzlockeRun), args.Error(1)
+}
+
+func (m *MockNuzlockeRepo) DeleteRun(userID, id int) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+func (m *MockNuzlockeRepo) InsertEncounter(e *dto.NuzlockeEncounter, now time.Time) error {
+	args := m.Called(e, now)
+	return args.Error(0)
+}
+
+func (m *MockNuzlockeRepo) UpdateEncounter(e *dto.NuzlockeEncounter, now time.Time) error {
+	args := m.Called(e, now)
+	return args.Error(0)
+}
+
+func (m *MockNuzlockeRepo) DeleteEncounter(runID, id int, now time.Time) error {
+	args := m.Called(runID, id, now)
+	return args.Error(0)
+}
+
+func TestCheckNuzlockeRules(t *testing.T) {
+	allClauses := dto.NuzlockeRules{DupesClause: true, ShinyClause: true, GiftClause: true}
+	pidgey := dto.NuzlockeEncounter{LocationID: 1, LocationName: "Route 1", SpeciesID: 16, SpeciesName: "pidgey", EvolutionChainID: 6, Status: dto.NuzlockeAlive}
+	missed := dto.NuzlockeEncounter{LocationID: 2, LocationName: "Route 2", SpeciesID: 19, SpeciesName: "rattata", EvolutionChainID: 7, Status: dto.NuzlockeMissed}
+
+	tests := []struct {
+		name      string
+		rules     dto.NuzlockeRules
+		encounter dto.NuzlockeEncounter
+		allowed   bool
+	}{
+		{"New location", allClauses, dto.NuzlockeEncounter{LocationID: 3, SpeciesID: 10, EvolutionChainID: 4, Status: dto.NuzlockeAlive}, true},
+		{"Location already used", allClauses, dto.NuzlockeEncounter{LocationID: 1, SpeciesID: 19, EvolutionChainID: 7, Status: dto.NuzlockeAlive}, false},
+		{"Missed encounters use up the location", allClauses, dto.NuzlockeEncounter{LocationID: 2, SpeciesID: 10, EvolutionChainID: 4, Status: dto.NuzlockeAlive}, false},
+		{"Shiny clause", allClauses, dto.NuzlockeEncounter{LocationID: 1, SpeciesID: 19, Shiny: true, Status: dto.NuzlockeAlive}, true},
+		{"Shiny without shiny clause", dto.NuzlockeRules{}, dto.NuzlockeEncounter{LocationID: 1, SpeciesID: 19, Shiny: true, Status: dto.NuzlockeAlive}, false},
+		{"Gift clause", allClauses, dto.NuzlockeEncounter{LocationID: 1, SpeciesID: 133, Gift: true, Status: dto.NuzlockeAlive}, true},
+		{"Gift without gift clause", dto.NuzlockeRules{}, dto.NuzlockeEncounter{LocationID: 1, SpeciesID: 133, Gift: true, Status: dto.NuzlockeAlive}, false},
+		{"Dupe of a caught family", allClauses, dto.NuzlockeEncounter{LocationID: 3, SpeciesID: 17, EvolutionChainID: 6, Status: dto.NuzlockeAlive}, false},
+		{"Dupe without dupes clause", dto.NuzlockeRules{}, dto.NuzlockeEncounter{LocationID: 3, SpeciesID: 17, EvolutionChainID: 6, Status: dto.NuzlockeAlive}, true},
+		{"Missed species are no dupes", allClauses, dto.NuzlockeEncounter{LocationID: 3, SpeciesID: 20, EvolutionChainID: 7, Status: dto.NuzlockeAlive}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := &dto.NuzlockeRun{Rules: tt.rules, Encounters: []dto.NuzlockeEncounter{pidgey, missed}}
+			err := checkNuzlockeRules(run, &tt.encounter)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrRuleViolation)
+			}
+		})
+	}
+
+	t.Run("Full party", func(t *testing.T) {
+		run := &dto.NuzlockeRun{}
+		for i := 1; i <= maxPartySize; i++ {
+			run.Encounters = append(run.Encounters, dto.NuzlockeEncounter{LocationID: i, SpeciesID: i, Status: dto.NuzlockeAlive})
+		}
+
+		err := checkNuzlockeRules(run, &dto.NuzlockeEncounter{LocationID: 10, SpeciesID: 10, Status: dto.NuzlockeAlive})
+		assert.ErrorIs(t, err, ErrRuleViolation)
+		err = checkNuzlockeRules(run, &dto.NuzlockeEncounter{LocationID: 10, SpeciesID: 10, Status: dto.NuzlockeBoxed})
+		assert.NoError(t, err)
+
+		// Clauses don't make room in the party
+		run.Rules = dto.NuzlockeRules{ShinyClause: true}
+		err = checkNuzlockeRules(run, &dto.NuzlockeEncounter{LocationID: 1, SpeciesID: 10, Shiny: true, Status: dto.NuzlockeAlive})
+		assert.ErrorIs(t, err, ErrRuleViolation)
+	})
+}
+
+func TestNuzlockeUpdateEncounter(t *testing.T) {
+	died := time.Unix(1700000000, 0)
+	run := &dto.NuzlockeRun{
+		ID: 1,
+		Encounters: []dto.NuzlockeEncounter{
+			{ID: 1, RunID: 1, LocationName: "Route 1", SpeciesName: "pidgey", Level: 5, Status: dto.NuzlockeAlive},
+			{ID: 2, RunID: 1, LocationName: "Route 2", SpeciesName: "rattata", Level: 4, Status: dto.NuzlockeDead, DiedAt: &died},
+			{ID: 3, RunID: 1, LocationName: "Route 22", SpeciesName: "mankey", Level: 3, Status: dto.NuzlockeMissed},
+		},
+	}
+	repo := new(MockNuzlockeRepo)
+	repo.On("GetRun", 1, 1).Return(run, nil)
+	repo.On("UpdateEncounter", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewNuzlockeService(repo, nil, nil, nil)
+	service.now = func() time.Time { return died.Add(time.Hour) }
+
+	t.Run("Death is permanent", func(t *testing.T) {
+		_, err := service.UpdateEncounter(1, 1, 2, &NuzlockeEncounterUpdate{Level: 4, Status: dto.NuzlockeAlive})
+		assert.ErrorIs(t, err, ErrRuleViolation)
+	})
+
+	t.Run("Missed encounters can't be caught", func(t *testing.T) {
+		_, err := service.UpdateEncounter(1, 1, 3, &NuzlockeEncounterUpdate{Level: 3, Status: dto.NuzlockeBoxed})
+		assert.ErrorIs(t, err, ErrRuleViolation)
+	})
+
+	t.Run("Cause of death only for dead pokemon", func(t *testing.T) {
+		_, err := service.UpdateEncounter(1, 1, 1, &NuzlockeEncounterUpdate{Level: 5, Status: dto.NuzlockeBoxed, CauseOfDeath: "Brock"})
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+
+	t.Run("Killing sets the time of death", func(t *testing.T) {
+		_, err := service.UpdateEncounter(1, 1, 1, &NuzlockeEncounterUpdate{Level: 12, Status: dto.NuzlockeDead, CauseOfDeath: "Brock's Onix"})
+		require.NoError(t, err)
+
+		updated := repo.Calls[len(repo.Calls)-2].Arguments.Get(0).(*dto.NuzlockeEncounter)
+		assert.Equal(t, dto.NuzlockeDead, updated.Status)
+		assert.Equal(t, "Brock's Onix", updated.CauseOfDeath)
+		require.NotNil(t, updated.DiedAt)
+		assert.Equal(t, died.Add(time.Hour), *updated.DiedAt)
+	})
+}
+
+func TestNuzlockeStats(t *testing.T) {
+	first, second := time.Unix(1700000000, 0), time.Unix(1700003600, 0)
+	repo := new(MockNuzlockeRepo)
+	repo.On("GetRun", 1, 1).Return(&dto.NuzlockeRun{
+		ID: 1,
+		Encounters: []dto.NuzlockeEncounter{
+			{ID: 1, SpeciesName: "charmander", Level: 14, Status: dto.NuzlockeAlive},
+			{ID: 2, SpeciesName: "pidgey", Level: 11, Status: dto.NuzlockeAlive},
+			{ID: 3, SpeciesName: "rattata", Level: 6, Status: dto.NuzlockeDead, CauseOfDeath: "crit", DiedAt: &first},
+			{ID: 4, SpeciesName: "caterpie", Level: 7, Status: dto.NuzlockeBoxed},
+			{ID: 5, SpeciesName: "spearow", Level: 8, Status: dto.NuzlockeDead, DiedAt: &second},
+			{ID: 6, SpeciesName: "nidoran-f", Level: 3, Status: dto.NuzlockeMissed},
+		},
+	}, nil)
+
+	stats, err := NewNuzlockeService(repo, nil, nil, nil).Stats(1, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, stats.Encounters)
+	assert.Equal(t, 2, stats.Alive)
+	assert.Equal(t, 1, stats.Boxed)
+	assert.Equal(t, 2, stats.Dead)
+	assert.Equal(t, 1, stats.Missed)
+	assert.Equal(t, 60.0, stats.SurvivalRate)
+	assert.Equal(t, 12.5, stats.PartyLevel)
+	require.Len(t, stats.Graveyard, 2)
+	assert.Equal(t, "spearow", stats.Graveyard[0].SpeciesName)
+	assert.Equal(t, map[string]int{"crit": 1, "unknown": 1}, stats.DeathsByCause)
+}
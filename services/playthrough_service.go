@@ -0,0 +1,154 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/db"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+)
+
+// PlaythroughInput holds the editable fields of a playthrough.
+// VersionID is ignored on update, a playthrough stays tied to its game.
+type PlaythroughInput struct {
+	VersionID int    `json:"versionId"`
+	Name      string `json:"name"`
+	Notes     string `json:"notes"`
+}
+
+// PlaythroughService manages the playthroughs of a user. Versions are checked
+// against the reference data, which lives in a separate database.
+type PlaythroughService struct {
+	repo        PlaythroughRepo
+	versionRepo VersionRepo
+	now         func() time.Time
+}
+
+func NewPlaythroughService(repo PlaythroughRepo, versionRepo VersionRepo) *PlaythroughService {
+	return &PlaythroughService{
+		repo:        repo,
+		versionRepo: versionRepo,
+		now:         time.Now,
+	}
+}
+
+func (s *PlaythroughService) Create(userID int, in *PlaythroughInput) (*dto.Playthrough, error) {
+	if _, err := s.versionRepo.GetVersionByID(in.VersionID); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, fmt.Errorf("%w: version %d does not exist", ErrInvalidInput, in.VersionID)
+		}
+		return nil, err
+	}
+	name, err := validatePlaythroughName(in.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dto.Playthrough{
+		UserID:    userID,
+		VersionID: in.VersionID,
+		Name:      name,
+		Notes:     in.Notes,
+	}
+	if err := s.repo.InsertPlaythrough(p, s.now()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetPlaythrough(userID, p.ID)
+}
+
+func (s *PlaythroughService) List(userID int) ([]*dto.Playthrough, error) {
+	return s.repo.GetPlaythroughs(userID)
+}
+
+func (s *PlaythroughService) Get(userID, id int) (*dto.Playthrough, error) {
+	return s.repo.GetPlaythrough(userID, id)
+}
+
+func (s *PlaythroughService) Update(userID, id int, in *PlaythroughInput) (*dto.Playthrough, error) {
+	p, err := s.repo.GetPlaythrough(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	p.Name, err = validatePlaythroughName(in.Name)
+	if err != nil {
+		return nil, err
+	}
+	p.Notes = in.Notes
+
+	if err := s.repo.UpdatePlaythrough(p, s.now()); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (s *PlaythroughService) Delete(userID, id int) error {
+	return s.repo.DeletePlaythrough(userID, id)
+}
+
+// SetSpeciesStatus marks a species as "seen" or "caught", an empty status
+// marks it as unseen again
+func (s *PlaythroughService) SetSpeciesStatus(userID, id, speciesID int, status string) (*dto.Playthrough, error) {
+	if _, err := s.repo.GetPlaythrough(userID, id); err != nil {
+		return nil, err
+	}
+	if speciesID < 1 {
+		return nil, fmt.Errorf("%w: invalid species %d", ErrInvalidInput, speciesID)
+	}
+
+	var err error
+	switch status {
+	case "seen", "caught":
+		err = s.repo.SetSpeciesStatus(id, speciesID, status, s.now())
+	case "":
+		err = s.repo.DeleteSpeciesStatus(id, speciesID, s.now())
+	default:
+		return nil, fmt.Errorf("%w: status must be \"seen\" or \"caught\", got %q", ErrInvalidInput, status)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetPlaythrough(userID, id)
+}
+
+// SetParty replaces the party, slots must be unique and between 1 and 6
+func (s *PlaythroughService) SetParty(userID, id int, party []dto.PartyMember) (*dto.Playthrough, error) {
+	if _, err := s.repo.GetPlaythrough(userID, id); err != nil {
+		return nil, err
+	}
+	if len(party) > 6 {
+		return nil, fmt.Errorf("%w: a party has at most 6 members, got %d", ErrInvalidInput, len(party))
+	}
+
+	slots := make(map[int]bool)
+	for _, m := range party {
+		if m.Slot < 1 || m.Slot > 6 || slots[m.Slot] {
+			return nil, fmt.Errorf("%w: invalid or duplicate party slot %d", ErrInvalidInput, m.Slot)
+		}
+		slots[m.Slot] = true
+		if m.PokemonID < 1 {
+			return nil, fmt.Errorf("%w: invalid pokemon %d in slot %d", ErrInvalidInput, m.PokemonID, m.Slot)
+		}
+		if m.Level < 1 || m.Level > 100 {
+			return nil, fmt.Errorf("%w: level must be between 1 and 100, got %d in slot %d", ErrInvalidInput, m.Level, m.Slot)
+		}
+	}
+
+	if err := s.repo.SetParty(id, party, s.now()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetPlaythrough(userID, id)
+}
+
+func validatePlaythroughName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return "", fmt.Errorf("%w: name must be 1-100 characters", ErrInvalidInput)
+	}
+	return name, nil
+}
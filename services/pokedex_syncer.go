@@ -2,7 +2,6 @@ package services
 
 import (
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
@@ -56,7 +55,7 @@ func (s *PokedexSyncer) SyncPokedex(id int) (*external.Pokedex, error) {
 func (s *PokedexSyncer) SyncAll(limit int) error {
 	allPokedexes, err := s.client.FetchAll(fmt.Sprintf("pokedex?limit=%d", limit))
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("failed to fetch pokedexes: %w", err)
 	}
 
 	for i, pkdx := range allPokedexes {
@@ -66,20 +65,19 @@ func (s *PokedexSyncer) SyncAll(limit int) error {
 
 		id, err := utils.ExtractIDFromURL(pkdx.Url)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 
 		pokedex, err := s.client.FetchPokedex(id)
 		if err != nil {
-			log.Fatal(err)
+			return fmt.Errorf("failed to fetch pokedex %d: %w", id, err)
 		}
 
-		err = s.repo.InsertPokedex(pokedex)
-		if err != nil {
-			log.Fatalf("Failed to insert pokedex ID %d: %v", pokedex.ID, err)
+		if err := s.repo.InsertPokedex(pokedex); err != nil {
+			return fmt.Errorf("failed to insert pokedex %d: %w", pokedex.ID, err)
 		}
 
-		fmt.Printf("Inserted Pokedex %s (%d/%d)\n", pokedex.Name, i+1, len(allPokedexes))
+		logger().Info("inserted pokedex", "pokedex", pokedex.Name, "n", i+1, "total", len(allPokedexes))
 	}
 	return nil
 }
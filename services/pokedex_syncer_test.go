@@ -55,6 +55,22 @@ func (m *MockPokedexRepo) GetPokedexByID(id int) (*dto.Pokedex, error) {
 	return args.Get(0).(*dto.Pokedex), args.Error(1)
 }
 
+func (m *MockPokedexRepo) GetPokedexEntries(pokedexID int) ([]*dto.PokemonEntry, error) {
+	args := m.Called(pokedexID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.PokemonEntry), args.Error(1)
+}
+
+func (m *MockPokedexRepo) GetVersionGroupPokedexes(versionGroupID int) ([]*dto.Pokedex, error) {
+	args := m.Called(versionGroupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.Pokedex), args.Error(1)
+}
+
 func TestSyncAllPokedexes(t *testing.T) {
 	t.Run("Succesfully sync all pokedexes", func(t *testing.T) {
 		mockClient := new(MockPokedexAPIClient)
@@ -2,30 +2,39 @@ package services
 
 import (
 	"fmt"
-	"log"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/progress"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
 )
 
 type PokemonSyncer struct {
 	client        PokemonAPIClient
 	repo          PokemonRepo
+	images        ImageDownloader
 	rateLimiter   *time.Ticker
-	syncedSpecies map[int]bool // In-memory cache of synced species IDs
-	syncedPokemon map[int]bool // In-memory cache of synced Pokemon IDs
-	mu            sync.Mutex   // Protects cache maps
+	syncedSpecies map[int]*external.Species     // In-memory cache of synced species
+	syncedPokemon map[int]bool                  // In-memory cache of synced Pokemon IDs
+	syncedForms   map[int]*external.PokemonForm // In-memory cache of synced Pokemon forms
+	mu            sync.Mutex                    // Protects cache maps
+
+	Progress *progress.Tracker // Optional
 }
 
-func NewPokemonSyncer(client PokemonAPIClient, repo PokemonRepo, rateLimiter *time.Ticker) *PokemonSyncer {
+func NewPokemonSyncer(client PokemonAPIClient, repo PokemonRepo, images ImageDownloader, rateLimiter *time.Ticker) *PokemonSyncer {
 	return &PokemonSyncer{
 		client:        client,
 		repo:          repo,
+		images:        images,
 		rateLimiter:   rateLimiter,
-		syncedSpecies: make(map[int]bool),
+		syncedSpecies: make(map[int]*external.Species),
 		syncedPokemon: make(map[int]bool),
+		syncedForms:   make(map[int]*external.PokemonForm),
 	}
 }
 
@@ -45,31 +54,85 @@ func (s *PokemonSyncer) InsertType(t *external.PokemonType, pokemonID int) error
 	return s.repo.InsertType(t, pokemonID)
 }
 
+func (s *PokemonSyncer) InsertPastTypes(p *external.Pokemon) error {
+	return s.repo.InsertPastTypes(p)
+}
+
 func (s *PokemonSyncer) InsertAbility(a *external.Ability, pokemonID int) error {
 	return s.repo.InsertAbility(a, pokemonID)
 }
 
 func (s *PokemonSyncer) SyncPokemon(id int) (*external.Pokemon, error) {
+	pokemon, err := s.FetchPokemon(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.StorePokemon(pokemon); err != nil {
+		return nil, err
+	}
+	return pokemon, nil
+}
+
+// StorePokemon stores a fetched pokemon unless it was already synced in this session
+func (s *PokemonSyncer) StorePokemon(pokemon *external.Pokemon) error {
 	// Check cache first
 	s.mu.Lock()
-	if s.syncedPokemon[id] {
-		s.mu.Unlock()
-		// Already synced in this session, just fetch from client without DB insert
-		// We still return the Pokemon data for the caller
-		pokemon, err := s.client.FetchPokemon(id)
+	synced := s.syncedPokemon[pokemon.ID]
+	s.mu.Unlock()
+
+	if synced {
+		s.Progress.CacheHit()
+		return nil
+	}
+	return s.storePokemon(pokemon)
+}
+
+// SyncPokemonWithSpecies syncs a single pokemon outside of a game: its
+// species, the pokemon with its sprites, forms, types and abilities. Moves
+// and encounters depend on the game and are left to GameSyncer.
+func (s *PokemonSyncer) SyncPokemonWithSpecies(id int) (*external.Pokemon, error) {
+	s.Progress.AddTotal(progress.Pokemon, 1)
+	pokemon, err := s.FetchPokemon(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.SyncSpecies(pokemon.SpeciesID); err != nil {
+		return nil, fmt.Errorf("failed to sync species %d: %w", pokemon.SpeciesID, err)
+	}
+	if err := s.storePokemon(pokemon); err != nil {
+		return nil, err
+	}
+
+	for _, f := range pokemon.Forms {
+		formID, err := utils.ExtractIDFromURL(f.Url)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to extract pokemon form ID: %w", err)
 		}
-		speciesID, err := utils.ExtractIDFromURL(pokemon.Species.Url)
-		if err != nil {
-			return nil, err
+		if _, err := s.SyncPokemonForm(formID); err != nil {
+			return nil, fmt.Errorf("failed to sync pokemon form %d: %w", formID, err)
 		}
-		pokemon.SpeciesID = speciesID
-		return pokemon, nil
 	}
-	s.mu.Unlock()
+	for _, t := range pokemon.Types {
+		if err := s.repo.InsertType(&t, pokemon.ID); err != nil {
+			return nil, fmt.Errorf("failed to insert type %s for pokemon %d: %w", t.Type.Name, pokemon.ID, err)
+		}
+	}
+	if err := s.repo.InsertPastTypes(pokemon); err != nil {
+		return nil, err
+	}
+	for _, a := range pokemon.Abilities {
+		if err := s.repo.InsertAbility(&a, pokemon.ID); err != nil {
+			return nil, fmt.Errorf("failed to insert ability %s for pokemon %d: %w", a.Ability.Name, pokemon.ID, err)
+		}
+	}
+	s.Progress.Done(progress.Pokemon)
 
-	// Not in cache, fetch and insert
+	return pokemon, nil
+}
+
+// FetchPokemon fetches a pokemon and fills in its species ID, nothing is stored
+func (s *PokemonSyncer) FetchPokemon(id int) (*external.Pokemon, error) {
 	pokemon, err := s.client.FetchPokemon(id)
 	if err != nil {
 		return nil, err
@@ -80,46 +143,147 @@ func (s *PokemonSyncer) SyncPokemon(id int) (*external.Pokemon, error) {
 		return nil, err
 	}
 	pokemon.SpeciesID = speciesID
+	return pokemon, nil
+}
 
+// storePokemon downloads the sprites of a pokemon and inserts it
+func (s *PokemonSyncer) storePokemon(pokemon *external.Pokemon) error {
 	// Download and save Pokemon sprites locally
-	if pokemon.Sprites.Other.OfficialArtwork.FrontDefault != "" {
-		localPath := utils.GetPokemonSpritePath(pokemon.ID, "artwork")
-		if err := utils.DownloadImage(pokemon.Sprites.Other.OfficialArtwork.FrontDefault, localPath); err != nil {
-			log.Printf("Warning: failed to download artwork for Pokemon %d: %v", pokemon.ID, err)
-		} else {
-			pokemon.Sprites.Other.OfficialArtwork.FrontDefault = localPath
+	for _, sprite := range []struct {
+		name string
+		url  *string
+	}{
+		{"sprite", &pokemon.Sprites.FrontDefault},
+		{"shiny sprite", &pokemon.Sprites.FrontShiny},
+		{"artwork", &pokemon.Sprites.Other.OfficialArtwork.FrontDefault},
+		{"shiny artwork", &pokemon.Sprites.Other.OfficialArtwork.FrontShiny},
+	} {
+		if *sprite.url == "" {
+			continue
+		}
+		asset, err := s.images.Download(*sprite.url)
+		if err != nil {
+			logger().Warn("failed to download sprite", "sprite", sprite.name, "pokemon_id", pokemon.ID, "err", err)
+			s.Progress.Failed()
+			continue
 		}
+		*sprite.url = asset.Path
 	}
 
-	if pokemon.Sprites.Other.OfficialArtwork.FrontShiny != "" {
-		localPath := utils.GetPokemonSpritePath(pokemon.ID, "artwork_shiny")
-		if err := utils.DownloadImage(pokemon.Sprites.Other.OfficialArtwork.FrontShiny, localPath); err != nil {
-			log.Printf("Warning: failed to download shiny artwork for Pokemon %d: %v", pokemon.ID, err)
-		} else {
-			pokemon.Sprites.Other.OfficialArtwork.FrontShiny = localPath
-		}
+	if err := s.repo.InsertPokemon(pokemon); err != nil {
+		return err
 	}
+	s.Progress.Inserted(1)
 
-	err = s.repo.InsertPokemon(pokemon)
-	if err != nil {
-		return nil, err
+	if err := s.repo.InsertPokemonSprites(pokemon.ID, s.versionSprites(pokemon)); err != nil {
+		return err
 	}
 
 	// Mark as synced in cache
 	s.mu.Lock()
-	s.syncedPokemon[id] = true
+	s.syncedPokemon[pokemon.ID] = true
 	s.mu.Unlock()
 
-	return pokemon, nil
+	return nil
+}
+
+// versionSprites downloads the in-game sprites of every game in
+// sprites.versions. Sprites that fail to download are left out.
+func (s *PokemonSyncer) versionSprites(p *external.Pokemon) []*dto.PokemonSprite {
+	sprites := []*dto.PokemonSprite{}
+
+	for _, generationName := range slices.Sorted(maps.Keys(p.Sprites.Versions)) {
+		generation, err := utils.GenerationNumber(generationName)
+		if err != nil {
+			logger().Warn("skipping sprites", "pokemon_id", p.ID, "generation", generationName, "err", err)
+			continue
+		}
+
+		sets := p.Sprites.Versions[generationName]
+		for _, setName := range slices.Sorted(maps.Keys(sets)) {
+			if setName == "icons" { // Menu icons, not battle sprites of a game
+				continue
+			}
+			versionGroup := setName
+			if name, ok := spriteVersionGroups[setName]; ok {
+				versionGroup = name
+			}
+
+			template := dto.PokemonSprite{
+				PokemonID:    p.ID,
+				Generation:   generation,
+				VersionGroup: versionGroup,
+				SpriteSet:    setName,
+			}
+			sprites = append(sprites, s.downloadSpriteSet(template, sets[setName].SpriteSet)...)
+			if animated := sets[setName].Animated; animated != nil {
+				template.Animated = true
+				sprites = append(sprites, s.downloadSpriteSet(template, *animated)...)
+			}
+		}
+	}
+
+	return sprites
+}
+
+// spriteVersionGroups are the sprite sets PokeAPI doesn't name after their
+// version group
+var spriteVersionGroups = map[string]string{
+	"gold":                            "gold-silver",
+	"silver":                          "gold-silver",
+	"omegaruby-alphasapphire":         "omega-ruby-alpha-sapphire",
+	"brilliant-diamond-shining-pearl": "brilliant-diamond-and-shining-pearl",
+}
+
+// downloadSpriteSet downloads the sprites of set, filling in side, shiny and
+// female on copies of template
+func (s *PokemonSyncer) downloadSpriteSet(template dto.PokemonSprite, set external.SpriteSet) []*dto.PokemonSprite {
+	sprites := []*dto.PokemonSprite{}
+
+	for _, v := range []struct {
+		url    string
+		side   string
+		shiny  bool
+		female bool
+	}{
+		{set.FrontDefault, dto.SpriteFront, false, false},
+		{set.FrontShiny, dto.SpriteFront, true, false},
+		{set.FrontFemale, dto.SpriteFront, false, true},
+		{set.FrontShinyFemale, dto.SpriteFront, true, true},
+		{set.BackDefault, dto.SpriteBack, false, false},
+		{set.BackShiny, dto.SpriteBack, true, false},
+		{set.BackFemale, dto.SpriteBack, false, true},
+		{set.BackShinyFemale, dto.SpriteBack, true, true},
+	} {
+		if v.url == "" {
+			continue
+		}
+		asset, err := s.images.Download(v.url)
+		if err != nil {
+			logger().Warn("failed to download sprite", "sprite_set", template.SpriteSet, "pokemon_id", template.PokemonID, "err", err)
+			s.Progress.Failed()
+			continue
+		}
+
+		sprite := template
+		sprite.Side = v.side
+		sprite.Shiny = v.shiny
+		sprite.Female = v.female
+		sprite.Path = asset.Path
+		sprites = append(sprites, &sprite)
+	}
+
+	return sprites
 }
 
 func (s *PokemonSyncer) SyncSpecies(id int) (*external.Species, error) {
 	// Check cache first
 	s.mu.Lock()
-	if s.syncedSpecies[id] {
+	if species, ok := s.syncedSpecies[id]; ok {
 		s.mu.Unlock()
 		// Already synced in this session, skip API call and DB insert
-		return nil, nil
+		s.Progress.CacheHit()
+		return species, nil
 	}
 	s.mu.Unlock()
 
@@ -133,20 +297,75 @@ func (s *PokemonSyncer) SyncSpecies(id int) (*external.Species, error) {
 	if err != nil {
 		return nil, err
 	}
+	s.Progress.Inserted(1)
 
 	// Mark as synced in cache
 	s.mu.Lock()
-	s.syncedSpecies[id] = true
+	s.syncedSpecies[id] = species
 	s.mu.Unlock()
 
 	return species, nil
 }
 
+func (s *PokemonSyncer) SyncPokemonForm(id int) (*external.PokemonForm, error) {
+	// Check cache first
+	s.mu.Lock()
+	if form, ok := s.syncedForms[id]; ok {
+		s.mu.Unlock()
+		// Already synced in this session, skip API call and DB insert
+		s.Progress.CacheHit()
+		return form, nil
+	}
+	s.mu.Unlock()
+
+	// Not in cache, fetch and insert
+	form, err := s.client.FetchPokemonForm(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.storePokemonForm(form); err != nil {
+		return nil, err
+	}
+	return form, nil
+}
+
+// FetchPokemonForm returns a pokemon form without storing it, from the cache
+// if it was already synced
+func (s *PokemonSyncer) FetchPokemonForm(id int) (*external.PokemonForm, error) {
+	s.mu.Lock()
+	form, ok := s.syncedForms[id]
+	s.mu.Unlock()
+	if ok {
+		return form, nil
+	}
+	return s.client.FetchPokemonForm(id)
+}
+
+// storePokemonForm inserts a pokemon form and caches it
+func (s *PokemonSyncer) storePokemonForm(form *external.PokemonForm) error {
+
+	if err := s.repo.InsertPokemonForm(form); err != nil {
+		return err
+	}
+	s.Progress.Inserted(1)
+
+	// Mark as synced in cache
+	s.mu.Lock()
+	s.syncedForms[form.ID] = form
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *PokemonSyncer) InsertPokemonFormVersionGroup(formID, versionGroupID int) error {
+	return s.repo.InsertPokemonFormVersionGroup(formID, versionGroupID)
+}
+
 func (s *PokemonSyncer) SyncAll(limit int) error {
 	// Fetch all Pokemon from API
 	allPokemonResponse, err := s.client.FetchAll(fmt.Sprintf("pokemon?limit=%d", limit))
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("failed to fetch pokemon: %w", err)
 	}
 
 	for i, apr := range allPokemonResponse {
@@ -158,18 +377,17 @@ func (s *PokemonSyncer) SyncAll(limit int) error {
 		// Extract ID from URL
 		id, err := utils.ExtractIDFromURL(apr.Url)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 
 		pokemon, err := s.client.FetchPokemon(id)
 		if err != nil {
-			log.Fatal(err)
+			return fmt.Errorf("failed to fetch pokemon %d: %w", id, err)
 		}
-		err = s.repo.InsertPokemon(pokemon)
-		if err != nil {
-			log.Fatal(err)
+		if err := s.repo.InsertPokemon(pokemon); err != nil {
+			return fmt.Errorf("failed to insert pokemon %s: %w", pokemon.Name, err)
 		}
-		fmt.Printf("Inserted Pokemon %s (%d/%d)\n", pokemon.Name, i+1, len(allPokemonResponse))
+		logger().Info("inserted pokemon", "pokemon", pokemon.Name, "n", i+1, "total", len(allPokemonResponse))
 	}
 	return nil
 }
@@ -1,11 +1,14 @@
 package services
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -35,6 +38,14 @@ func (m *MockPokemonAPIClient) FetchPokemon(id int) (*external.Pokemon, error) {
 	return args.Get(0).(*external.Pokemon), args.Error(1)
 }
 
+func (m *MockPokemonAPIClient) FetchPokemonForm(id int) (*external.PokemonForm, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*external.PokemonForm), args.Error(1)
+}
+
 type MockPokemonRepo struct {
 	mock.Mock
 }
@@ -49,6 +60,35 @@ func (m *MockPokemonRepo) InsertType(p *external.PokemonType, pokemonId int) err
 	return args.Error(0)
 }
 
+func (m *MockPokemonRepo) InsertPastTypes(p *external.Pokemon) error {
+	args := m.Called(p)
+	return args.Error(0)
+}
+
+func (m *MockPokemonRepo) GetPokemonTypes(pokemonID, generation int) ([]string, error) {
+	args := m.Called(pokemonID, generation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockPokemonRepo) GetSpeciesByID(id int) (*dto.Species, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.Species), args.Error(1)
+}
+
+func (m *MockPokemonRepo) GetBreedingCandidates(moveID, versionGroupID int) ([]*dto.BreedingCandidate, error) {
+	args := m.Called(moveID, versionGroupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.BreedingCandidate), args.Error(1)
+}
+
 func (m *MockPokemonRepo) InsertAbility(p *external.Ability, pokemonId int) error {
 	args := m.Called(p)
 	return args.Error(0)
@@ -59,6 +99,37 @@ func (m *MockPokemonRepo) InsertSpecies(p *external.Species) error {
 	return args.Error(0)
 }
 
+func (m *MockPokemonRepo) InsertPokemonForm(f *external.PokemonForm) error {
+	args := m.Called(f)
+	return args.Error(0)
+}
+
+func (m *MockPokemonRepo) InsertPokemonFormVersionGroup(formID, versionGroupID int) error {
+	args := m.Called(formID, versionGroupID)
+	return args.Error(0)
+}
+
+func (m *MockPokemonRepo) InsertPokemonSprites(pokemonID int, sprites []*dto.PokemonSprite) error {
+	args := m.Called(pokemonID, sprites)
+	return args.Error(0)
+}
+
+func (m *MockPokemonRepo) GetPokemonSprites(pokemonID int, versionGroup string) ([]*dto.PokemonSprite, error) {
+	args := m.Called(pokemonID, versionGroup)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.PokemonSprite), args.Error(1)
+}
+
+func (m *MockPokemonRepo) GetSpeciesForms(speciesID, versionGroupID int) ([]*dto.PokemonForm, error) {
+	args := m.Called(speciesID, versionGroupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.PokemonForm), args.Error(1)
+}
+
 func (m *MockPokemonRepo) GetPokemonByID(id int) (*dto.Pokemon, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
@@ -101,7 +172,7 @@ func TestSyncAllPokemon(t *testing.T) {
 
 		rateLimiter := time.NewTicker(1 * time.Millisecond)
 
-		syncer := NewPokemonSyncer(mockClient, mockRepo, rateLimiter)
+		syncer := NewPokemonSyncer(mockClient, mockRepo, new(MockImageDownloader), rateLimiter)
 
 		err := syncer.SyncAll(2)
 
@@ -110,3 +181,118 @@ func TestSyncAllPokemon(t *testing.T) {
 		mockClient.AssertExpectations(t)
 	})
 }
+
+const pikachuSprites = `{
+	"front_default": "https://sprites/25.png",
+	"front_shiny": "https://sprites/shiny/25.png",
+	"front_female": null,
+	"other": {"official-artwork": {"front_default": "https://artwork/25.png", "front_shiny": "https://artwork/shiny/25.png"}},
+	"versions": {
+		"generation-ii": {
+			"gold": {"front_default": "https://sprites/gold/25.png", "front_shiny": null, "front_transparent": "https://sprites/gold/transparent/25.png"},
+			"silver": {"front_default": "https://sprites/silver/25.png", "back_default": "https://sprites/silver/back/25.png"}
+		},
+		"generation-v": {
+			"black-white": {
+				"front_default": "https://sprites/bw/25.png",
+				"back_female": "https://sprites/bw/back/female/25.png",
+				"animated": {"front_default": "https://sprites/bw/animated/25.gif"}
+			}
+		},
+		"generation-vii": {
+			"icons": {"front_default": "https://sprites/icons/25.png"}
+		}
+	}
+}`
+
+func TestSyncPokemonSprites(t *testing.T) {
+	var sprites external.Sprite
+	require.NoError(t, json.Unmarshal([]byte(pikachuSprites), &sprites))
+
+	mockClient := new(MockPokemonAPIClient)
+	mockClient.On("FetchPokemon", 25).Return(&external.Pokemon{
+		ID:      25,
+		Name:    "pikachu",
+		Species: external.Response{Name: "pikachu", Url: "https://pokeapi.co/api/v2/pokemon-species/25/"},
+		Sprites: sprites,
+	}, nil)
+
+	images := new(MockImageDownloader)
+	for url, path := range map[string]string{
+		"https://sprites/25.png":                "assets/front.png",
+		"https://sprites/shiny/25.png":          "assets/shiny.png",
+		"https://artwork/25.png":                "assets/artwork.png",
+		"https://artwork/shiny/25.png":          "assets/artwork_shiny.png",
+		"https://sprites/gold/25.png":           "assets/gold.png",
+		"https://sprites/silver/25.png":         "assets/silver.png",
+		"https://sprites/bw/25.png":             "assets/bw.png",
+		"https://sprites/bw/back/female/25.png": "assets/bw_back_female.png",
+		"https://sprites/bw/animated/25.gif":    "assets/bw_animated.gif",
+	} {
+		images.On("Download", url).Return(&dto.Asset{Path: path}, nil)
+	}
+	// A failed download only loses that sprite
+	images.On("Download", "https://sprites/silver/back/25.png").Return(nil, errors.New("not found"))
+
+	mockRepo := new(MockPokemonRepo)
+	var inserted *external.Pokemon
+	mockRepo.On("InsertPokemon", mock.AnythingOfType("*external.Pokemon")).Run(func(args mock.Arguments) {
+		inserted = args.Get(0).(*external.Pokemon)
+	}).Return(nil)
+	var stored []*dto.PokemonSprite
+	mockRepo.On("InsertPokemonSprites", 25, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).([]*dto.PokemonSprite)
+	}).Return(nil)
+
+	syncer := NewPokemonSyncer(mockClient, mockRepo, images, time.NewTicker(time.Millisecond))
+	_, err := syncer.SyncPokemon(25)
+	require.NoError(t, err)
+
+	assert.Equal(t, "assets/front.png", inserted.Sprites.FrontDefault)
+	assert.Equal(t, "assets/shiny.png", inserted.Sprites.FrontShiny)
+	assert.Equal(t, "assets/artwork.png", inserted.Sprites.Other.OfficialArtwork.FrontDefault)
+	assert.Equal(t, "assets/artwork_shiny.png", inserted.Sprites.Other.OfficialArtwork.FrontShiny)
+
+	assert.Equal(t, []*dto.PokemonSprite{
+		{PokemonID: 25, Generation: 2, VersionGroup: "gold-silver", SpriteSet: "gold", Side: dto.SpriteFront, Path: "assets/gold.png"},
+		{PokemonID: 25, Generation: 2, VersionGroup: "gold-silver", SpriteSet: "silver", Side: dto.SpriteFront, Path: "assets/silver.png"},
+		{PokemonID: 25, Generation: 5, VersionGroup: "black-white", SpriteSet: "black-white", Side: dto.SpriteFront, Path: "assets/bw.png"},
+		{PokemonID: 25, Generation: 5, VersionGroup: "black-white", SpriteSet: "black-white", Side: dto.SpriteBack, Female: true, Path: "assets/bw_back_female.png"},
+		{PokemonID: 25, Generation: 5, VersionGroup: "black-white", SpriteSet: "black-white", Side: dto.SpriteFront, Animated: true, Path: "assets/bw_animated.gif"},
+	}, stored)
+
+	// Icons aren't game sprites
+	images.AssertNotCalled(t, "Download", "https://sprites/icons/25.png")
+	images.AssertExpectations(t)
+}
+
+func TestSyncPokemonWithSpecies(t *testing.T) {
+	mockClient := new(MockPokemonAPIClient)
+	mockClient.On("FetchPokemon", 26).Return(&external.Pokemon{
+		ID:        26,
+		Name:      "raichu",
+		Species:   external.Response{Name: "raichu", Url: "https://pokeapi.co/api/v2/pokemon-species/26/"},
+		Forms:     []external.Response{{Name: "raichu", Url: "https://pokeapi.co/api/v2/pokemon-form/26/"}},
+		Types:     []external.PokemonType{{Type: external.Response{Name: "electric"}, Slot: 1}},
+		Abilities: []external.Ability{{Ability: external.Response{Name: "static"}, Slot: 1}},
+	}, nil)
+	mockClient.On("FetchSpecies", 26).Return(&external.Species{ID: 26, Name: "raichu"}, nil)
+	mockClient.On("FetchPokemonForm", 26).Return(&external.PokemonForm{ID: 26, Name: "raichu"}, nil)
+
+	mockRepo := new(MockPokemonRepo)
+	mockRepo.On("InsertSpecies", mock.AnythingOfType("*external.Species")).Return(nil).Once()
+	mockRepo.On("InsertPokemon", mock.AnythingOfType("*external.Pokemon")).Return(nil).Once()
+	mockRepo.On("InsertPokemonSprites", 26, mock.Anything).Return(nil).Once()
+	mockRepo.On("InsertPokemonForm", mock.AnythingOfType("*external.PokemonForm")).Return(nil).Once()
+	mockRepo.On("InsertType", mock.AnythingOfType("*external.PokemonType")).Return(nil).Once()
+	mockRepo.On("InsertPastTypes", mock.AnythingOfType("*external.Pokemon")).Return(nil).Once()
+	mockRepo.On("InsertAbility", mock.AnythingOfType("*external.Ability")).Return(nil).Once()
+
+	syncer := NewPokemonSyncer(mockClient, mockRepo, new(MockImageDownloader), time.NewTicker(time.Millisecond))
+	pokemon, err := syncer.SyncPokemonWithSpecies(26)
+	require.NoError(t, err)
+	assert.Equal(t, 26, pokemon.SpeciesID)
+
+	mockClient.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,358 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+)
+
+// StatNames are the PokeAPI stat names in the order used by StatSpread
+var StatNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// StatSpread holds one value per stat: base stats, IVs, EVs or calculated stats.
+// In Gen 1-2 IVs are DVs (0-15) and EVs are Stat Experience (0-65535).
+type StatSpread struct {
+	HP             int `json:"hp"`
+	Attack         int `json:"attack"`
+	Defense        int `json:"defense"`
+	SpecialAttack  int `json:"specialAttack"`
+	SpecialDefense int `json:"specialDefense"`
+	Speed          int `json:"speed"`
+}
+
+// Get returns the value of a stat by PokeAPI name, ok is false for unknown stats
+func (s *StatSpread) Get(stat string) (value int, ok bool) {
+	f := s.field(stat)
+	if f == nil {
+		return 0, false
+	}
+	return *f, true
+}
+
+// Set sets the value of a stat by PokeAPI name and reports whether the stat
+// is known, the spread is left unchanged otherwise
+func (s *StatSpread) Set(stat string, value int) bool {
+	f := s.field(stat)
+	if f == nil {
+		return false
+	}
+	*f = value
+	return true
+}
+
+// get returns the value of one of StatNames
+func (s *StatSpread) get(stat string) int {
+	value, _ := s.Get(stat)
+	return value
+}
+
+func (s *StatSpread) field(stat string) *int {
+	switch stat {
+	case "hp":
+		return &s.HP
+	case "attack":
+		return &s.Attack
+	case "defense":
+		return &s.Defense
+	case "special-attack":
+		return &s.SpecialAttack
+	case "special-defense":
+		return &s.SpecialDefense
+	case "speed":
+		return &s.Speed
+	}
+	return nil
+}
+
+// StatInput describes a Pokemon whose actual stats should be calculated.
+// Nature is ignored in Gen 1-2, an empty Nature is neutral.
+type StatInput struct {
+	PokemonID      int        `json:"pokemonId"`
+	VersionGroupID int        `json:"versionGroupId"`
+	Level          int        `json:"level"`
+	Nature         string     `json:"nature"`
+	IVs            StatSpread `json:"ivs"`
+	EVs            StatSpread `json:"evs"`
+}
+
+// IVRange is the inclusive range of IVs (DVs in Gen 1-2) that produce an observed stat
+type IVRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+type IVRanges struct {
+	HP             IVRange `json:"hp"`
+	Attack         IVRange `json:"attack"`
+	Defense        IVRange `json:"defense"`
+	SpecialAttack  IVRange `json:"specialAttack"`
+	SpecialDefense IVRange `json:"specialDefense"`
+	Speed          IVRange `json:"speed"`
+}
+
+type StatCalculator struct {
+	pokemonRepo PokemonRepo
+	versionRepo VersionRepo
+	natureRepo  NatureRepo
+}
+
+func NewStatCalculator(pokemonRepo PokemonRepo, versionRepo VersionRepo, natureRepo NatureRepo) *StatCalculator {
+	return &StatCalculator{
+		pokemonRepo: pokemonRepo,
+		versionRepo: versionRepo,
+		natureRepo:  natureRepo,
+	}
+}
+
+// CalculateStats computes actual stats from the base stats stored on pokemon, using
+// the DV/Stat Experience formula for Gen 1-2 and the IV/EV/nature formula otherwise.
+// In Gen 1-2 the HP DV is derived from the other DVs, and special defense uses the
+// special attack DV and Stat Experience (the single Special stat).
+func (c *StatCalculator) CalculateStats(in *StatInput) (*StatSpread, error) {
+	calc, err := c.prepare(in.PokemonID, in.VersionGroupID, in.Level, in.Nature)
+	if err != nil {
+		return nil, err
+	}
+
+	ivs, evs := in.IVs, in.EVs
+	if calc.legacy {
+		ivs.SpecialDefense, evs.SpecialDefense = ivs.SpecialAttack, evs.SpecialAttack
+		ivs.HP = legacyHPDV(ivs)
+	}
+	if err := calc.validate(ivs, evs); err != nil {
+		return nil, err
+	}
+
+	var stats StatSpread
+	for _, stat := range StatNames {
+		stats.Set(stat, calc.stat(stat, ivs.get(stat), evs.get(stat)))
+	}
+
+	return &stats, nil
+}
+
+// EstimateIVs returns for every stat the range of IVs that yields the observed value.
+// An optional characteristic narrows the range of its highest stat. In Gen 1-2 the
+// special DV and Stat Experience are shared by both special stats and the HP DV has
+// to match the low bits of the other DVs, which narrows the ranges further.
+func (c *StatCalculator) EstimateIVs(in *StatInput, observed StatSpread, characteristic *dto.Characteristic) (*IVRanges, error) {
+	calc, err := c.prepare(in.PokemonID, in.VersionGroupID, in.Level, in.Nature)
+	if err != nil {
+		return nil, err
+	}
+
+	evs := in.EVs
+	if calc.legacy {
+		evs.SpecialDefense = evs.SpecialAttack
+	}
+	if err := calc.validate(StatSpread{}, evs); err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[string][]int, len(StatNames))
+	for _, stat := range StatNames {
+		for iv := 0; iv <= calc.maxIV(); iv++ {
+			if characteristic != nil && characteristic.HighestStat == stat && !slices.Contains(characteristic.PossibleValues, iv) {
+				continue
+			}
+			if calc.stat(stat, iv, evs.get(stat)) == observed.get(stat) {
+				candidates[stat] = append(candidates[stat], iv)
+			}
+		}
+		if len(candidates[stat]) == 0 {
+			return nil, fmt.Errorf("no IV produces %s %d at level %d, check level, nature and EVs", stat, observed.get(stat), in.Level)
+		}
+	}
+	if calc.legacy {
+		if err := narrowLegacyDVs(candidates); err != nil {
+			return nil, fmt.Errorf("%w at level %d, check level and stat experience", err, in.Level)
+		}
+	}
+
+	var ranges IVRanges
+	for _, stat := range StatNames {
+		dvs := candidates[stat]
+		ranges.set(stat, IVRange{Min: dvs[0], Max: dvs[len(dvs)-1]})
+	}
+
+	return &ranges, nil
+}
+
+// legacyHPBits are the stats whose lowest DV bit makes up the Gen 1-2 HP DV, highest bit first
+var legacyHPBits = []string{"attack", "defense", "speed", "special-attack"}
+
+// narrowLegacyDVs keeps the Gen 1-2 DV candidates of every stat that fit together:
+// one special DV for both special stats and an HP DV made of the others' low bits
+func narrowLegacyDVs(candidates map[string][]int) error {
+	special := slices.DeleteFunc(candidates["special-attack"], func(dv int) bool {
+		return !slices.Contains(candidates["special-defense"], dv)
+	})
+	candidates["special-attack"], candidates["special-defense"] = special, special
+
+	hasBit := func(stat string, bit int) bool {
+		return slices.ContainsFunc(candidates[stat], func(dv int) bool { return dv&1 == bit })
+	}
+	candidates["hp"] = slices.DeleteFunc(candidates["hp"], func(hp int) bool {
+		for i, stat := range legacyHPBits {
+			if !hasBit(stat, hp>>(3-i)&1) {
+				return true
+			}
+		}
+		return false
+	})
+
+	for i, stat := range legacyHPBits {
+		candidates[stat] = slices.DeleteFunc(candidates[stat], func(dv int) bool {
+			return !slices.ContainsFunc(candidates["hp"], func(hp int) bool { return hp>>(3-i)&1 == dv&1 })
+		})
+	}
+	candidates["special-defense"] = candidates["special-attack"]
+
+	for _, stat := range StatNames {
+		if len(candidates[stat]) == 0 {
+			return fmt.Errorf("no DVs produce the observed stats together")
+		}
+	}
+	return nil
+}
+
+func (r *IVRanges) set(stat string, value IVRange) {
+	switch stat {
+	case "hp":
+		r.HP = value
+	case "attack":
+		r.Attack = value
+	case "defense":
+		r.Defense = value
+	case "special-attack":
+		r.SpecialAttack = value
+	case "special-defense":
+		r.SpecialDefense = value
+	case "speed":
+		r.Speed = value
+	}
+}
+
+// statFormula holds everything needed to compute a single stat
+type statFormula struct {
+	pokemon *dto.Pokemon
+	base    StatSpread
+	level   int
+	legacy  bool // Gen 1-2 DV/Stat Experience formula
+	nature  *dto.Nature
+}
+
+func (c *StatCalculator) prepare(pokemonID, versionGroupID, level int, natureName string) (*statFormula, error) {
+	if level < 1 || level > 100 {
+		return nil, fmt.Errorf("level must be between 1 and 100, got %d", level)
+	}
+
+	pokemon, err := c.pokemonRepo.GetPokemonByID(pokemonID)
+	if err != nil {
+		return nil, err
+	}
+	versionGroup, err := c.versionRepo.GetVersionGroupByID(versionGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	calc := &statFormula{
+		pokemon: pokemon,
+		base: StatSpread{
+			HP:             pokemon.HP,
+			Attack:         pokemon.Attack,
+			Defense:        pokemon.Defense,
+			SpecialAttack:  pokemon.SpecialAttack,
+			SpecialDefense: pokemon.SpecialDefense,
+			Speed:          pokemon.Speed,
+		},
+		level:  level,
+		legacy: IsLegacyGeneration(versionGroup.GenerationName),
+	}
+
+	if !calc.legacy && natureName != "" {
+		calc.nature, err = c.natureRepo.GetNatureByName(natureName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return calc, nil
+}
+
+func (f *statFormula) maxIV() int {
+	if f.legacy {
+		return 15
+	}
+	return 31
+}
+
+func (f *statFormula) validate(ivs, evs StatSpread) error {
+	total := 0
+	for _, stat := range StatNames {
+		if iv := ivs.get(stat); iv < 0 || iv > f.maxIV() {
+			return fmt.Errorf("%s IV must be between 0 and %d, got %d", stat, f.maxIV(), iv)
+		}
+		ev := evs.get(stat)
+		if f.legacy {
+			if ev < 0 || ev > 65535 {
+				return fmt.Errorf("%s stat experience must be between 0 and 65535, got %d", stat, ev)
+			}
+			continue
+		}
+		if ev < 0 || ev > 255 {
+			return fmt.Errorf("%s EV must be between 0 and 255, got %d", stat, ev)
+		}
+		total += ev
+	}
+	if total > 510 {
+		return fmt.Errorf("EVs must not exceed 510 in total, got %d", total)
+	}
+	return nil
+}
+
+func (f *statFormula) stat(stat string, iv, ev int) int {
+	base := f.base.get(stat)
+
+	if f.legacy {
+		// Gen 1-2: floor(min(255, floor(sqrt(max(0, StatExp - 1))) + 1) / 4)
+		statExpBonus := min(255, int(math.Sqrt(float64(max(0, ev-1))))+1) / 4
+		value := ((base+iv)*2 + statExpBonus) * f.level / 100
+		if stat == "hp" {
+			return value + f.level + 10
+		}
+		return value + 5
+	}
+
+	value := (2*base + iv + ev/4) * f.level / 100
+	if stat == "hp" {
+		// Shedinja always has 1 HP
+		if f.pokemon.Name == "shedinja" {
+			return 1
+		}
+		return value + f.level + 10
+	}
+
+	value += 5
+	if f.nature != nil {
+		switch stat {
+		case f.nature.IncreasedStat:
+			value = value * 110 / 100
+		case f.nature.DecreasedStat:
+			value = value * 90 / 100
+		}
+	}
+	return value
+}
+
+// legacyHPDV derives the Gen 1-2 HP DV from the low bits of the other DVs
+func legacyHPDV(dvs StatSpread) int {
+	return (dvs.Attack&1)<<3 | (dvs.Defense&1)<<2 | (dvs.Speed&1)<<1 | (dvs.SpecialAttack & 1)
+}
+
+// IsLegacyGeneration reports whether a generation uses Gen 1-2 battle mechanics
+func IsLegacyGeneration(generationName string) bool {
+	return generationName == "generation-i" || generationName == "generation-ii"
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockNatureRepo struct {
+	mock.Mock
+}
+
+func (m *MockNatureRepo) InsertNature(n *external.Nature) error {
+	args := m.Called(n)
+	return args.Error(0)
+}
+
+func (m *MockNatureRepo) InsertCharacteristic(c *external.Characteristic) error {
+	args := m.Called(c)
+	return args.Error(0)
+}
+
+func (m *MockNatureRepo) GetNatureByName(name string) (*dto.Nature, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.Nature), args.Error(1)
+}
+
+func (m *MockNatureRepo) GetCharacteristicByID(id int) (*dto.Characteristic, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.Characteristic), args.Error(1)
+}
+
+func newTestStatCalculator() *StatCalculator {
+	pokemonRepo := new(MockPokemonRepo)
+	pokemonRepo.On("GetPokemonByID", 445).Return(&dto.Pokemon{
+		ID: 445, Name: "garchomp",
+		HP: 108, Attack: 130, Defense: 95, SpecialAttack: 80, SpecialDefense: 85, Speed: 102,
+	}, nil)
+	pokemonRepo.On("GetPokemonByID", 150).Return(&dto.Pokemon{
+		ID: 150, Name: "mewtwo",
+		HP: 106, Attack: 110, Defense: 90, SpecialAttack: 154, SpecialDefense: 90, Speed: 130,
+	}, nil)
+
+	versionRepo := new(MockVersionRepo)
+	versionRepo.On("GetVersionGroupByID", 1).Return(&dto.VersionGroup{ID: 1, Name: "red-blue", GenerationName: "generation-i"}, nil)
+	versionRepo.On("GetVersionGroupByID", 8).Return(&dto.VersionGroup{ID: 8, Name: "diamond-pearl", GenerationName: "generation-iv"}, nil)
+
+	natureRepo := new(MockNatureRepo)
+	natureRepo.On("GetNatureByName", "adamant").Return(&dto.Nature{ID: 3, Name: "adamant", IncreasedStat: "attack", DecreasedStat: "special-attack"}, nil)
+
+	return NewStatCalculator(pokemonRepo, versionRepo, natureRepo)
+}
+
+func TestStatSpread(t *testing.T) {
+	var s StatSpread
+	assert.True(t, s.Set("special-attack", 90))
+	value, ok := s.Get("special-attack")
+	assert.True(t, ok)
+	assert.Equal(t, 90, value)
+
+	// Unknown stats are reported, not a panic
+	assert.False(t, s.Set("special", 50))
+	_, ok = s.Get("special")
+	assert.False(t, ok)
+	assert.Equal(t, StatSpread{SpecialAttack: 90}, s)
+}
+
+func TestCalculateStats(t *testing.T) {
+	calculator := newTestStatCalculator()
+
+	t.Run("Gen 3+ formula with nature", func(t *testing.T) {
+		stats, err := calculator.CalculateStats(&StatInput{
+			PokemonID:      445,
+			VersionGroupID: 8,
+			Level:          78,
+			Nature:         "adamant",
+			IVs:            StatSpread{HP: 24, Attack: 12, Defense: 30, SpecialAttack: 16, SpecialDefense: 23, Speed: 5},
+			EVs:            StatSpread{HP: 74, Attack: 190, Defense: 91, SpecialAttack: 48, SpecialDefense: 84, Speed: 23},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &StatSpread{HP: 289, Attack: 278, Defense: 193, SpecialAttack: 135, SpecialDefense: 171, Speed: 171}, stats)
+	})
+
+	t.Run("Gen 1-2 formula with max DVs and stat experience", func(t *testing.T) {
+		stats, err := calculator.CalculateStats(&StatInput{
+			PokemonID:      150,
+			VersionGroupID: 1,
+			Level:          100,
+			IVs:            StatSpread{Attack: 15, Defense: 15, SpecialAttack: 15, Speed: 15},
+			EVs:            StatSpread{HP: 65535, Attack: 65535, Defense: 65535, SpecialAttack: 65535, Speed: 65535},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &StatSpread{HP: 415, Attack: 318, Defense: 278, SpecialAttack: 406, SpecialDefense: 278, Speed: 358}, stats)
+	})
+
+	t.Run("Rejects EVs over the total cap", func(t *testing.T) {
+		_, err := calculator.CalculateStats(&StatInput{
+			PokemonID:      445,
+			VersionGroupID: 8,
+			Level:          50,
+			EVs:            StatSpread{HP: 252, Attack: 252, Speed: 252},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestEstimateIVs(t *testing.T) {
+	calculator := newTestStatCalculator()
+
+	in := &StatInput{
+		PokemonID:      445,
+		VersionGroupID: 8,
+		Level:          78,
+		Nature:         "adamant",
+		EVs:            StatSpread{HP: 74, Attack: 190, Defense: 91, SpecialAttack: 48, SpecialDefense: 84, Speed: 23},
+	}
+	observed := StatSpread{HP: 289, Attack: 278, Defense: 193, SpecialAttack: 135, SpecialDefense: 171, Speed: 171}
+
+	ranges, err := calculator.EstimateIVs(in, observed, nil)
+	require.NoError(t, err)
+	assert.Equal(t, IVRange{Min: 24, Max: 24}, ranges.HP)
+	assert.LessOrEqual(t, ranges.Attack.Min, 12)
+	assert.GreaterOrEqual(t, ranges.Attack.Max, 12)
+	assert.LessOrEqual(t, ranges.Speed.Min, 5)
+	assert.GreaterOrEqual(t, ranges.Speed.Max, 5)
+
+	t.Run("Characteristic narrows the highest stat", func(t *testing.T) {
+		// "Capable of taking hits": defense is highest, defense IV mod 5 == 0
+		characteristic := &dto.Characteristic{HighestStat: "defense", GeneModulo: 0, PossibleValues: []int{0, 5, 10, 15, 20, 25, 30}}
+		ranges, err := calculator.EstimateIVs(in, observed, characteristic)
+		require.NoError(t, err)
+		assert.Equal(t, IVRange{Min: 30, Max: 30}, ranges.Defense)
+	})
+
+	t.Run("Impossible stat returns an error", func(t *testing.T) {
+		impossible := observed
+		impossible.HP = 500
+		_, err := calculator.EstimateIVs(in, impossible, nil)
+		assert.Error(t, err)
+	})
+	t.Run("Gen 1-2 DVs have to fit together", func(t *testing.T) {
+		legacy := &StatInput{PokemonID: 150, VersionGroupID: 1, Level: 25}
+		legacy.IVs = StatSpread{Attack: 14, Defense: 15, SpecialAttack: 9, Speed: 12}
+		observed, err := calculator.CalculateStats(legacy)
+		require.NoError(t, err)
+
+		// At level 25 every stat fits two DVs, the HP DV (4 or 5) fixes the
+		// low bits of attack, defense and speed
+		ranges, err := calculator.EstimateIVs(legacy, *observed, nil)
+		require.NoError(t, err)
+		assert.Equal(t, &IVRanges{
+			HP:             IVRange{Min: 4, Max: 5},
+			Attack:         IVRange{Min: 14, Max: 14},
+			Defense:        IVRange{Min: 15, Max: 15},
+			SpecialAttack:  IVRange{Min: 8, Max: 9},
+			SpecialDefense: IVRange{Min: 8, Max: 9},
+			Speed:          IVRange{Min: 12, Max: 12},
+		}, ranges)
+
+		// Special attack and special defense share one DV
+		mismatched := *observed
+		mismatched.SpecialDefense += 2
+		_, err = calculator.EstimateIVs(legacy, mismatched, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/progress"
+)
+
+// Failures lets a sync carry on past resources that fail to sync. Each one is
+// stored so it can be retried later. Syncers without Failures stop at the
+// first error.
+type Failures struct {
+	repo SyncFailureRepo
+	now  func() time.Time
+
+	Progress *progress.Tracker // Optional
+}
+
+func NewFailures(repo SyncFailureRepo) *Failures {
+	return &Failures{repo: repo, now: time.Now}
+}
+
+// Record stores err as the failure of a resource and returns nil for the sync
+// to carry on. On nil Failures, or when the failure can't be stored, the sync
+// stops with err.
+func (f *Failures) Record(resource string, id, versionID int, err error) error {
+	if f == nil {
+		return err
+	}
+
+	failure := &dto.SyncFailure{
+		Resource:   resource,
+		ResourceID: id,
+		VersionID:  versionID,
+		Error:      err.Error(),
+		FailedAt:   f.now(),
+	}
+	if recordErr := f.repo.InsertSyncFailure(failure); recordErr != nil {
+		return fmt.Errorf("%w (and failed to record it: %v)", err, recordErr)
+	}
+	f.Progress.Failed()
+	logger().Warn("sync failed, carrying on", "resource", resource, "id", id, "version_id", versionID, "err", err)
+
+	return nil
+}
+
+// Clear drops every stored failure, for syncs that start over
+func (f *Failures) Clear() error {
+	if f == nil {
+		return nil
+	}
+	return f.repo.DeleteSyncFailures()
+}
+
+// Retry tries every stored failure again with retry. Failures that sync are
+// dropped, the others are stored again with their new error. It returns how
+// many failures were fixed and how many still fail.
+func (f *Failures) Retry(retry func(*dto.SyncFailure) error) (fixed, failed int, err error) {
+	failures, err := f.repo.GetSyncFailures()
+	if err != nil {
+		return 0, 0, err
+	}
+	f.Progress.AddTotal(progress.Retries, len(failures))
+
+	for _, failure := range failures {
+		if err := retry(failure); err != nil {
+			if err := f.Record(failure.Resource, failure.ResourceID, failure.VersionID, err); err != nil {
+				return fixed, failed, err
+			}
+			failed++
+		} else {
+			if err := f.repo.DeleteSyncFailure(failure); err != nil {
+				return fixed, failed, err
+			}
+			fixed++
+		}
+		f.Progress.Done(progress.Retries)
+	}
+
+	return fixed, failed, nil
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockSyncFailureRepo struct {
+	mock.Mock
+}
+
+func (m *MockSyncFailureRepo) InsertSyncFailure(f *dto.SyncFailure) error {
+	args := m.Called(f)
+	return args.Error(0)
+}
+
+func (m *MockSyncFailureRepo) GetSyncFailures() ([]*dto.SyncFailure, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.SyncFailure), args.Error(1)
+}
+
+func (m *MockSyncFailureRepo) DeleteSyncFailure(f *dto.SyncFailure) error {
+	args := m.Called(f)
+	return args.Error(0)
+}
+
+func (m *MockSyncFailureRepo) DeleteSyncFailures() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func TestFailuresRecord(t *testing.T) {
+	notFound := errors.New("failed to fetch pokemon: 404 Not Found")
+
+	t.Run("Without failures the sync stops", func(t *testing.T) {
+		var failures *Failures
+		assert.Equal(t, notFound, failures.Record(dto.ResourceSpecies, 25, 1, notFound))
+		assert.NoError(t, failures.Clear())
+	})
+
+	t.Run("Records and carries on", func(t *testing.T) {
+		now := time.Unix(1700000000, 0)
+		repo := new(MockSyncFailureRepo)
+		repo.On("InsertSyncFailure", &dto.SyncFailure{
+			Resource:   dto.ResourceSpecies,
+			ResourceID: 25,
+			VersionID:  1,
+			Error:      notFound.Error(),
+			FailedAt:   now,
+		}).Return(nil)
+
+		failures := NewFailures(repo)
+		failures.now = func() time.Time { return now }
+		failures.Progress = progress.NewTracker()
+		require.NoError(t, failures.Record(dto.ResourceSpecies, 25, 1, notFound))
+		repo.AssertExpectations(t)
+		assert.Equal(t, 1, failures.Progress.Snapshot().Failed)
+	})
+
+	t.Run("Stops when the failure can't be recorded", func(t *testing.T) {
+		repo := new(MockSyncFailureRepo)
+		repo.On("InsertSyncFailure", mock.Anything).Return(errors.New("no such table: sync_failures"))
+
+		err := NewFailures(repo).Record(dto.ResourceMove, 33, 0, notFound)
+		assert.ErrorIs(t, err, notFound)
+		assert.ErrorContains(t, err, "no such table")
+	})
+}
+
+func TestFailuresRetry(t *testing.T) {
+	fixedMove := &dto.SyncFailure{Resource: dto.ResourceMove, ResourceID: 33, Error: "timeout", Attempts: 1}
+	brokenSpecies := &dto.SyncFailure{Resource: dto.ResourceSpecies, ResourceID: 25, VersionID: 1, Error: "404 Not Found", Attempts: 1}
+
+	repo := new(MockSyncFailureRepo)
+	repo.On("GetSyncFailures").Return([]*dto.SyncFailure{fixedMove, brokenSpecies}, nil)
+	repo.On("DeleteSyncFailure", fixedMove).Return(nil).Once()
+	repo.On("InsertSyncFailure", mock.MatchedBy(func(f *dto.SyncFailure) bool {
+		return f.Resource == dto.ResourceSpecies && f.ResourceID == 25 && f.VersionID == 1 && f.Error == "still 404"
+	})).Return(nil).Once()
+
+	failures := NewFailures(repo)
+	failures.Progress = progress.NewTracker()
+	var retried []string
+	fixed, failed, err := failures.Retry(func(f *dto.SyncFailure) error {
+		retried = append(retried, f.Resource)
+		if f.Resource == dto.ResourceSpecies {
+			return errors.New("still 404")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, fixed)
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, []string{dto.ResourceMove, dto.ResourceSpecies}, retried)
+	repo.AssertExpectations(t)
+
+	s := failures.Progress.Snapshot()
+	assert.Equal(t, []progress.Stage{{Name: progress.Retries, Done: 2, Total: 2}}, s.Stages)
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/progress"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/scraper"
+)
+
+// TrainerSyncer stores the gym leaders and Elite Four scraped from Bulbapedia.
+// A scraped team lists the games it appears in by name, each becomes one
+// trainer battle per matching version of the version group.
+type TrainerSyncer struct {
+	scraper     TrainerScraper
+	versionRepo VersionRepo
+	repo        TrainerRepo
+
+	Progress *progress.Tracker // Optional
+	Failures *Failures         // Optional, version groups that fail to sync are skipped with it
+}
+
+func NewTrainerSyncer(scraper TrainerScraper, versionRepo VersionRepo, repo TrainerRepo) *TrainerSyncer {
+	return &TrainerSyncer{
+		scraper:     scraper,
+		versionRepo: versionRepo,
+		repo:        repo,
+	}
+}
+
+// SyncAll syncs the trainers of every version group with a Bulbapedia page
+func (s *TrainerSyncer) SyncAll() error {
+	versionGroups := make([]string, 0, len(scraper.GamePages))
+	for name := range scraper.GamePages {
+		versionGroups = append(versionGroups, name)
+	}
+	sort.Strings(versionGroups)
+
+	for _, name := range versionGroups {
+		if err := s.SyncVersionGroup(name); err != nil {
+			if err := s.Failures.Record(dto.ResourceTrainers, scraper.VersionGroupIDs[name], 0, err); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// SyncVersionGroup syncs the trainers of one version group. A trainer whose
+// team can't be stored, e.g. because of a species name that doesn't match the
+// reference data, is logged and skipped.
+func (s *TrainerSyncer) SyncVersionGroup(name string) error {
+	versions, err := s.versionGroupVersions(name)
+	if err != nil {
+		return err
+	}
+	return s.syncTrainers(name, versions)
+}
+
+// SyncVersionGroupByID syncs the trainers of the version group with an ID
+func (s *TrainerSyncer) SyncVersionGroupByID(id int) error {
+	versionGroup, err := s.versionRepo.GetVersionGroupByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to get version group %d: %w", id, err)
+	}
+	return s.SyncVersionGroup(versionGroup.Name)
+}
+
+// versionGroupVersions returns the synced versions of a version group, at
+// least one
+func (s *TrainerSyncer) versionGroupVersions(name string) ([]*dto.Version, error) {
+	versions, err := s.versionRepo.GetVersionGroupVersions(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get versions of %s: %w", name, err)
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("version group %s has no versions, sync versions first", name)
+	}
+	return versions, nil
+}
+
+func (s *TrainerSyncer) syncTrainers(name string, versions []*dto.Version) error {
+	trainers, err := s.scraper.ScrapeTrainers(name)
+	if err != nil {
+		return fmt.Errorf("failed to scrape trainers of %s: %w", name, err)
+	}
+
+	s.Progress.AddTotal(progress.Trainers, len(trainers))
+	for _, t := range trainers {
+		for _, battle := range trainerBattles(t, versions) {
+			if err := s.repo.InsertTrainer(battle); err != nil {
+				logger().Warn("skipped trainer", "trainer", t.Name, "battle", battle.Battle, "err", err)
+				s.Progress.Failed()
+				continue
+			}
+			s.Progress.Inserted(1)
+		}
+		s.Progress.Done(progress.Trainers)
+	}
+
+	return nil
+}
+
+// trainerBattles turns the teams of a scraped trainer into one battle per
+// version, teams for games outside the version group are dropped
+func trainerBattles(t *scraper.Trainer, versions []*dto.Version) []*dto.Trainer {
+	var battles []*dto.Trainer
+	for _, team := range t.Teams {
+		for _, v := range versions {
+			if !slices.Contains(team.Games, v.Name) {
+				continue
+			}
+
+			battle := &dto.Trainer{
+				Name:          t.Name,
+				Role:          t.Role,
+				VersionID:     v.ID,
+				Battle:        team.Battle,
+				Order:         t.Order,
+				Location:      t.Location,
+				Badge:         t.Badge,
+				TypeSpecialty: t.TypeSpecialty,
+				SourceURL:     t.URL,
+				Team:          make([]dto.TrainerPokemon, 0, len(team.Pokemon)),
+			}
+			for i, p := range team.Pokemon {
+				battle.Team = append(battle.Team, dto.TrainerPokemon{
+					Slot:        i + 1,
+					SpeciesName: p.Species,
+					Level:       p.Level,
+					HeldItem:    p.HeldItem,
+					Moves:       p.Moves,
+				})
+			}
+			battles = append(battles, battle)
+		}
+	}
+	return battles
+}
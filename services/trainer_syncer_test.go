@@ -0,0 +1,138 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/scraper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockTrainerScraper struct {
+	mock.Mock
+}
+
+func (m *MockTrainerScraper) ScrapeTrainers(versionGroup string) ([]*scraper.Trainer, error) {
+	args := m.Called(versionGroup)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*scraper.Trainer), args.Error(1)
+}
+
+type MockTrainerRepo struct {
+	mock.Mock
+}
+
+func (m *MockTrainerRepo) InsertTrainer(t *dto.Trainer) error {
+	args := m.Called(t)
+	return args.Error(0)
+}
+
+func (m *MockTrainerRepo) GetTrainers(versionID int) ([]*dto.Trainer, error) {
+	args := m.Called(versionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.Trainer), args.Error(1)
+}
+
+func (m *MockTrainerRepo) GetTrainerByID(id int) (*dto.Trainer, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.Trainer), args.Error(1)
+}
+
+func TestTrainerSyncer_SyncVersionGroup(t *testing.T) {
+	mockScraper := new(MockTrainerScraper)
+	mockVersionRepo := new(MockVersionRepo)
+	mockRepo := new(MockTrainerRepo)
+	syncer := NewTrainerSyncer(mockScraper, mockVersionRepo, mockRepo)
+
+	mockVersionRepo.On("GetVersionGroupVersions", "red-blue").Return([]*dto.Version{
+		{ID: 1, Name: "red", VersionGroupID: 1},
+		{ID: 2, Name: "blue", VersionGroupID: 1},
+	}, nil)
+	mockScraper.On("ScrapeTrainers", "red-blue").Return([]*scraper.Trainer{
+		{
+			Name: "Brock", Role: "gym-leader", Order: 1, Location: "Pewter Gym", Badge: "Boulder Badge", TypeSpecialty: "rock",
+			URL: "https://bulbapedia.bulbagarden.net/wiki/Brock",
+			Teams: []scraper.Team{
+				{Games: []string{"red", "green", "blue"}, Battle: 1, Pokemon: []scraper.TeamPokemon{
+					{Species: "geodude", Level: 12, Moves: []string{"tackle"}},
+					{Species: "onix", Level: 14, Moves: []string{"tackle", "screech"}},
+				}},
+				{Games: []string{"yellow"}, Battle: 1, Pokemon: []scraper.TeamPokemon{{Species: "geodude", Level: 10}}},
+			},
+		},
+		{
+			Name: "Misty", Role: "gym-leader", Order: 2,
+			Teams: []scraper.Team{
+				{Games: []string{"red", "blue"}, Battle: 1, Pokemon: []scraper.TeamPokemon{{Species: "starmie", Level: 21}}},
+			},
+		},
+	}, nil)
+
+	var inserted []*dto.Trainer
+	mockRepo.On("InsertTrainer", mock.MatchedBy(func(t *dto.Trainer) bool { return t.Name == "Brock" })).
+		Run(func(args mock.Arguments) { inserted = append(inserted, args.Get(0).(*dto.Trainer)) }).
+		Return(nil)
+	// A failing trainer is skipped, not fatal
+	mockRepo.On("InsertTrainer", mock.MatchedBy(func(t *dto.Trainer) bool { return t.Name == "Misty" })).
+		Return(errors.New("species starmie not found"))
+
+	require.NoError(t, syncer.SyncVersionGroup("red-blue"))
+
+	// The yellow team has no version in red-blue
+	require.Len(t, inserted, 2)
+	assert.Equal(t, 1, inserted[0].VersionID)
+	assert.Equal(t, 2, inserted[1].VersionID)
+	assert.Equal(t, "Pewter Gym", inserted[0].Location)
+	assert.Equal(t, "https://bulbapedia.bulbagarden.net/wiki/Brock", inserted[0].SourceURL)
+	require.Len(t, inserted[0].Team, 2)
+	assert.Equal(t, dto.TrainerPokemon{Slot: 2, SpeciesName: "onix", Level: 14, Moves: []string{"tackle", "screech"}}, inserted[0].Team[1])
+	mockRepo.AssertNumberOfCalls(t, "InsertTrainer", 4)
+}
+
+func TestTrainerSyncer_SyncVersionGroupWithoutVersions(t *testing.T) {
+	mockScraper := new(MockTrainerScraper)
+	mockVersionRepo := new(MockVersionRepo)
+	syncer := NewTrainerSyncer(mockScraper, mockVersionRepo, new(MockTrainerRepo))
+
+	mockVersionRepo.On("GetVersionGroupVersions", "red-blue").Return([]*dto.Version{}, nil)
+
+	assert.Error(t, syncer.SyncVersionGroup("red-blue"))
+	mockScraper.AssertNotCalled(t, "ScrapeTrainers", mock.Anything)
+}
+
+func TestTrainerSyncer_SyncAllRecordsFailures(t *testing.T) {
+	mockScraper := new(MockTrainerScraper)
+	mockVersionRepo := new(MockVersionRepo)
+	failureRepo := new(MockSyncFailureRepo)
+	syncer := NewTrainerSyncer(mockScraper, mockVersionRepo, new(MockTrainerRepo))
+	syncer.Failures = NewFailures(failureRepo)
+
+	// Only red-blue is synced, every other version group fails and is skipped
+	mockVersionRepo.On("GetVersionGroupVersions", "red-blue").Return([]*dto.Version{{ID: 1, Name: "red", VersionGroupID: 1}}, nil)
+	mockVersionRepo.On("GetVersionGroupVersions", mock.Anything).Return([]*dto.Version{}, nil)
+	mockScraper.On("ScrapeTrainers", "red-blue").Return([]*scraper.Trainer{}, nil)
+	var recorded []*dto.SyncFailure
+	failureRepo.On("InsertSyncFailure", mock.Anything).
+		Run(func(args mock.Arguments) { recorded = append(recorded, args.Get(0).(*dto.SyncFailure)) }).
+		Return(nil)
+
+	require.NoError(t, syncer.SyncAll())
+
+	require.Len(t, recorded, len(scraper.GamePages)-1)
+	for _, f := range recorded {
+		assert.Equal(t, dto.ResourceTrainers, f.Resource)
+		assert.NotZero(t, f.ResourceID)
+		assert.NotEqual(t, 1, f.ResourceID)
+	}
+	mockScraper.AssertNumberOfCalls(t, "ScrapeTrainers", 1)
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/progress"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
+)
+
+type TypeSyncer struct {
+	client      TypeAPIClient
+	repo        TypeRepo
+	rateLimiter *time.Ticker
+
+	Progress *progress.Tracker // Optional
+	Failures *Failures         // Optional, types that fail are skipped with it
+}
+
+func NewTypeSyncer(client TypeAPIClient, repo TypeRepo, rateLimiter *time.Ticker) *TypeSyncer {
+	return &TypeSyncer{
+		client:      client,
+		repo:        repo,
+		rateLimiter: rateLimiter,
+	}
+}
+
+// SyncAll syncs every type with its current and past damage relations
+func (s *TypeSyncer) SyncAll(limit int) error {
+	allTypes, err := s.client.FetchAll(fmt.Sprintf("type?limit=%d", limit))
+	if err != nil {
+		return fmt.Errorf("failed to fetch types: %w", err)
+	}
+	s.Progress.AddTotal(progress.Types, len(allTypes))
+
+	for i, at := range allTypes {
+		if i > 0 {
+			<-s.rateLimiter.C
+		}
+
+		id, err := utils.ExtractIDFromURL(at.Url)
+		if err != nil {
+			return err
+		}
+		if err := s.SyncType(id); err != nil {
+			if err := s.Failures.Record(dto.ResourceType, id, 0, err); err != nil {
+				return err
+			}
+		}
+		s.Progress.Done(progress.Types)
+	}
+
+	return nil
+}
+
+// SyncType syncs one type with its damage relations
+func (s *TypeSyncer) SyncType(id int) error {
+	t, err := s.client.FetchType(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch type %d: %w", id, err)
+	}
+	if err := s.repo.InsertType(t); err != nil {
+		return fmt.Errorf("failed to insert type %d: %w", id, err)
+	}
+	s.Progress.Inserted(1)
+	return nil
+}
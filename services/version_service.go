@@ -0,0 +1,56 @@
+package services
+
+import "github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
+
+// VersionService answers questions about games from the reference data
+type VersionService struct {
+	versionRepo   VersionRepo
+	encounterRepo EncounterRepo
+	pokemonRepo   PokemonRepo
+	moveRepo      MoveRepo
+}
+
+func NewVersionService(versionRepo VersionRepo, encounterRepo EncounterRepo, pokemonRepo PokemonRepo, moveRepo MoveRepo) *VersionService {
+	return &VersionService{
+		versionRepo:   versionRepo,
+		encounterRepo: encounterRepo,
+		pokemonRepo:   pokemonRepo,
+		moveRepo:      moveRepo,
+	}
+}
+
+// GetVersionExclusives returns, for each version of a version group, the
+// species that cannot be obtained in its paired versions
+func (s *VersionService) GetVersionExclusives(versionGroupID int) ([]*dto.VersionExclusives, error) {
+	if _, err := s.versionRepo.GetVersionGroupByID(versionGroupID); err != nil {
+		return nil, err
+	}
+	return s.encounterRepo.GetVersionExclusives(versionGroupID)
+}
+
+// GetVersionMetadata returns the IGDB metadata and media of a version
+func (s *VersionService) GetVersionMetadata(versionID int) (*dto.VersionMetadata, error) {
+	return s.versionRepo.GetVersionMetadata(versionID)
+}
+
+// GetPokemonSprites returns the in-game sprites of a pokemon in a version
+// group, empty for games PokeAPI has no sprites of
+func (s *VersionService) GetPokemonSprites(pokemonID, versionGroupID int) ([]*dto.PokemonSprite, error) {
+	if _, err := s.pokemonRepo.GetPokemonByID(pokemonID); err != nil {
+		return nil, err
+	}
+	vg, err := s.versionRepo.GetVersionGroupByID(versionGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return s.pokemonRepo.GetPokemonSprites(pokemonID, vg.Name)
+}
+
+// GetMove returns a move as it was in a version group, with its type, power,
+// accuracy, PP and effect chance from that version group
+func (s *VersionService) GetMove(moveID, versionGroupID int) (*dto.Move, error) {
+	if _, err := s.versionRepo.GetVersionGroupByID(versionGroupID); err != nil {
+		return nil, err
+	}
+	return s.moveRepo.GetMoveForVersionGroup(moveID, versionGroupID)
+}
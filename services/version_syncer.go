@@ -1,13 +1,14 @@
 package services
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"os"
 	"time"
 
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/igdb"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/dto"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/models/external"
+	"github.com/ArtisGulbis/pokemon-companion-go-backend/progress"
 	"github.com/ArtisGulbis/pokemon-companion-go-backend/utils"
 )
 
@@ -15,54 +16,142 @@ type VersionSyncer struct {
 	client      VersionAPIClient
 	igdbClient  IGDBClient
 	repo        VersionRepo
+	images      ImageDownloader
 	rateLimiter *time.Ticker
+	// IGDB games by version name, fetched in one query on the first insert
+	games map[string]*igdb.Game
+
+	Progress *progress.Tracker // Optional
 }
 
-func NewVersionSyncer(client VersionAPIClient, igdbClient IGDBClient, repo VersionRepo, rateLimiter *time.Ticker) *VersionSyncer {
+func NewVersionSyncer(client VersionAPIClient, igdbClient IGDBClient, repo VersionRepo, images ImageDownloader, rateLimiter *time.Ticker) *VersionSyncer {
 	return &VersionSyncer{
 		client:      client,
 		igdbClient:  igdbClient,
 		repo:        repo,
+		images:      images,
 		rateLimiter: rateLimiter,
 	}
 }
 
 func (s *VersionSyncer) InsertVersion(v *external.Version) error {
-	// Check if cover already exists locally to skip IGDB API call
-	localPath := utils.GetGameCoverPath(v.Name)
-
-	// If cover already exists, just use it and skip IGDB API call
-	if _, err := os.Stat(localPath); err == nil {
-		v.Cover = localPath
-		// Still need release date from IGDB if available
-		game, err := s.igdbClient.GetPokemonGameCover(v.Name)
-		if err == nil && game != nil {
-			v.ReleaseDate = int(game.FirstReleaseDate)
+	game := s.game(v.Name)
+
+	var cover *dto.Asset
+	if game != nil {
+		v.ReleaseDate = int(game.FirstReleaseDate)
+		if game.Cover.ImageID != "" {
+			asset, err := s.images.Download(igdb.GetCoverURL(game.Cover.ImageID, igdb.SizeCoverBig))
+			if err != nil {
+				logger().Warn("failed to download cover", "version", v.Name, "err", err)
+				s.Progress.Failed()
+			} else {
+				cover = asset
+				v.Cover = asset.Path
+			}
 		}
-	} else {
-		// Cover doesn't exist, fetch from IGDB
-		game, err := s.igdbClient.GetPokemonGameCover(v.Name)
+	}
+
+	if err := s.repo.InsertVersion(v); err != nil {
+		return err
+	}
+	if game == nil {
+		return nil
+	}
+
+	return s.repo.InsertVersionMetadata(s.versionMetadata(v, game, cover))
+}
+
+// game returns the IGDB game of a version, nil if IGDB doesn't know it or
+// can't be reached. Syncs don't fail without IGDB, versions just miss covers.
+func (s *VersionSyncer) game(versionName string) *igdb.Game {
+	if s.games == nil {
+		games, err := s.igdbClient.GetGames(context.Background(), s.gameIDs())
 		if err != nil {
-			// Don't fail the sync if cover can't be fetched, just log a warning
-			log.Printf("Warning: failed to get cover for %s: %v", v.Name, err)
-		} else if game != nil {
-			if game.Cover.ImageID != "" {
-				// Get the cover URL from IGDB
-				coverURL := igdb.GetCoverURL(game.Cover.ImageID, "cover_big")
-
-				// Download and save the image locally
-				if err := utils.DownloadImage(coverURL, localPath); err != nil {
-					log.Printf("Warning: failed to download cover for %s: %v", v.Name, err)
-				} else {
-					// Store the local path instead of the URL
-					v.Cover = localPath
-				}
-			}
-			v.ReleaseDate = int(game.FirstReleaseDate)
+			logger().Warn("failed to get games from IGDB", "err", err)
+			games = map[string]*igdb.Game{}
 		}
+		s.games = games
 	}
+	return s.games[versionName]
+}
 
-	return s.repo.InsertVersion(v)
+// gameIDs returns the built-in IGDB game IDs with confirmed mappings from
+// the database on top
+func (s *VersionSyncer) gameIDs() map[string]int {
+	ids := igdb.KnownGameIDs()
+	mappings, err := s.repo.GetGameMappings()
+	if err != nil {
+		logger().Warn("failed to get IGDB game mappings", "err", err)
+		return ids
+	}
+	for _, m := range mappings {
+		if m.Status == dto.GameMappingConfirmed {
+			ids[m.VersionName] = m.IGDBID
+		}
+	}
+	return ids
+}
+
+// versionMetadata turns an IGDB game into the metadata of a version and
+// downloads its media. Media that fails to download is left out.
+func (s *VersionSyncer) versionMetadata(v *external.Version, game *igdb.Game, cover *dto.Asset) *dto.VersionMetadata {
+	m := &dto.VersionMetadata{
+		VersionID:  v.ID,
+		IGDBID:     game.ID,
+		Summary:    game.Summary,
+		Storyline:  game.Storyline,
+		Platforms:  []string{},
+		Developers: game.Developers(),
+		Publishers: game.Publishers(),
+	}
+	for _, p := range game.Platforms {
+		m.Platforms = append(m.Platforms, p.Name)
+	}
+	for _, rd := range game.ReleaseDates {
+		m.ReleaseDates = append(m.ReleaseDates, dto.ReleaseDate{
+			Region:   igdb.RegionName(rd.Region),
+			Platform: rd.Platform.Name,
+			Date:     rd.Date,
+		})
+	}
+
+	if cover != nil {
+		m.Media = append(m.Media, versionMedia(dto.MediaCover, game.Cover.ImageID, cover))
+	}
+	m.Media = append(m.Media, s.downloadMedia(v.Name, dto.MediaArtwork, game.Artworks, igdb.Size1080p)...)
+	m.Media = append(m.Media, s.downloadMedia(v.Name, dto.MediaScreenshot, game.Screenshots, igdb.SizeScreenshotBig)...)
+
+	return m
+}
+
+func (s *VersionSyncer) downloadMedia(versionName, kind string, images []igdb.Image, size string) []dto.VersionMedia {
+	var media []dto.VersionMedia
+	for _, img := range images {
+		if img.ImageID == "" {
+			continue
+		}
+		asset, err := s.images.Download(igdb.GetImageURL(img.ImageID, size))
+		if err != nil {
+			logger().Warn("failed to download media", "kind", kind, "image_id", img.ImageID, "version", versionName, "err", err)
+			s.Progress.Failed()
+			continue
+		}
+		media = append(media, versionMedia(kind, img.ImageID, asset))
+	}
+	return media
+}
+
+// versionMedia describes a downloaded image by the size it was downloaded
+// in, IGDB's sizes are of the full image
+func versionMedia(kind, imageID string, asset *dto.Asset) dto.VersionMedia {
+	return dto.VersionMedia{
+		Kind:    kind,
+		ImageID: imageID,
+		Width:   asset.Width,
+		Height:  asset.Height,
+		Path:    asset.Path,
+	}
 }
 
 func (s *VersionSyncer) InsertVersionGroup(vg *external.VersionGroup) error {
@@ -104,7 +193,7 @@ func (s *VersionSyncer) SyncVersionGroup(id int) (*external.VersionGroup, error)
 func (s *VersionSyncer) SyncAll(limit int) error {
 	allVersions, err := s.client.FetchAll(fmt.Sprintf("version?limit=%d", limit))
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("failed to fetch versions: %w", err)
 	}
 
 	for i, av := range allVersions {
@@ -114,17 +203,16 @@ func (s *VersionSyncer) SyncAll(limit int) error {
 
 		id, err := utils.ExtractIDFromURL(av.Url)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 		version, err := s.client.FetchVersion(id)
 		if err != nil {
-			log.Fatal(err)
+			return fmt.Errorf("failed to fetch version %d: %w", id, err)
 		}
-		err = s.repo.InsertVersion(version)
-		if err != nil {
-			log.Fatal(err)
+		if err := s.repo.InsertVersion(version); err != nil {
+			return fmt.Errorf("failed to insert version %s: %w", version.Name, err)
 		}
-		fmt.Printf("Inserted Version %s (%d/%d)\n", version.Name, i+1, len(allVersions))
+		logger().Info("inserted version", "version", version.Name, "n", i+1, "total", len(allVersions))
 	}
 
 	return nil
@@ -1,6 +1,9 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -61,12 +64,78 @@ func (m *MockVersionRepo) GetVersionByID(id int) (*dto.Version, error) {
 	return args.Get(0).(*dto.Version), args.Error(1)
 }
 
+func (m *MockVersionRepo) GetVersionMetadata(versionID int) (*dto.VersionMetadata, error) {
+	args := m.Called(versionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.VersionMetadata), args.Error(1)
+}
+
+func (m *MockVersionRepo) GetVersionGroupByID(id int) (*dto.VersionGroup, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.VersionGroup), args.Error(1)
+}
+
+func (m *MockVersionRepo) InsertVersionMetadata(md *dto.VersionMetadata) error {
+	args := m.Called(md)
+	return args.Error(0)
+}
+
+func (m *MockVersionRepo) GetVersions() ([]*dto.Version, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.Version), args.Error(1)
+}
+
+func (m *MockVersionRepo) InsertGameMapping(gm *dto.GameMapping) error {
+	args := m.Called(gm)
+	return args.Error(0)
+}
+
+func (m *MockVersionRepo) GetGameMappings() ([]*dto.GameMapping, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.GameMapping), args.Error(1)
+}
+
+func (m *MockVersionRepo) GetVersionGroupVersions(versionGroupName string) ([]*dto.Version, error) {
+	args := m.Called(versionGroupName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.Version), args.Error(1)
+}
+
 type MockIGDBClient struct {
 	mock.Mock
 }
 
-func (m *MockIGDBClient) GetPokemonGameCover(name string) (*igdb.Game, error) {
-	args := m.Called(name)
+func (m *MockIGDBClient) GetGames(ctx context.Context, gameIDs map[string]int) (map[string]*igdb.Game, error) {
+	args := m.Called(ctx, gameIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*igdb.Game), args.Error(1)
+}
+
+func (m *MockIGDBClient) SearchGame(ctx context.Context, gameName string) ([]igdb.Game, error) {
+	args := m.Called(ctx, gameName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]igdb.Game), args.Error(1)
+}
+
+func (m *MockIGDBClient) GetGameByID(ctx context.Context, gameID int) (*igdb.Game, error) {
+	args := m.Called(ctx, gameID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -97,12 +166,13 @@ func TestSyncVersion(t *testing.T) {
 		mockClient.On("FetchVersion", 1).Return(mockResponse, nil)
 
 		mockRepo.On("InsertVersion", mock.AnythingOfType("*external.Version")).Return(nil).Once()
-		mockIGDBClient.On("GetPokemonGameCover", mock.Anything).Return(nil, nil).Once()
+		mockRepo.On("GetGameMappings").Return([]*dto.GameMapping{}, nil).Once()
+		mockIGDBClient.On("GetGames", mock.Anything, mock.Anything).Return(map[string]*igdb.Game{}, nil).Once()
 
 		rateLimiter := time.NewTicker(1 * time.Millisecond)
 
 		// Create syncer with mocks
-		syncer := NewVersionSyncer(mockClient, mockIGDBClient, mockRepo, rateLimiter)
+		syncer := NewVersionSyncer(mockClient, mockIGDBClient, mockRepo, new(MockImageDownloader), rateLimiter)
 
 		// Act
 		response, err := syncer.SyncVersion(1)
@@ -118,6 +188,112 @@ func TestSyncVersion(t *testing.T) {
 		assert.Equal(t, mockResponse, response)
 	})
 }
+
+func TestInsertVersionWithIGDBGame(t *testing.T) {
+	game := &igdb.Game{
+		ID:               1561,
+		Name:             "Pokémon Red",
+		FirstReleaseDate: 825811200,
+		Summary:          "Catch them all.",
+		Cover:            igdb.Cover{ImageID: "co1", Width: 264, Height: 374},
+		Platforms:        []igdb.Platform{{Name: "Game Boy"}},
+		ReleaseDates: []igdb.ReleaseDate{
+			{Date: 825811200, Region: 5, Platform: igdb.Platform{Name: "Game Boy"}},
+			{Date: 906076800, Region: 2, Platform: igdb.Platform{Name: "Game Boy"}},
+		},
+		InvolvedCompanies: []igdb.InvolvedCompany{
+			{Company: igdb.Company{Name: "Game Freak"}, Developer: true},
+			{Company: igdb.Company{Name: "Nintendo"}, Publisher: true},
+		},
+		Screenshots: []igdb.Image{{ImageID: "sc1", Width: 889, Height: 500}, {ImageID: "sc2"}},
+		Artworks:    []igdb.Image{{ImageID: "ar1"}},
+	}
+
+	// Confirmed mappings override the built-in IDs, pending ones are ignored
+	mockRepo := new(MockVersionRepo)
+	mockRepo.On("GetGameMappings").Return([]*dto.GameMapping{
+		{VersionName: "blue", IGDBID: 1561, Status: dto.GameMappingConfirmed},
+		{VersionName: "new-game", IGDBID: 9999, Status: dto.GameMappingPending},
+	}, nil).Once()
+
+	mockIGDBClient := new(MockIGDBClient)
+	mockIGDBClient.On("GetGames", mock.Anything, mock.MatchedBy(func(ids map[string]int) bool {
+		_, pending := ids["new-game"]
+		return ids["red"] == 1561 && ids["blue"] == 1561 && !pending
+	})).Return(map[string]*igdb.Game{"red": game, "blue": game}, nil).Once()
+
+	var stored *dto.VersionMetadata
+	mockRepo.On("InsertVersion", mock.AnythingOfType("*external.Version")).Return(nil).Twice()
+	mockRepo.On("InsertVersionMetadata", mock.AnythingOfType("*dto.VersionMetadata")).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*dto.VersionMetadata)
+	}).Return(nil).Twice()
+
+	images := new(MockImageDownloader)
+	for _, id := range []string{"t_cover_big/co1", "t_1080p/ar1", "t_screenshot_big/sc1"} {
+		url := "https://images.igdb.com/igdb/image/upload/" + id + ".jpg"
+		path := "images/assets/" + id[strings.Index(id, "/")+1:] + ".jpg"
+		images.On("Download", url).Return(&dto.Asset{SourceURL: url, Width: 100, Height: 50, Path: path}, nil)
+	}
+	images.On("Download", "https://images.igdb.com/igdb/image/upload/t_screenshot_big/sc2.jpg").
+		Return(nil, errors.New("bad status: 404 Not Found"))
+
+	syncer := NewVersionSyncer(new(MockVersionAPIClient), mockIGDBClient, mockRepo, images, time.NewTicker(time.Millisecond))
+
+	red := &external.Version{ID: 1, Name: "red", VersionGroup: external.Response{Url: "https://pokeapi.co/api/v2/version-group/1/"}}
+	require.NoError(t, syncer.InsertVersion(red))
+
+	assert.Equal(t, "images/assets/co1.jpg", red.Cover)
+	assert.Equal(t, 825811200, red.ReleaseDate)
+	images.AssertExpectations(t)
+
+	require.NotNil(t, stored)
+	assert.Equal(t, &dto.VersionMetadata{
+		VersionID:  1,
+		IGDBID:     1561,
+		Summary:    "Catch them all.",
+		Platforms:  []string{"Game Boy"},
+		Developers: []string{"Game Freak"},
+		Publishers: []string{"Nintendo"},
+		ReleaseDates: []dto.ReleaseDate{
+			{Region: "japan", Platform: "Game Boy", Date: 825811200},
+			{Region: "north-america", Platform: "Game Boy", Date: 906076800},
+		},
+		// Sizes are of the downloaded images, sc2 failed to download
+		Media: []dto.VersionMedia{
+			{Kind: dto.MediaCover, ImageID: "co1", Width: 100, Height: 50, Path: "images/assets/co1.jpg"},
+			{Kind: dto.MediaArtwork, ImageID: "ar1", Width: 100, Height: 50, Path: "images/assets/ar1.jpg"},
+			{Kind: dto.MediaScreenshot, ImageID: "sc1", Width: 100, Height: 50, Path: "images/assets/sc1.jpg"},
+		},
+	}, stored)
+
+	// IGDB is only asked once per sync
+	blue := &external.Version{ID: 2, Name: "blue", VersionGroup: external.Response{Url: "https://pokeapi.co/api/v2/version-group/1/"}}
+	require.NoError(t, syncer.InsertVersion(blue))
+	assert.Equal(t, 2, stored.VersionID)
+
+	mockIGDBClient.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestInsertVersionWithoutIGDB(t *testing.T) {
+	mockIGDBClient := new(MockIGDBClient)
+	mockIGDBClient.On("GetGames", mock.Anything, mock.Anything).Return(nil, errors.New("IGDB_CLIENT_ID and IGDB_CLIENT_SECRET must be set")).Once()
+	mockRepo := new(MockVersionRepo)
+	mockRepo.On("GetGameMappings").Return(nil, errors.New("no such table: igdb_game_mappings")).Once()
+	mockRepo.On("InsertVersion", mock.AnythingOfType("*external.Version")).Return(nil).Twice()
+
+	syncer := NewVersionSyncer(new(MockVersionAPIClient), mockIGDBClient, mockRepo, new(MockImageDownloader), time.NewTicker(time.Millisecond))
+	for id, name := range map[int]string{1: "red", 2: "blue"} {
+		v := &external.Version{ID: id, Name: name, VersionGroup: external.Response{Url: "https://pokeapi.co/api/v2/version-group/1/"}}
+		require.NoError(t, syncer.InsertVersion(v))
+		assert.Empty(t, v.Cover)
+	}
+
+	mockIGDBClient.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "InsertVersionMetadata", mock.Anything)
+}
+
 func TestSyncVersionGroup(t *testing.T) {
 	t.Run("Successfully sync a version group", func(t *testing.T) {
 		mockClient := new(MockVersionAPIClient)
@@ -146,7 +322,7 @@ func TestSyncVersionGroup(t *testing.T) {
 		rateLimiter := time.NewTicker(1 * time.Millisecond)
 
 		// Create syncer with mocks
-		syncer := NewVersionSyncer(mockClient, mockIGDBClient, mockRepo, rateLimiter)
+		syncer := NewVersionSyncer(mockClient, mockIGDBClient, mockRepo, new(MockImageDownloader), rateLimiter)
 
 		// Act
 		response, err := syncer.SyncVersionGroup(1)
@@ -197,12 +373,12 @@ func TestSyncAllVersions(t *testing.T) {
 
 		// Expect InsertVersion to be called twice
 		mockRepo.On("InsertVersion", mock.AnythingOfType("*external.Version")).Return(nil).Twice()
-		mockIGDBClient.On("GetPokemonGameCover", mock.Anything).Return(nil, nil).Twice()
+		mockIGDBClient.On("GetGames", mock.Anything, mock.Anything).Return(map[string]*igdb.Game{}, nil).Once()
 
 		rateLimiter := time.NewTicker(1 * time.Millisecond)
 
 		// Create syncer with mocks
-		syncer := NewVersionSyncer(mockClient, mockIGDBClient, mockRepo, rateLimiter)
+		syncer := NewVersionSyncer(mockClient, mockIGDBClient, mockRepo, new(MockImageDownloader), rateLimiter)
 
 		// Act
 		err := syncer.SyncAll(2)
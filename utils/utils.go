@@ -21,3 +21,16 @@ func ExtractIDFromURL(url string) (int, error) {
 	}
 	return id, nil
 }
+
+var generationNumerals = map[string]int{
+	"i": 1, "ii": 2, "iii": 3, "iv": 4, "v": 5, "vi": 6, "vii": 7, "viii": 8, "ix": 9,
+}
+
+// GenerationNumber converts a PokeAPI generation name like "generation-iv" to 4
+func GenerationNumber(generationName string) (int, error) {
+	n, ok := generationNumerals[strings.TrimPrefix(generationName, "generation-")]
+	if !ok {
+		return 0, fmt.Errorf("unknown generation %q", generationName)
+	}
+	return n, nil
+}
@@ -32,3 +32,28 @@ func TestExtractIDFromURL(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerationNumber(t *testing.T) {
+	tests := map[string]int{
+		"generation-i":    1,
+		"generation-iv":   4,
+		"generation-viii": 8,
+		"generation-ix":   9,
+	}
+
+	for name, expected := range tests {
+		t.Run(name, func(t *testing.T) {
+			n, err := GenerationNumber(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != expected {
+				t.Fatalf("Expected generation %d, but got %d", expected, n)
+			}
+		})
+	}
+
+	if _, err := GenerationNumber("generation-x"); err == nil {
+		t.Fatal("Expected error for unknown generation")
+	}
+}